@@ -11,6 +11,8 @@ import (
 // @title			Retail Vision API
 // @version		1.0
 // @description	API сервис для распознавания товаров и управления каталогом.
+// @description	Язык названий товаров и сообщений об ошибках выбирается по заголовку Accept-Language (en, ru, по умолчанию ru),
+// @description	выбранный язык возвращается в Content-Language. Ошибки содержат код error_code, не зависящий от языка.
 // @host			localhost:8080
 // @BasePath		/api/v1
 func main() {
@@ -0,0 +1,2 @@
+ALTER TABLE products ALTER COLUMN is_archived DROP NOT NULL;
+ALTER TABLE categories ALTER COLUMN is_archived DROP NOT NULL;
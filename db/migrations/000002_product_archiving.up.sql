@@ -0,0 +1,5 @@
+UPDATE products SET is_archived = false WHERE is_archived IS NULL;
+ALTER TABLE products ALTER COLUMN is_archived SET NOT NULL;
+
+UPDATE categories SET is_archived = false WHERE is_archived IS NULL;
+ALTER TABLE categories ALTER COLUMN is_archived SET NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS product_images(
+    id UUID PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    object_key VARCHAR(512) UNIQUE NOT NULL,
+    mime_type VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    embedding_id UUID UNIQUE, -- ID точки в Qdrant
+    model_version VARCHAR(64),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_product_images_product ON product_images(product_id);
@@ -0,0 +1,5 @@
+-- Индексы для постраничной выборки продуктов (keyset по полю сортировки и id)
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products(category_id, id);
+CREATE INDEX IF NOT EXISTS idx_products_price ON products(price, id);
+CREATE INDEX IF NOT EXISTS idx_products_created_at ON products(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_products_updated_at ON products((COALESCE(updated_at, created_at)), id);
@@ -0,0 +1,7 @@
+-- Иерархия категорий: корневые категории не имеют родителя
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT,
+    ADD CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT chk_categories_parent CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id);
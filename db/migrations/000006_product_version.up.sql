@@ -0,0 +1,4 @@
+-- Версия продукта увеличивается при каждом изменении и используется для оптимистичной блокировки
+-- и упорядочивания событий ProductChangeEvent на стороне потребителей
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
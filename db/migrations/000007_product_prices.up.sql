@@ -0,0 +1,17 @@
+-- История цен продукта. Запланированная цена хранится с applied_at = NULL до её применения фоновой задачей
+CREATE TABLE IF NOT EXISTS product_prices(
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price BIGINT NOT NULL CHECK (price > 0),
+    effective_from TIMESTAMP NOT NULL,
+    applied_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_prices_product_effective ON product_prices(product_id, effective_from DESC);
+CREATE INDEX IF NOT EXISTS idx_product_prices_pending ON product_prices(effective_from) WHERE applied_at IS NULL;
+
+-- Текущие цены существующих продуктов считаются действующими с момента создания продукта
+INSERT INTO product_prices (product_id, price, effective_from, applied_at)
+SELECT id, price, created_at, created_at
+FROM products;
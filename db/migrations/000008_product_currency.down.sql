@@ -0,0 +1,5 @@
+ALTER TABLE product_prices
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS currency;
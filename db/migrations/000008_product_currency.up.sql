@@ -0,0 +1,7 @@
+-- Цены хранятся в минимальных единицах валюты; валюта задаётся кодом ISO 4217.
+-- Существующие цены указаны в рублях
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE product_prices
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
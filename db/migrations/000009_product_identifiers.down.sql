@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS product_barcodes;
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_unit_check,
+    DROP CONSTRAINT IF EXISTS products_sku_key,
+    DROP COLUMN IF EXISTS unit,
+    DROP COLUMN IF EXISTS sku;
@@ -0,0 +1,17 @@
+-- Артикул и единица измерения используются кассой, когда товар не удаётся распознать по изображению
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS sku VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS unit VARCHAR(8) NOT NULL DEFAULT 'piece';
+
+ALTER TABLE products
+    ADD CONSTRAINT products_sku_key UNIQUE (sku),
+    ADD CONSTRAINT products_unit_check CHECK (unit IN ('piece', 'kg', 'l'));
+
+-- Штрихкоды EAN-8, EAN-13 и GTIN-14; UPC-A хранится в виде EAN-13 с ведущим нулём
+CREATE TABLE IF NOT EXISTS product_barcodes(
+    barcode VARCHAR(14) PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product ON product_barcodes(product_id);
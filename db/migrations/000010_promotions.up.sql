@@ -0,0 +1,28 @@
+-- Акции: процентная или фиксированная скидка и "N за M" на продукт или категорию вместе с её подкатегориями.
+-- Временные границы хранятся в UTC; ends_at не входит в период действия акции
+CREATE TABLE IF NOT EXISTS promotions(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    product_id BIGINT,
+    category_id BIGINT,
+    percent INT NOT NULL DEFAULT 0,
+    amount BIGINT,
+    currency CHAR(3),
+    buy_quantity INT NOT NULL DEFAULT 0,
+    pay_quantity INT NOT NULL DEFAULT 0,
+    priority INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    CONSTRAINT fk_promotions_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT fk_promotions_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE,
+    CONSTRAINT chk_promotions_kind CHECK (kind IN ('percent', 'fixed', 'bundle')),
+    CONSTRAINT chk_promotions_target CHECK ((product_id IS NULL) <> (category_id IS NULL)),
+    CONSTRAINT chk_promotions_window CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_product ON promotions(product_id) WHERE product_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_promotions_category ON promotions(category_id) WHERE category_id IS NOT NULL;
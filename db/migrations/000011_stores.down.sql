@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS store_products;
+DROP TABLE IF EXISTS stores;
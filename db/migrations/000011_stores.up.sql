@@ -0,0 +1,27 @@
+-- Магазины сети. Продукты общие для всех магазинов, ассортимент и цены магазина хранятся в store_products
+CREATE TABLE IF NOT EXISTS stores(
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    CONSTRAINT uq_stores_code UNIQUE (code)
+);
+
+-- Ассортимент магазина: продукт без записи в магазине не продаётся и не распознаётся.
+-- Цена магазина заменяет общую цену продукта; amount и currency задаются вместе
+CREATE TABLE IF NOT EXISTS store_products(
+    store_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    price BIGINT,
+    currency CHAR(3),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    PRIMARY KEY (store_id, product_id),
+    CONSTRAINT fk_store_products_store FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    CONSTRAINT fk_store_products_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_store_products_price CHECK ((price IS NULL) = (currency IS NULL) AND (price IS NULL OR price > 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_store_products_product ON store_products(product_id);
@@ -0,0 +1,31 @@
+-- Остатки товаров в магазинах в минимальных единицах: штуках, граммах или миллилитрах.
+-- Отсутствие записи означает, что остаток товара в магазине не учитывается
+CREATE TABLE IF NOT EXISTS stock_levels(
+    store_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    on_hand BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    PRIMARY KEY (store_id, product_id),
+    CONSTRAINT fk_stock_levels_store FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_levels_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_stock_levels_on_hand CHECK (on_hand >= 0)
+);
+
+-- Резервы остатков. Активный резерв уменьшает доступный остаток до expires_at;
+-- подтверждённый резерв списывает остаток, отменённый и просроченный не учитываются
+CREATE TABLE IF NOT EXISTS stock_reservations(
+    id UUID PRIMARY KEY,
+    store_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    CONSTRAINT fk_stock_reservations_level FOREIGN KEY (store_id, product_id) REFERENCES stock_levels(store_id, product_id) ON DELETE CASCADE,
+    CONSTRAINT chk_stock_reservations_quantity CHECK (quantity > 0),
+    CONSTRAINT chk_stock_reservations_status CHECK (status IN ('active', 'committed', 'released'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_active ON stock_reservations(store_id, product_id, expires_at) WHERE status = 'active';
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_products_variant_group;
+DROP INDEX IF EXISTS idx_products_attributes;
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS chk_products_attributes,
+    DROP CONSTRAINT IF EXISTS fk_products_variant_group,
+    DROP COLUMN IF EXISTS variant_group_id,
+    DROP COLUMN IF EXISTS attributes;
+
+DROP TABLE IF EXISTS variant_groups;
+DROP TABLE IF EXISTS category_attribute_schemas;
@@ -0,0 +1,31 @@
+-- Схема атрибутов категории — список определений атрибутов. Продукт проверяется по объединению схем
+-- своей категории и её предков; определение подкатегории заменяет одноимённое определение предка
+CREATE TABLE IF NOT EXISTS category_attribute_schemas(
+    category_id BIGINT PRIMARY KEY,
+    attributes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    CONSTRAINT fk_category_attribute_schemas_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE,
+    CONSTRAINT chk_category_attribute_schemas_attributes CHECK (jsonb_typeof(attributes) = 'array')
+);
+
+-- Группа вариантов объединяет один товар в разных фасовках; axes — коды атрибутов, различающих варианты
+CREATE TABLE IF NOT EXISTS variant_groups(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(128) NOT NULL,
+    axes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    CONSTRAINT uq_variant_groups_name UNIQUE (name)
+);
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS variant_group_id BIGINT;
+
+ALTER TABLE products
+    ADD CONSTRAINT fk_products_variant_group FOREIGN KEY (variant_group_id) REFERENCES variant_groups(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT chk_products_attributes CHECK (jsonb_typeof(attributes) = 'object');
+
+CREATE INDEX IF NOT EXISTS idx_products_attributes ON products USING GIN (attributes jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_products_variant_group ON products(variant_group_id) WHERE variant_group_id IS NOT NULL;
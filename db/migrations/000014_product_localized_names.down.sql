@@ -0,0 +1,3 @@
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS chk_products_localized_names,
+    DROP COLUMN IF EXISTS localized_names;
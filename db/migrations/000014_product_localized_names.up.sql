@@ -0,0 +1,6 @@
+-- Названия продукта для отображения на других языках: ключ — код языка, значение — название
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS localized_names JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE products
+    ADD CONSTRAINT chk_products_localized_names CHECK (jsonb_typeof(localized_names) = 'object');
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS product_transitions;
+
+DROP INDEX IF EXISTS idx_products_review_queue;
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS chk_products_status_archived,
+    DROP CONSTRAINT IF EXISTS chk_products_status,
+    DROP COLUMN IF EXISTS status;
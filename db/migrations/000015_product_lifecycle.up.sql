@@ -0,0 +1,30 @@
+-- Статус жизненного цикла продукта: draft → pending_review → active → archived.
+-- Существующие продукты уже опубликованы; is_archived сохраняется и совпадает со status = 'archived'
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active';
+
+UPDATE products SET status = 'archived' WHERE is_archived;
+
+-- Новые продукты публикуются только после проверки
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'pending_review';
+
+ALTER TABLE products
+    ADD CONSTRAINT chk_products_status CHECK (status IN ('draft', 'pending_review', 'active', 'archived')),
+    ADD CONSTRAINT chk_products_status_archived CHECK (is_archived = (status = 'archived'));
+
+-- Очередь проверки упорядочена по времени последнего изменения: отправки на проверку или доработки
+CREATE INDEX IF NOT EXISTS idx_products_review_queue ON products((COALESCE(updated_at, created_at)), id) WHERE status = 'pending_review';
+
+-- История статусов продукта с проверяющим и его комментарием
+CREATE TABLE IF NOT EXISTS product_transitions(
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    from_status VARCHAR(16) NOT NULL,
+    to_status VARCHAR(16) NOT NULL,
+    reviewer VARCHAR(128),
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_product_transitions_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_transitions_product ON product_transitions(product_id, id);
@@ -0,0 +1,12 @@
+-- Перенаправления со слитых продуктов на продукт, в который они слиты.
+-- Цепочки не хранятся: при слиянии продукта-получателя его перенаправления переводятся на новый продукт
+CREATE TABLE IF NOT EXISTS product_redirects(
+    source_id BIGINT PRIMARY KEY,
+    target_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_product_redirects_source FOREIGN KEY (source_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_redirects_target FOREIGN KEY (target_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_product_redirects_self CHECK (source_id <> target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_redirects_target ON product_redirects(target_id);
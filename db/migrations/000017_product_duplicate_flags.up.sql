@@ -0,0 +1,13 @@
+-- Вероятные дубликаты, найденные при регистрации продукта по похожести изображений.
+-- Отметки остаются для проверяющего, пока продукт или кандидат не удалены
+CREATE TABLE IF NOT EXISTS product_duplicate_flags(
+    product_id BIGINT NOT NULL,
+    candidate_id BIGINT NOT NULL,
+    score REAL NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, candidate_id),
+    CONSTRAINT fk_product_duplicate_flags_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_duplicate_flags_candidate FOREIGN KEY (candidate_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_duplicate_flags_candidate ON product_duplicate_flags(candidate_id);
@@ -0,0 +1,3 @@
+ALTER TABLE product_prices
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS attempts;
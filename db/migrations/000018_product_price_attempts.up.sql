@@ -0,0 +1,5 @@
+-- Неудачные попытки применения запланированной цены. Цена с ошибкой откладывается,
+-- чтобы не задерживать применение более поздних цен, и после исчерпания попыток больше не применяется
+ALTER TABLE product_prices
+    ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
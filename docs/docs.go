@@ -15,55 +15,316 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/products": {
+        "/categories": {
+            "get": {
+                "description": "Возвращает категории в порядке обхода дерева с путём от корня и количеством неархивных товаров",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Список категорий",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только архивные (true) или только активные (false) категории",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категории",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListCategoriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Создает новый товар в каталоге с изображениями",
+                "description": "Идемпотентно создаёт категорию: при существующем названии с тем же родителем возвращается имеющаяся категория",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "categories"
                 ],
-                "summary": "Регистрация нового товара",
+                "summary": "Создание категории",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Название товара",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
+                        "description": "Название и родитель категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Категория",
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Родительская категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     },
+                    "409": {
+                        "description": "Название занято или родитель архивный",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Возвращает категорию, включая архивную, с путём от корня и количеством неархивных товаров",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Получение категории",
+                "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "description": "Категория",
-                        "name": "category_name",
-                        "in": "formData",
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "По умолчанию категория архивируется; архивировать категорию с неархивными товарами или подкатегориями нельзя.\nПри hard=true категория удаляется безвозвратно, если в ней нет подкатегорий и ни одного товара, включая архивные.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Удаление категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "number",
-                        "description": "Цена",
-                        "name": "price",
-                        "in": "formData",
+                        "type": "boolean",
+                        "description": "Безвозвратное удаление",
+                        "name": "hard",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория после архивации",
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "Категория удалена"
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "В категории есть товары или подкатегории",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Переименование категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Изображения товара",
-                        "name": "images",
-                        "in": "formData",
+                        "description": "Новое название категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.RenameCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Название занято другой категорией",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/attributes": {
+            "get": {
+                "description": "Возвращает собственную схему атрибутов категории и определения, действующие для её товаров с учётом схем предков",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Схема атрибутов категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Успешное создание",
+                    "200": {
+                        "description": "Схема атрибутов",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/http.AttributeSchemaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет собственную схему атрибутов категории. Определение подкатегории заменяет одноимённое определение предка.\nАтрибуты существующих товаров проверяются по новой схеме при их следующем изменении.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Изменение схемы атрибутов категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Определения атрибутов",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SetAttributeSchemaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Схема атрибутов после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/http.AttributeSchemaResponse"
                         }
                     },
                     "400": {
@@ -71,20 +332,4166 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "message": {
-                    "type": "string"
+        },
+        "/categories/{id}/move": {
+            "post": {
+                "description": "Переносит категорию вместе с подкатегориями к новому родителю. parent_id = null делает категорию корневой.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Перенос категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый родитель",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.MoveCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория после переноса",
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Перенос в собственное поддерево или в архивную категорию",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/unarchive": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Восстановление категории из архива",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория после восстановления",
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Родительская категория архивная",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout": {
+            "post": {
+                "description": "Создаёт пустую сессию оформления покупки. Сессия хранится ограниченное время с момента последнего изменения.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Создание корзины",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Корзина",
+                        "schema": {
+                            "$ref": "#/definitions/http.CheckoutResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/{id}": {
+            "get": {
+                "description": "Возвращает позиции корзины и итог по текущим ценам товаров",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Получение корзины",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID корзины",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Корзина",
+                        "schema": {
+                            "$ref": "#/definitions/http.CheckoutResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена или истекла",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Позиции корзины в разных валютах",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/{id}/complete": {
+            "post": {
+                "description": "Фиксирует корзину по текущим ценам и публикует событие checkout_completed для аналитики.\nКорзина с недоступными позициями не оформляется. Если корзина оформлена, но событие не сохранилось,\nповторный запрос публикует его заново.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Оформление покупки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID корзины",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Оформленная корзина и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.CompleteCheckoutResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Корзина уже оформлена, пуста или содержит недоступные позиции",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/{id}/items": {
+            "post": {
+                "description": "Добавляет товар, выбранный по ID или отсканированный по штрихкоду. Повторное добавление штучного товара\nтем же способом увеличивает кол-во в имеющейся позиции. Для весового товара обязателен weight_grams.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Добавление товара в корзину по ID или штрихкоду",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID корзины",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Товар и его кол-во или вес",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AddCheckoutItemRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Корзина",
+                        "schema": {
+                            "$ref": "#/definitions/http.CheckoutResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина или товар не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Корзина оформлена, переполнена или изменена параллельно",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/{id}/items/recognize": {
+            "post": {
+                "description": "Распознаёт товар по одному или нескольким кадрам и добавляет его в корзину, если вердикт accepted.\nПозиция сохраняет score распознавания и версию модели. При другом вердикте корзина не изменяется,\nа кандидаты возвращаются для ручного выбора.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Добавление распознанного товара в корзину",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID корзины",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Кадры товара",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Кол-во штучного товара, по умолчанию 1",
+                        "name": "quantity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Вес весового товара в граммах",
+                        "name": "weight_grams",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ограничение поиска поддеревом категории",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "rrf",
+                            "centroid"
+                        ],
+                        "type": "string",
+                        "description": "Стратегия объединения кадров",
+                        "name": "fusion",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Макс. кол-во кандидатов",
+                        "name": "limit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Корзина и результат распознавания",
+                        "schema": {
+                            "$ref": "#/definitions/http.AddRecognizedItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Корзина оформлена, переполнена или изменена параллельно",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/{id}/items/{lineId}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Удаление позиции корзины",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID корзины",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID позиции",
+                        "name": "lineId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Корзина",
+                        "schema": {
+                            "$ref": "#/definitions/http.CheckoutResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина или позиция не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Корзина оформлена или изменена параллельно",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Изменяет кол-во штучного товара или вес весового товара в позиции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkout"
+                ],
+                "summary": "Изменение позиции корзины",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID корзины",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID позиции",
+                        "name": "lineId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое кол-во или вес",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateCheckoutLineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Корзина",
+                        "schema": {
+                            "$ref": "#/definitions/http.CheckoutResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина или позиция не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Корзина оформлена или изменена параллельно",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Возвращает страницу товаров с фильтрацией и сортировкой. Следующая страница запрашивается\nс параметром cursor из next_cursor предыдущего ответа при тех же sort и order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Список товаров",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать товары подкатегорий",
+                        "name": "include_subcategories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Код валюты ISO 4217; min_price и max_price задаются в ней, по умолчанию в RUB",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Признак архивации, по умолчанию все товары",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "pending_review",
+                            "active",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Статус товара, по умолчанию все",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Создан не раньше (RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Создан раньше (RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Изменён не раньше (RFC 3339)",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Изменён раньше (RFC 3339)",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "price",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Поле сортировки",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, макс. 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница товаров",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новый товар в каталоге с изображениями. Новый товар ожидает проверки (pending_review)\nи не участвует в распознавании, пока его не одобрят.\nТовар с существующим названием изменяется, только если If-Match содержит его текущую версию.\nИзображения нового товара сравниваются с опубликованными товарами. При похожести не ниже порога\nрегистрация по умолчанию отклоняется с 409 и списком вероятных дубликатов; при on_duplicate=review товар создаётся,\nа вероятные дубликаты возвращаются в поле Duplicates и сохраняются для проверяющего.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Регистрация нового товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название товара",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Категория",
+                        "name": "category_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Цена в основных единицах валюты",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Переводы названия JSON-объектом по кодам языков en и ru",
+                        "name": "localized_names",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Код валюты ISO 4217, по умолчанию RUB",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Артикул; пустое значение удаляет артикул существующего товара",
+                        "name": "sku",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Штрихкоды EAN-8, UPC-A, EAN-13 или GTIN-14",
+                        "name": "barcode",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "piece",
+                            "kg",
+                            "l"
+                        ],
+                        "type": "string",
+                        "description": "Единица измерения, по умолчанию piece",
+                        "name": "unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Атрибуты товара JSON-объектом по схеме категории",
+                        "name": "attributes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID группы вариантов; 0 исключает существующий товар из группы",
+                        "name": "variant_group_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Изображения товара",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "reject",
+                            "review"
+                        ],
+                        "type": "string",
+                        "description": "Действие при вероятном дубликате: reject – отказ, review – отметка для проверяющего",
+                        "name": "on_duplicate",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag существующего товара",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Успешное создание",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа вариантов не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже существует, артикул или штрихкод занят, вариант уже есть в группе, версия не совпадает с If-Match или товар похож на опубликованные (possible_duplicate, заполняется duplicates)",
+                        "schema": {
+                            "$ref": "#/definitions/http.DuplicateProductsErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/barcodes/{barcode}": {
+            "get": {
+                "description": "Возвращает неархивный товар по штрихкоду EAN-8, UPC-A, EAN-13 или GTIN-14.\nИспользуется кассой, если товар не удалось распознать по фото.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Поиск товара по штрихкоду",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Штрихкод",
+                        "name": "barcode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный штрихкод",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/review-queue": {
+            "get": {
+                "description": "Возвращает страницу товаров, ожидающих проверки, начиная с давно не изменявшихся.\nСледующая страница запрашивается с параметром cursor из next_cursor предыдущего ответа.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Очередь проверки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, макс. 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница очереди",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Возвращает товар, включая архивный. Заголовок ETag содержит версию товара для If-Match при изменении.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получение товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductDetailsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия товара"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "По умолчанию товар архивируется: он перестаёт распознаваться и отдаваться в информации о товарах.\nАрхивировать можно только опубликованный товар (active).\nПри hard=true товар удаляется безвозвратно вместе с векторами и изображениями.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удаление товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Безвозвратное удаление",
+                        "name": "hard",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID события изменения товара",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Частично изменяет название, переводы названия, цену, категорию, артикул, штрихкоды, единицу измерения, атрибуты и группу вариантов товара\nбез изменения изображений. Атрибуты проверяются по схеме категории товара.\nТовар изменяется, только если его версия совпадает с переданным в If-Match значением ETag.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Изменение товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag товара",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateProductResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия товара"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или группа вариантов не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Название, артикул или штрихкод заняты другим товаром, вариант уже есть в группе или версия не совпадает",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Не передан If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/approve": {
+            "post": {
+                "description": "Публикует товар из очереди проверки: он и его векторы начинают участвовать в распознавании.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Одобрение товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Проверяющий и комментарий",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новый статус товара",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReviewResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/duplicates": {
+            "get": {
+                "description": "Возвращает опубликованные товары, на которые похож товар, зарегистрированный с on_duplicate=review,\nначиная с самого похожего. Дубликат объединяется с существующим товаром через слияние.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Вероятные дубликаты товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вероятные дубликаты",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListDuplicateFlagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "post": {
+                "description": "Загружает изображения существующего товара и добавляет их векторы для распознавания",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Добавление изображений товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Изображения товара",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID добавленных изображений",
+                        "schema": {
+                            "$ref": "#/definitions/http.AddProductImagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/{imageId}": {
+            "delete": {
+                "description": "Удаляет изображение товара и его вектор. ID изображения совпадает с ID вектора.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удаление изображения товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID изображения",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID события изменения товара",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или изображение не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/merge": {
+            "post": {
+                "description": "Переносит изображения и векторы товара из пути запроса товару target_id и архивирует исходный товар.\nПо ID исходного товара информация о товарах возвращает товар target_id. Восстановить слитый товар нельзя.\nСлияние отклоняется, если у товара target_id вместе с перенесёнными станет больше 10 изображений.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Слияние товара-дубликата",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара-дубликата",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID товара, в который сливается дубликат",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.MergeProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат слияния",
+                        "schema": {
+                            "$ref": "#/definitions/http.MergeProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или у товара target_id станет больше 10 изображений",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже слит или товар target_id в архиве",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices": {
+            "get": {
+                "description": "Возвращает применённые и запланированные цены товара, начиная с самой поздней",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "История цен товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Цены товара",
+                        "schema": {
+                            "$ref": "#/definitions/http.PriceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Сохраняет цену, которая будет применена к товару в момент effective_from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Планирование цены товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Цена и время начала её действия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SchedulePriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Запланированная цена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices/by-weight": {
+            "get": {
+                "description": "Рассчитывает стоимость товара, продаваемого на вес, по цене за килограмм и показанию весов в граммах.\nСумма округляется до минимальной единицы валюты (половина — вверх), но не меньше одной минимальной единицы.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Стоимость весового товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Показание весов в граммах",
+                        "name": "weight_grams",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Стоимость товара",
+                        "schema": {
+                            "$ref": "#/definitions/http.WeightedPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или товар продаётся не на вес",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices/effective": {
+            "get": {
+                "description": "Возвращает цену товара, действующую в момент at. Для будущего момента учитываются запланированные цены.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Цена товара на момент времени",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Момент времени в формате RFC 3339, по умолчанию текущий",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Действующая цена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден или не имел цены в этот момент",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices/{priceId}": {
+            "delete": {
+                "description": "Удаляет ещё не применённую цену товара",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Отмена запланированной цены",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID цены",
+                        "name": "priceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Цена отменена"
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Запланированная цена не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/reject": {
+            "post": {
+                "description": "Возвращает товар из очереди проверки на доработку (draft). Комментарий обязателен.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Отклонение товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Проверяющий и причина отклонения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новый статус товара",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReviewResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/submit": {
+            "post": {
+                "description": "Переводит отклонённый товар (draft) обратно в очередь проверки. Новые товары попадают в очередь сразу при регистрации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Отправка товара на проверку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий к доработке",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новый статус товара",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReviewResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/transitions": {
+            "get": {
+                "description": "Возвращает переходы статусов товара с проверяющими и комментариями, начиная с самого раннего.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "История статусов товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История статусов",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListProductTransitionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/unarchive": {
+            "post": {
+                "description": "Возвращает архивный товар в статус active.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Восстановление товара из архива",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID события изменения товара",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недопустимый переход статуса, товар слит или категория в архиве",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "Возвращает все акции по возрастанию ID или только действующие в момент active_at",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Список акций",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Момент времени в формате RFC 3339",
+                        "name": "active_at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Акции",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListPromotionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт процентную или фиксированную скидку либо акцию \"N за M\" на товар или категорию вместе с подкатегориями.\nПериод действия задаётся необязательными starts_at и ends_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Создание акции",
+                "parameters": [
+                    {
+                        "description": "Параметры акции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Акция",
+                        "schema": {
+                            "$ref": "#/definitions/http.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/evaluate": {
+            "post": {
+                "description": "Применяет действующие акции к корзине или к списку товаров с кол-вом или весом.\nК позиции применяется одна акция с наибольшей скидкой; при равной скидке — с большим приоритетом,\nзатем акция на товар раньше акции на категорию, затем акция с меньшим ID.\nДля каждой позиции возвращаются все подходящие акции с результатом проверки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Расчёт скидок",
+                "parameters": [
+                    {
+                        "description": "Корзина или список товаров",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.EvaluatePromotionsRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Позиции со скидками и итоги",
+                        "schema": {
+                            "$ref": "#/definitions/http.DiscountedCartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Позиции в разных валютах",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Получение акции",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID акции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Акция",
+                        "schema": {
+                            "$ref": "#/definitions/http.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Акция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Удаление акции",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID акции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Акция удалена"
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Акция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recognize": {
+            "post": {
+                "description": "Векторизует один или несколько кадров одного товара и возвращает наиболее похожие товары каталога.\nРезультаты кадров объединяются стратегией fusion: rrf – поиск по каждому кадру и Reciprocal Rank Fusion, centroid – поиск по усреднённому вектору.\nverdict: accepted – первый кандидат распознан уверенно, variant – уверенно распознана группа вариантов (variant_group), но не вариант,\nambiguous – требуется выбор кассира, unknown – товар не найден.\nВариант группы уточняется повторным распознаванием с фильтром attributes по осям группы.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recognition"
+                ],
+                "summary": "Распознавание товара по фото",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Кадры товара",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Макс. кол-во кандидатов",
+                        "name": "limit",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "rrf",
+                            "centroid"
+                        ],
+                        "type": "string",
+                        "description": "Стратегия объединения кадров",
+                        "name": "fusion",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Поиск только среди товаров категории и её подкатегорий",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Добавить к кандидатам остатки магазина; требует X-Store-ID",
+                        "name": "include_stock",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поиск только среди товаров с указанными значениями атрибутов, JSON-объект",
+                        "name": "attributes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Кандидаты распознавания",
+                        "schema": {
+                            "$ref": "#/definitions/http.RecognizeProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Список магазинов",
+                "responses": {
+                    "200": {
+                        "description": "Магазины",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListStoresResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Создание магазина",
+                "parameters": [
+                    {
+                        "description": "Код и название магазина",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreateStoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Магазин",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Код магазина занят",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Получение магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Магазин",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/products": {
+            "get": {
+                "description": "Возвращает товары ассортимента магазина по возрастанию ID товара, включая недоступные для продажи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Ассортимент магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ассортимент",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListStoreProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/products/{productId}": {
+            "put": {
+                "description": "Добавляет товар в ассортимент магазина или изменяет его доступность и цену в магазине.\nТовар, доступный в магазине, распознаётся в нём и возвращается с ценой магазина.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Добавление товара в ассортимент магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Доступность и цена в магазине",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SetStoreProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар в ассортименте и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или товар не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Исключение товара из ассортимента магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID события",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или товар в ассортименте не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/reservations/{reservationId}": {
+            "delete": {
+                "description": "Отменяет активный резерв, в том числе просроченный",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Отмена резерва",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID резерва",
+                        "name": "reservationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остаток, резерв и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Резерв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Резерв уже подтверждён или отменён",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/reservations/{reservationId}/commit": {
+            "post": {
+                "description": "Списывает зарезервированное кол-во с остатка. Просроченный резерв подтвердить нельзя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Подтверждение резерва",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID резерва",
+                        "name": "reservationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остаток, резерв и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Резерв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Резерв подтверждён, отменён или просрочен",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/stock": {
+            "get": {
+                "description": "Возвращает остатки товаров магазина по возрастанию ID товара. Остатки указаны в штуках, граммах или миллилитрах.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Остатки магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остатки",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListStockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/stock/{productId}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Остаток товара в магазине",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остаток",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockLevelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Остаток товара в магазине не учитывается",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Задаёт фактический остаток товара в магазине по результатам инвентаризации или приёмки.\nДля товара, остаток которого не учитывался, учёт начинается. Активные резервы сохраняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Установка остатка",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Фактический остаток",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SetStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остаток и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или товар не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/stock/{productId}/decrement": {
+            "post": {
+                "description": "Списывает доступный остаток без резерва, например при продаже.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Списание остатка",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Кол-во для списания",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.StockQuantityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остаток и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Остаток товара в магазине не учитывается",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недостаточно остатка",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/stock/{productId}/reservations": {
+            "post": {
+                "description": "Резервирует доступный остаток. Резерв, не подтверждённый и не отменённый до expires_at, перестаёт учитываться.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Резервирование остатка",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Кол-во для резервирования",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.StockQuantityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Остаток, резерв и ID события",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Остаток товара в магазине не учитывается",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недостаточно остатка",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/variant-groups": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variant-groups"
+                ],
+                "summary": "Список групп вариантов",
+                "responses": {
+                    "200": {
+                        "description": "Группы вариантов",
+                        "schema": {
+                            "$ref": "#/definitions/http.ListVariantGroupsResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт группу вариантов одного товара, например разных объёмов. Варианты группы различаются значениями атрибутов-осей.\nТовары добавляются в группу полем variant_group_id при регистрации или изменении товара.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variant-groups"
+                ],
+                "summary": "Создание группы вариантов",
+                "parameters": [
+                    {
+                        "description": "Название и оси группы",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreateVariantGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Группа вариантов",
+                        "schema": {
+                            "$ref": "#/definitions/http.VariantGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Название занято",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/variant-groups/{id}": {
+            "get": {
+                "description": "Возвращает группу с её неархивными товарами. С X-Store-ID возвращаются только варианты из ассортимента магазина.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variant-groups"
+                ],
+                "summary": "Получение группы вариантов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Группа вариантов",
+                        "schema": {
+                            "$ref": "#/definitions/http.VariantGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.AddCheckoutItemRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4601234567893"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "weight_grams": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.AddProductImagesResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.AddRecognizedItemResponse": {
+            "type": "object",
+            "properties": {
+                "checkout": {
+                    "$ref": "#/definitions/http.CheckoutResponse"
+                },
+                "line_id": {
+                    "type": "string"
+                },
+                "recognition": {
+                    "$ref": "#/definitions/http.RecognizeProductResponse"
+                }
+            }
+        },
+        "http.AttributeDefinition": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "volume"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Объём"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "bool",
+                        "enum"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "l"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.AttributeSchemaResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttributeDefinition"
+                    }
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "effective": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttributeDefinition"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CheckoutLineResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "boolean"
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "recognition",
+                        "id",
+                        "barcode"
+                    ]
+                },
+                "model_version": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/http.ProductResponse"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "recognition_score": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_display": {
+                    "type": "string",
+                    "example": "119.98 RUB"
+                },
+                "weight_grams": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.CheckoutResponse": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CheckoutLineResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed"
+                    ]
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_display": {
+                    "type": "string",
+                    "example": "119.98 RUB"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CompleteCheckoutResponse": {
+            "type": "object",
+            "properties": {
+                "checkout": {
+                    "$ref": "#/definitions/http.CheckoutResponse"
+                },
+                "event_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CreateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.CreatePromotionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 50
+                },
+                "buy_quantity": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed",
+                        "bundle"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pay_quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "percent": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CreateStoreRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "msk-001"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.CreateVariantGroupRequest": {
+            "type": "object",
+            "properties": {
+                "axes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "volume"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Молоко 3,2%"
+                }
+            }
+        },
+        "http.DiscountedCartResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "evaluated_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DiscountedLineResponse"
+                    }
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_display": {
+                    "type": "string",
+                    "example": "107.98 RUB"
+                }
+            }
+        },
+        "http.DiscountedLineResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "line_id": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/http.ProductResponse"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PromotionEvaluationResponse"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "weight_grams": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.DuplicateCandidateResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.97
+                }
+            }
+        },
+        "http.DuplicateFlagResponse": {
+            "type": "object",
+            "properties": {
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.97
+                }
+            }
+        },
+        "http.DuplicateProductsErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "duplicates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DuplicateCandidateResponse"
+                    }
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "possible_duplicate"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "product_not_found"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.EvaluatePromotionsRequest": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "checkout_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PromotionItemRequest"
+                    }
+                }
+            }
+        },
+        "http.ListCategoriesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CategoryResponse"
+                    }
+                }
+            }
+        },
+        "http.ListDuplicateFlagsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DuplicateFlagResponse"
+                    }
+                }
+            }
+        },
+        "http.ListProductTransitionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductTransitionResponse"
+                    }
+                }
+            }
+        },
+        "http.ListProductsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductDetailsResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.ListPromotionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PromotionResponse"
+                    }
+                }
+            }
+        },
+        "http.ListStockResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StockLevelResponse"
+                    }
+                }
+            }
+        },
+        "http.ListStoreProductsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StoreProductResponse"
+                    }
+                }
+            }
+        },
+        "http.ListStoresResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StoreResponse"
+                    }
+                }
+            }
+        },
+        "http.ListVariantGroupsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.VariantGroupResponse"
+                    }
+                }
+            }
+        },
+        "http.MergeProductRequest": {
+            "type": "object",
+            "properties": {
+                "target_id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "http.MergeProductResponse": {
+            "type": "object",
+            "properties": {
+                "embedding_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_event_id": {
+                    "type": "string"
+                },
+                "source_id": {
+                    "type": "integer"
+                },
+                "source_version": {
+                    "type": "integer"
+                },
+                "target_event_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "target_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.MoveCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.PriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPriceResponse"
+                    }
+                }
+            }
+        },
+        "http.ProductDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "is_weighted": {
+                    "type": "boolean"
+                },
+                "localized_names": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "en": "Milk 3.2%"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_display": {
+                    "type": "string",
+                    "example": "599.99 RUB"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MLK-3.2-1L"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "active",
+                        "archived"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "piece",
+                        "kg",
+                        "l"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variant_group_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_scheduled": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_display": {
+                    "type": "string",
+                    "example": "499.99 RUB"
+                },
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_weighted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_display": {
+                    "type": "string",
+                    "example": "599.99 RUB"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MLK-3.2-1L"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "piece",
+                        "kg",
+                        "l"
+                    ]
+                },
+                "variant_group_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.ProductTransitionResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "active",
+                        "archived"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "active",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "http.PromotionEvaluationResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer"
+                },
+                "discount_display": {
+                    "type": "string",
+                    "example": "12.00 RUB"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed",
+                        "bundle"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "applied",
+                        "outranked",
+                        "not_applicable"
+                    ]
+                },
+                "promotion_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.PromotionItemRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "weight_grams": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_display": {
+                    "type": "string",
+                    "example": "50.00 RUB"
+                },
+                "buy_quantity": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed",
+                        "bundle"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pay_quantity": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.RecognitionCandidateResponse": {
+            "type": "object",
+            "properties": {
+                "hits": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/http.ProductResponse"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "stock": {
+                    "$ref": "#/definitions/http.StockLevelResponse"
+                }
+            }
+        },
+        "http.RecognizeProductResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.RecognitionCandidateResponse"
+                    }
+                },
+                "model_version": {
+                    "type": "string"
+                },
+                "variant_group": {
+                    "$ref": "#/definitions/http.VariantGroupResponse"
+                },
+                "verdict": {
+                    "type": "string",
+                    "enum": [
+                        "accepted",
+                        "variant",
+                        "ambiguous",
+                        "unknown"
+                    ]
+                }
+            }
+        },
+        "http.RenameCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.ReviewRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Нужно фото упаковки со штрихкодом"
+                },
+                "reviewer": {
+                    "type": "string",
+                    "example": "moderator@drsn.tech"
+                }
+            }
+        },
+        "http.ReviewResultResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "active",
+                        "archived"
+                    ]
+                },
+                "transition": {
+                    "$ref": "#/definitions/http.ProductTransitionResponse"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.SchedulePriceRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 499.99
+                }
+            }
+        },
+        "http.SetAttributeSchemaRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttributeDefinition"
+                    }
+                }
+            }
+        },
+        "http.SetStockRequest": {
+            "type": "object",
+            "properties": {
+                "on_hand": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "http.SetStoreProductRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 589.99
+                }
+            }
+        },
+        "http.StockChangeResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "reservation": {
+                    "$ref": "#/definitions/http.StockReservationResponse"
+                },
+                "stock": {
+                    "$ref": "#/definitions/http.StockLevelResponse"
+                }
+            }
+        },
+        "http.StockLevelResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "in_stock": {
+                    "type": "boolean"
+                },
+                "on_hand": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.StockQuantityRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.StockReservationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "committed",
+                        "released"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.StoreProductResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_display": {
+                    "type": "string",
+                    "example": "589.99 RUB"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.StoreResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "msk-001"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.UpdateCheckoutLineRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "weight_grams": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.UpdateProductRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "localized_names": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "en": "Milk 3.2%"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 599.99
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MLK-3.2-1L"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "piece",
+                        "kg",
+                        "l"
+                    ]
+                },
+                "variant_group_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.UpdateProductResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/http.ProductDetailsResponse"
+                }
+            }
+        },
+        "http.VariantGroupResponse": {
+            "type": "object",
+            "properties": {
+                "axes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductResponse"
+                    }
+                }
+            }
+        },
+        "http.WeightedPriceResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_display": {
+                    "type": "string",
+                    "example": "123.45 RUB"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "kg"
+                },
+                "unit_price": {
+                    "type": "integer"
+                },
+                "weight_grams": {
+                    "type": "integer"
                 }
             }
         }
@@ -98,7 +4505,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "Retail Vision API",
-	Description:      "API сервис для распознавания товаров и управления каталогом.",
+	Description:      "API сервис для распознавания товаров и управления каталогом.\nЯзык названий товаров и сообщений об ошибках выбирается по заголовку Accept-Language (en, ru, по умолчанию ru),\nвыбранный язык возвращается в Content-Language. Ошибки содержат код error_code, не зависящий от языка.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
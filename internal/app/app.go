@@ -15,6 +15,7 @@ import (
 	"github.com/DRSN-tech/go-backend/internal/infrastructure/kafka"
 	minioInfra "github.com/DRSN-tech/go-backend/internal/infrastructure/minio"
 	ml_service "github.com/DRSN-tech/go-backend/internal/infrastructure/ml-service"
+	"github.com/DRSN-tech/go-backend/internal/infrastructure/pricing"
 	"github.com/DRSN-tech/go-backend/internal/proto"
 	s3Repo "github.com/DRSN-tech/go-backend/internal/repository/minio"
 	"github.com/DRSN-tech/go-backend/internal/repository/pgdb"
@@ -53,6 +54,7 @@ type App struct {
 	imagesInfra  *minioInfra.MinioInfrastructure
 	outboxWorker *kafka.OutboxWorker
 	workerCancel context.CancelFunc
+	priceWorker  *pricing.PriceWorker
 
 	// Servers
 	httpSrv *v1Http.Server
@@ -273,14 +275,37 @@ func (a *App) initServers() error {
 	prConv := &pgdbConv.ProductConverterImpl{}
 	infoConv := &redisConv.ProductInfoConverterImpl{}
 	outboxConv := &pgdbConv.OutboxEventConverterImpl{}
+	imageMetaConv := &pgdbConv.ImageMetaConverterImpl{}
+	priceConv := &pgdbConv.ProductPriceConverterImpl{}
+	checkoutConv := &redisConv.CheckoutConverterImpl{}
+	promoConv := &pgdbConv.PromotionConverterImpl{}
+	storeConv := &pgdbConv.StoreConverterImpl{}
+	stockConv := &pgdbConv.StockConverterImpl{}
+	storeProductConv := &redisConv.StoreProductConverterImpl{}
+	attributeConv := &pgdbConv.AttributeSchemaConverterImpl{}
+	variantConv := &pgdbConv.VariantGroupConverterImpl{}
+	transitionConv := &pgdbConv.ProductTransitionConverterImpl{}
+	redirectConv := &pgdbConv.ProductRedirectConverterImpl{}
+	duplicateConv := &pgdbConv.DuplicateFlagConverterImpl{}
 
 	// Repositories
 	productRepo := pgdb.NewProductRepo(a.db.Pool, prConv)
 	categoryRepo := pgdb.NewCategoryRepo(a.db.Pool, catConv)
+	imageMetaRepo := pgdb.NewImageMetaRepo(a.db.Pool, imageMetaConv)
+	priceRepo := pgdb.NewPriceRepo(a.db.Pool, priceConv)
 	outboxRepo := pgdb.NewOutboxEventRepo(a.db.Pool, outboxConv)
+	promoRepo := pgdb.NewPromotionRepo(a.db.Pool, promoConv)
+	storeRepo := pgdb.NewStoreRepo(a.db.Pool, storeConv)
+	stockRepo := pgdb.NewStockRepo(a.db.Pool, stockConv)
+	attributeRepo := pgdb.NewAttributeSchemaRepo(a.db.Pool, attributeConv)
+	variantRepo := pgdb.NewVariantGroupRepo(a.db.Pool, variantConv, prConv)
+	transitionRepo := pgdb.NewProductTransitionRepo(a.db.Pool, transitionConv)
+	redirectRepo := pgdb.NewProductRedirectRepo(a.db.Pool, redirectConv)
+	duplicateRepo := pgdb.NewDuplicateFlagRepo(a.db.Pool, duplicateConv)
 	imageRepo := s3Repo.NewImageRepo(a.minioClient, a.cfg.Minio)
 	embRepo := qdrantRepo.NewEmbeddingRepo(a.qdrantClient.Client, a.cfg.Qdrant)
-	cacheRepo := redis.NewCacheRepo(a.redisClient, infoConv, a.cfg.Redis, a.logger)
+	cacheRepo := redis.NewCacheRepo(a.redisClient, infoConv, storeProductConv, a.cfg.Redis, a.logger)
+	checkoutRepo := redis.NewCheckoutRepo(a.redisClient, checkoutConv, a.cfg.Redis, a.logger)
 
 	// Infrastructure
 	mlClient := proto.NewMachineLearningServiceClient(a.grpcConn)
@@ -308,6 +333,15 @@ func (a *App) initServers() error {
 	productUC := usecase.NewProductUC(
 		productRepo,
 		categoryRepo,
+		attributeRepo,
+		variantRepo,
+		transitionRepo,
+		redirectRepo,
+		duplicateRepo,
+		imageMetaRepo,
+		priceRepo,
+		storeRepo,
+		stockRepo,
 		a.db.Pool,
 		ml,
 		a.imagesInfra,
@@ -316,11 +350,27 @@ func (a *App) initServers() error {
 		cacheRepo,
 		a.producer,
 		outboxRepo,
+		a.cfg.Recognition,
 	)
+	categoryUC := usecase.NewCategoryUC(categoryRepo, attributeRepo, a.db.Pool, cacheRepo, a.logger)
+	checkoutUC := usecase.NewCheckoutUC(productUC, checkoutRepo, outboxRepo, a.producer, a.db.Pool, a.logger)
+	promoUC := usecase.NewPromotionUC(promoRepo, productUC, checkoutUC, a.db.Pool, a.logger)
+	storeUC := usecase.NewStoreUC(storeRepo, productRepo, embRepo, cacheRepo, outboxRepo, a.producer, a.db.Pool, a.logger)
+	stockUC := usecase.NewStockUC(stockRepo, storeRepo, outboxRepo, a.producer, a.db.Pool, a.cfg.Stock, a.logger)
+
+	// Price worker
+	a.priceWorker = pricing.NewPriceWorker(productUC, a.logger, a.cfg.Pricing)
+	a.priceWorker.Start(workerCtx)
+	a.logger.Infof("Price worker started")
+
+	a.closer.Add(func(ctx context.Context) error {
+		a.priceWorker.Stop()
+		return nil
+	})
 
 	// gRPC Server
-	a.grpcSrv = v1Grpc.NewGRPCServer(a.cfg.Grpc)
-	a.grpcSrv.RegisterServices(productUC, a.logger)
+	a.grpcSrv = v1Grpc.NewGRPCServer(a.cfg.Grpc, storeUC, a.logger)
+	a.grpcSrv.RegisterServices(productUC, categoryUC, a.logger)
 	a.closer.Add(func(ctx context.Context) error {
 		return a.grpcSrv.Stop(ctx)
 	})
@@ -328,7 +378,7 @@ func (a *App) initServers() error {
 	// HTTP Server
 	r := chi.NewRouter()
 	router := v1Http.NewRouter(r, a.logger)
-	router.Init(productUC)
+	router.Init(productUC, categoryUC, checkoutUC, promoUC, storeUC, stockUC)
 	a.httpSrv = v1Http.NewServer(r, a.cfg.Http)
 	a.closer.Add(func(ctx context.Context) error {
 		return a.httpSrv.Stop(ctx)
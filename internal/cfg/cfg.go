@@ -22,10 +22,16 @@ type Config struct {
 	Redis  *RedisCfg
 	Ml     *MLServiceCfg
 	Kafka  *KafkaCfg
+
+	Recognition *RecognitionCfg
+	Pricing     *PricingCfg
+	Stock       *StockCfg
 }
 
 type KafkaCfg struct {
 	Topic             string
+	AnalyticsTopic    string // Топик аналитических событий, например checkout_completed
+	StockTopic        string // Топик изменений остатков товаров в магазинах
 	Brokers           []string
 	NetworkMode       string
 	Partitions        int
@@ -80,6 +86,7 @@ type RedisCfg struct {
 	DialTimeout time.Duration
 	Timeout     time.Duration
 	ProductTTL  time.Duration
+	CheckoutTTL time.Duration // Время жизни сессии оформления покупки с момента последнего изменения
 }
 
 type MLServiceCfg struct {
@@ -88,6 +95,42 @@ type MLServiceCfg struct {
 	MaxRetries    int
 }
 
+type RecognitionCfg struct {
+	SearchLimit        uint64  // Кол-во ближайших векторов, запрашиваемых у Qdrant
+	MaxCandidates      int     // Макс. кол-во продуктов-кандидатов в ответе
+	Aggregation        string  // Стратегия агрегации score по продукту: max, mean_top_n, vote
+	TopN               int     // N для стратегий mean_top_n и vote
+	AcceptThreshold    float32 // Мин. score лучшего кандидата для вердикта accepted
+	RejectThreshold    float32 // Score ниже порога означает неизвестный продукт
+	MinMargin          float32 // Мин. отрыв лучшего кандидата от второго для вердикта accepted
+	Fusion             string  // Стратегия объединения нескольких кадров: rrf, centroid
+	MaxFrames          int     // Макс. кол-во кадров в одном запросе распознавания
+	StableFrames       int     // Кол-во подряд идущих кадров потока с одним и тем же accepted-продуктом для признака стабильности
+	DuplicateThreshold float32 // Мин. score существующего продукта, при котором регистрируемый продукт считается его дубликатом
+}
+
+type PricingCfg struct {
+	SchedulerInterval  time.Duration // Период проверки запланированных цен, наступивших к текущему моменту
+	SchedulerBatchSize int           // Макс. кол-во запланированных цен, применяемых за один проход
+}
+
+type StockCfg struct {
+	ReservationTTL time.Duration // Время, в течение которого резерв остатка учитывается без подтверждения
+}
+
+// Стратегии агрегации score распознавания по продукту
+const (
+	AggregationMax      = "max"
+	AggregationMeanTopN = "mean_top_n"
+	AggregationVote     = "vote"
+)
+
+// Стратегии объединения результатов распознавания нескольких кадров
+const (
+	FusionRRF      = "rrf"
+	FusionCentroid = "centroid"
+)
+
 // Load безопасно загружает конфигурацию и возвращает ошибку в случае неудачи.
 func Load(log logger.Logger) (*Config, error) {
 	db, err := loadPGDBCfg(log)
@@ -120,6 +163,21 @@ func Load(log logger.Logger) (*Config, error) {
 		return nil, e.Wrap(whereami.WhereAmI(), err)
 	}
 
+	recognition, err := loadRecognitionCfg(log)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	pricing, err := loadPricingCfg(log)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	stock, err := loadStockCfg(log)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
 	return &Config{
 		Minio:  minio,
 		Http:   http,
@@ -129,6 +187,10 @@ func Load(log logger.Logger) (*Config, error) {
 		Redis:  redis,
 		Ml:     loadMLServiceCfg(),
 		Kafka:  kafka,
+
+		Recognition: recognition,
+		Pricing:     pricing,
+		Stock:       stock,
 	}, nil
 }
 
@@ -137,6 +199,8 @@ func loadKafkaCfg() (*KafkaCfg, error) {
 		defaultPartitions        = 3
 		defaultReplicationFactor = 1
 		defaultNetworkMode       = "tcp"
+		defaultAnalyticsTopic    = "analytics_events"
+		defaultStockTopic        = "stock_changes"
 	)
 
 	brokerStr := os.Getenv("KAFKA_BROKERS")
@@ -164,10 +228,14 @@ func loadKafkaCfg() (*KafkaCfg, error) {
 	}
 
 	networkMode := getEnvOrDefault("KAFKA_NETWORK_MODE", defaultNetworkMode)
+	analyticsTopic := getEnvOrDefault("KAFKA_ANALYTICS_TOPIC", defaultAnalyticsTopic)
+	stockTopic := getEnvOrDefault("KAFKA_STOCK_TOPIC", defaultStockTopic)
 
 	return &KafkaCfg{
 		Brokers:           brokers,
 		Topic:             topic,
+		AnalyticsTopic:    analyticsTopic,
+		StockTopic:        stockTopic,
 		Partitions:        partitions,
 		ReplicationFactor: replicationFactor,
 		NetworkMode:       networkMode,
@@ -328,6 +396,7 @@ func loadRedisCfg(log logger.Logger) (*RedisCfg, error) {
 		defaultReadTimeout  = 3 * time.Second
 		defaultWriteTimeout = 3 * time.Second
 		defaultProductTTL   = 3 * time.Minute
+		defaultCheckoutTTL  = 30 * time.Minute
 	)
 
 	addr := getEnvOrDefault("REDIS_ADDR", defaultAddr)
@@ -372,6 +441,12 @@ func loadRedisCfg(log logger.Logger) (*RedisCfg, error) {
 		return nil, err
 	}
 
+	checkoutTTL, err := parseDurationEnv("CHECKOUT_SESSION_TTL", defaultCheckoutTTL)
+	if err != nil || checkoutTTL <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid CHECKOUT_SESSION_TTL")
+		return nil, e.Wrap("CHECKOUT_SESSION_TTL", e.ErrIncorrectEnvVariable)
+	}
+
 	timeout := readTimeout
 	if writeTimeout > timeout {
 		timeout = writeTimeout
@@ -386,6 +461,7 @@ func loadRedisCfg(log logger.Logger) (*RedisCfg, error) {
 		DialTimeout: dialTimeout,
 		Timeout:     timeout,
 		ProductTTL:  productTTL,
+		CheckoutTTL: checkoutTTL,
 	}, nil
 }
 
@@ -407,6 +483,147 @@ func loadMLServiceCfg() *MLServiceCfg {
 	}
 }
 
+func loadRecognitionCfg(log logger.Logger) (*RecognitionCfg, error) {
+	const (
+		defaultSearchLimit        = 50
+		defaultMaxCandidates      = 5
+		defaultAggregation        = AggregationMax
+		defaultTopN               = 3
+		defaultAcceptThreshold    = 0.85
+		defaultRejectThreshold    = 0.6
+		defaultMinMargin          = 0.05
+		defaultFusion             = FusionRRF
+		defaultMaxFrames          = 8
+		defaultStableFrames       = 3
+		defaultDuplicateThreshold = 0.95
+	)
+
+	searchLimit, err := parseIntEnv("RECOGNITION_SEARCH_LIMIT", defaultSearchLimit)
+	if err != nil || searchLimit <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_SEARCH_LIMIT")
+		return nil, e.Wrap("RECOGNITION_SEARCH_LIMIT", e.ErrIncorrectEnvVariable)
+	}
+
+	maxCandidates, err := parseIntEnv("RECOGNITION_MAX_CANDIDATES", defaultMaxCandidates)
+	if err != nil || maxCandidates <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_MAX_CANDIDATES")
+		return nil, e.Wrap("RECOGNITION_MAX_CANDIDATES", e.ErrIncorrectEnvVariable)
+	}
+
+	aggregation := getEnvOrDefault("RECOGNITION_AGGREGATION", defaultAggregation)
+	switch aggregation {
+	case AggregationMax, AggregationMeanTopN, AggregationVote:
+	default:
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_AGGREGATION")
+		return nil, e.Wrap("RECOGNITION_AGGREGATION", e.ErrIncorrectEnvVariable)
+	}
+
+	topN, err := parseIntEnv("RECOGNITION_TOP_N", defaultTopN)
+	if err != nil || topN <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_TOP_N")
+		return nil, e.Wrap("RECOGNITION_TOP_N", e.ErrIncorrectEnvVariable)
+	}
+
+	acceptThreshold, err := parseFloatEnv("RECOGNITION_ACCEPT_THRESHOLD", defaultAcceptThreshold)
+	if err != nil || acceptThreshold <= 0 || acceptThreshold > 1 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_ACCEPT_THRESHOLD")
+		return nil, e.Wrap("RECOGNITION_ACCEPT_THRESHOLD", e.ErrIncorrectEnvVariable)
+	}
+
+	rejectThreshold, err := parseFloatEnv("RECOGNITION_REJECT_THRESHOLD", defaultRejectThreshold)
+	if err != nil || rejectThreshold < 0 || rejectThreshold > 1 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_REJECT_THRESHOLD")
+		return nil, e.Wrap("RECOGNITION_REJECT_THRESHOLD", e.ErrIncorrectEnvVariable)
+	}
+
+	if rejectThreshold > acceptThreshold {
+		log.Errorf(e.ErrIncorrectEnvVariable, "RECOGNITION_REJECT_THRESHOLD must not exceed RECOGNITION_ACCEPT_THRESHOLD")
+		return nil, e.Wrap("RECOGNITION_REJECT_THRESHOLD", e.ErrIncorrectEnvVariable)
+	}
+
+	minMargin, err := parseFloatEnv("RECOGNITION_MIN_MARGIN", defaultMinMargin)
+	if err != nil {
+		log.Errorf(err, "invalid RECOGNITION_MIN_MARGIN")
+		return nil, err
+	}
+
+	fusion := getEnvOrDefault("RECOGNITION_FUSION", defaultFusion)
+	if fusion != FusionRRF && fusion != FusionCentroid {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_FUSION")
+		return nil, e.Wrap("RECOGNITION_FUSION", e.ErrIncorrectEnvVariable)
+	}
+
+	maxFrames, err := parseIntEnv("RECOGNITION_MAX_FRAMES", defaultMaxFrames)
+	if err != nil || maxFrames <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_MAX_FRAMES")
+		return nil, e.Wrap("RECOGNITION_MAX_FRAMES", e.ErrIncorrectEnvVariable)
+	}
+
+	stableFrames, err := parseIntEnv("RECOGNITION_STABLE_FRAMES", defaultStableFrames)
+	if err != nil || stableFrames <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_STABLE_FRAMES")
+		return nil, e.Wrap("RECOGNITION_STABLE_FRAMES", e.ErrIncorrectEnvVariable)
+	}
+
+	duplicateThreshold, err := parseFloatEnv("RECOGNITION_DUPLICATE_THRESHOLD", defaultDuplicateThreshold)
+	if err != nil || duplicateThreshold <= 0 || duplicateThreshold > 1 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid RECOGNITION_DUPLICATE_THRESHOLD")
+		return nil, e.Wrap("RECOGNITION_DUPLICATE_THRESHOLD", e.ErrIncorrectEnvVariable)
+	}
+
+	return &RecognitionCfg{
+		SearchLimit:        uint64(searchLimit),
+		MaxCandidates:      maxCandidates,
+		Aggregation:        aggregation,
+		TopN:               topN,
+		AcceptThreshold:    acceptThreshold,
+		RejectThreshold:    rejectThreshold,
+		MinMargin:          minMargin,
+		Fusion:             fusion,
+		MaxFrames:          maxFrames,
+		StableFrames:       stableFrames,
+		DuplicateThreshold: duplicateThreshold,
+	}, nil
+}
+
+func loadPricingCfg(log logger.Logger) (*PricingCfg, error) {
+	const (
+		defaultSchedulerInterval  = 30 * time.Second
+		defaultSchedulerBatchSize = 100
+	)
+
+	interval, err := parseDurationEnv("PRICE_SCHEDULER_INTERVAL", defaultSchedulerInterval)
+	if err != nil || interval <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid PRICE_SCHEDULER_INTERVAL")
+		return nil, e.Wrap("PRICE_SCHEDULER_INTERVAL", e.ErrIncorrectEnvVariable)
+	}
+
+	batchSize, err := parseIntEnv("PRICE_SCHEDULER_BATCH_SIZE", defaultSchedulerBatchSize)
+	if err != nil || batchSize <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid PRICE_SCHEDULER_BATCH_SIZE")
+		return nil, e.Wrap("PRICE_SCHEDULER_BATCH_SIZE", e.ErrIncorrectEnvVariable)
+	}
+
+	return &PricingCfg{
+		SchedulerInterval:  interval,
+		SchedulerBatchSize: batchSize,
+	}, nil
+}
+
+func loadStockCfg(log logger.Logger) (*StockCfg, error) {
+	const defaultReservationTTL = 15 * time.Minute
+
+	reservationTTL, err := parseDurationEnv("STOCK_RESERVATION_TTL", defaultReservationTTL)
+	if err != nil || reservationTTL <= 0 {
+		log.Errorf(e.ErrIncorrectEnvVariable, "invalid STOCK_RESERVATION_TTL")
+		return nil, e.Wrap("STOCK_RESERVATION_TTL", e.ErrIncorrectEnvVariable)
+	}
+
+	return &StockCfg{
+		ReservationTTL: reservationTTL,
+	}, nil
+}
+
 // getEnv возвращает значение переменной окружения.
 // Возвращает пустую строку, если переменная не задана.
 func getEnv(key string) string {
@@ -444,3 +661,18 @@ func parseIntEnv(key string, defaultValue int) (int, error) {
 
 	return intValue, nil
 }
+
+// parseFloatEnv считывает число с плавающей точкой или возвращает значение по умолчанию.
+func parseFloatEnv(key string, defaultValue float32) (float32, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue, nil
+	}
+
+	floatValue, err := strconv.ParseFloat(v, 32)
+	if err != nil {
+		return defaultValue, e.Wrap(key, e.ErrIncorrectEnvVariable)
+	}
+
+	return float32(floatValue), nil
+}
@@ -0,0 +1,170 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/proto"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CategoryService struct {
+	proto.UnimplementedCategoryServiceServer
+	catUC  usecase.CategoryUC
+	logger logger.Logger
+}
+
+func NewCategoryService(catUC usecase.CategoryUC, logger logger.Logger) *CategoryService {
+	return &CategoryService{catUC: catUC, logger: logger}
+}
+
+func (g *CategoryService) CreateCategory(ctx context.Context, req *proto.CreateCategoryRequest) (*proto.Category, error) {
+	const op = "grpc.CreateCategory"
+
+	category, err := g.catUC.CreateCategory(ctx, usecase.NewCreateCategoryReq(req.Name, req.ParentId))
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCCategory(category), nil
+}
+
+func (g *CategoryService) GetCategory(ctx context.Context, req *proto.GetCategoryRequest) (*proto.Category, error) {
+	const op = "grpc.GetCategory"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	details, err := g.catUC.GetCategory(ctx, req.Id)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCCategoryDetails(details), nil
+}
+
+func (g *CategoryService) ListCategories(ctx context.Context, req *proto.ListCategoriesRequest) (*proto.ListCategoriesResponse, error) {
+	const op = "grpc.ListCategories"
+
+	categories, err := g.catUC.ListCategories(ctx, req.Archived)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	res := make([]*proto.Category, 0, len(categories))
+	for _, category := range categories {
+		res = append(res, toGRPCCategoryDetails(&category))
+	}
+
+	return &proto.ListCategoriesResponse{Categories: res}, nil
+}
+
+func (g *CategoryService) RenameCategory(ctx context.Context, req *proto.RenameCategoryRequest) (*proto.Category, error) {
+	const op = "grpc.RenameCategory"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	category, err := g.catUC.RenameCategory(ctx, req.Id, req.Name)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCCategory(category), nil
+}
+
+func (g *CategoryService) MoveCategory(ctx context.Context, req *proto.MoveCategoryRequest) (*proto.Category, error) {
+	const op = "grpc.MoveCategory"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	category, err := g.catUC.MoveCategory(ctx, req.Id, req.ParentId)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCCategory(category), nil
+}
+
+func (g *CategoryService) ArchiveCategory(ctx context.Context, req *proto.ArchiveCategoryRequest) (*proto.Category, error) {
+	const op = "grpc.ArchiveCategory"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	category, err := g.catUC.ArchiveCategory(ctx, req.Id)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCCategory(category), nil
+}
+
+func (g *CategoryService) UnarchiveCategory(ctx context.Context, req *proto.UnarchiveCategoryRequest) (*proto.Category, error) {
+	const op = "grpc.UnarchiveCategory"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	category, err := g.catUC.UnarchiveCategory(ctx, req.Id)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCCategory(category), nil
+}
+
+func (g *CategoryService) DeleteCategory(ctx context.Context, req *proto.DeleteCategoryRequest) (*proto.DeleteCategoryResponse, error) {
+	const op = "grpc.DeleteCategory"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	if err := g.catUC.DeleteCategory(ctx, req.Id); err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return &proto.DeleteCategoryResponse{}, nil
+}
+
+func toGRPCCategory(category *domain.Category) *proto.Category {
+	res := &proto.Category{
+		Id:         category.ID,
+		Name:       category.Name,
+		ParentId:   category.ParentID,
+		IsArchived: category.IsArchived,
+		CreatedAt:  timestamppb.New(category.CreatedAt),
+	}
+
+	if category.UpdatedAt != nil {
+		res.UpdatedAt = timestamppb.New(*category.UpdatedAt)
+	}
+
+	return res
+}
+
+func toGRPCCategoryDetails(details *usecase.CategoryDetails) *proto.Category {
+	res := toGRPCCategory(details.Category)
+	res.Path = details.Path
+	res.ProductCount = details.ProductCount
+
+	return res
+}
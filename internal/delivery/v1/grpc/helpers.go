@@ -1,19 +1,107 @@
 package grpc
 
 import (
+	"context"
 	"errors"
 
 	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/locale"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-// GRPCErrorResponse формирует ошибку для gRPC
-func GRPCErrorResponse(err error) error {
+// errorDomain — домен кодов ошибок в errdetails.ErrorInfo
+const errorDomain = "go-backend.drsn.tech"
+
+// GRPCErrorResponse формирует ошибку для gRPC с сообщением на языке клиента из контекста.
+// Код ошибки передаётся в деталях статуса как errdetails.ErrorInfo.Reason.
+func GRPCErrorResponse(ctx context.Context, err error) error {
+	return grpcErrorStatus(ctx, err).Err()
+}
+
+// grpcErrorStatus формирует gRPC-статус ошибки, который передаётся клиенту целиком или внутри сообщения потока.
+func grpcErrorStatus(ctx context.Context, err error) *status.Status {
+	code, respErr := grpcStatus(err)
+
+	st := status.New(code, e.Message(respErr, locale.FromCtxOrDefault(ctx)))
+	if detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{Reason: e.Code(respErr), Domain: errorDomain}); detailsErr == nil {
+		st = detailed
+	}
+
+	return st
+}
+
+// grpcStatus сопоставляет ошибку с gRPC-кодом и ошибкой с кодом, которая попадает в ответ
+func grpcStatus(err error) (codes.Code, error) {
 	switch {
 	case errors.Is(err, e.ErrNoProducts):
-		return status.Error(codes.NotFound, err.Error())
+		return codes.NotFound, e.ErrNoProducts
+	case errors.Is(err, e.ErrProductNotFound):
+		return codes.NotFound, e.ErrProductNotFound
+	case errors.Is(err, e.ErrCategoryNotFound):
+		return codes.NotFound, e.ErrCategoryNotFound
+	case errors.Is(err, e.ErrStoreNotFound):
+		return codes.NotFound, e.ErrStoreNotFound
+	case errors.Is(err, e.ErrCategoryNameTaken):
+		return codes.AlreadyExists, e.ErrCategoryNameTaken
+	case errors.Is(err, e.ErrCategoryHasProducts):
+		return codes.FailedPrecondition, e.ErrCategoryHasProducts
+	case errors.Is(err, e.ErrCategoryArchived):
+		return codes.FailedPrecondition, e.ErrCategoryArchived
+	case errors.Is(err, e.ErrInvalidTransition):
+		return codes.FailedPrecondition, e.ErrInvalidTransition
+	case errors.Is(err, e.ErrProductMerged):
+		return codes.FailedPrecondition, e.ErrProductMerged
+	case errors.Is(err, e.ErrCategoryHasChildren):
+		return codes.FailedPrecondition, e.ErrCategoryHasChildren
+	case errors.Is(err, e.ErrCategoryCycle):
+		return codes.FailedPrecondition, e.ErrCategoryCycle
+	case errors.Is(err, e.ErrCategoryNameRequired):
+		return codes.InvalidArgument, e.ErrCategoryNameRequired
+	case errors.Is(err, e.ErrNoChanges):
+		return codes.FailedPrecondition, e.ErrNoChanges
+	case errors.Is(err, e.ErrInvalidID):
+		return codes.InvalidArgument, e.ErrInvalidID
+	case errors.Is(err, e.ErrInvalidStore):
+		return codes.InvalidArgument, e.ErrInvalidStore
+	case errors.Is(err, e.ErrStoreRequired):
+		return codes.InvalidArgument, e.ErrStoreRequired
+	case errors.Is(err, e.ErrUnknownAttribute):
+		return codes.InvalidArgument, e.ErrUnknownAttribute
+	case errors.Is(err, e.ErrInvalidAttribute):
+		return codes.InvalidArgument, e.ErrInvalidAttribute
+	case errors.Is(err, e.ErrStoreMismatch):
+		return codes.FailedPrecondition, e.ErrStoreMismatch
+	case errors.Is(err, e.ErrInvalidCursor):
+		return codes.InvalidArgument, e.ErrInvalidCursor
+	case errors.Is(err, e.ErrInvalidSort):
+		return codes.InvalidArgument, e.ErrInvalidSort
+	case errors.Is(err, e.ErrInvalidFilter):
+		return codes.InvalidArgument, e.ErrInvalidFilter
+	case errors.Is(err, e.ErrUnsupportedCurrency):
+		return codes.InvalidArgument, e.ErrUnsupportedCurrency
+	case errors.Is(err, e.ErrUnsupportedLocale):
+		return codes.InvalidArgument, e.ErrUnsupportedLocale
+	case errors.Is(err, e.ErrInvalidBarcode):
+		return codes.InvalidArgument, e.ErrInvalidBarcode
+	case errors.Is(err, e.ErrInvalidWeight):
+		return codes.InvalidArgument, e.ErrInvalidWeight
+	case errors.Is(err, e.ErrProductNotWeighted):
+		return codes.FailedPrecondition, e.ErrProductNotWeighted
+	case errors.Is(err, e.ErrNoImages):
+		return codes.InvalidArgument, e.ErrNoImages
+	case errors.Is(err, e.ErrUnsupportedMediaType):
+		return codes.InvalidArgument, e.ErrUnsupportedMediaType
+	case errors.Is(err, e.ErrInvalidLimit):
+		return codes.InvalidArgument, e.ErrInvalidLimit
+	case errors.Is(err, e.ErrTooManyFrames):
+		return codes.InvalidArgument, e.ErrTooManyFrames
+	case errors.Is(err, e.ErrInvalidFusion):
+		return codes.InvalidArgument, e.ErrInvalidFusion
+	case errors.Is(err, e.ErrVectorEmbeddingEmpty):
+		return codes.InvalidArgument, e.ErrVectorEmbeddingEmpty
 	default:
-		return status.Error(codes.Internal, e.ErrInternalServerError.Error())
+		return codes.Internal, e.ErrInternalServerError
 	}
 }
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/pkg/locale"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// acceptLanguageMetadataKey — ключ метаданных с языками, которые предпочитает клиент, в формате Accept-Language
+	acceptLanguageMetadataKey = "accept-language"
+	// contentLanguageMetadataKey — ключ заголовка ответа с языком ответа
+	contentLanguageMetadataKey = "content-language"
+)
+
+// localeUnaryInterceptor выбирает язык ответа по метаданным accept-language.
+func localeUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = localeContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(contentLanguageMetadataKey, locale.FromCtxOrDefault(ctx)))
+
+		return handler(ctx, req)
+	}
+}
+
+// localeStreamInterceptor выбирает язык ответа потока по метаданным accept-language.
+func localeStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := localeContext(stream.Context())
+		_ = stream.SetHeader(metadata.Pairs(contentLanguageMetadataKey, locale.FromCtxOrDefault(ctx)))
+
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// localeContext добавляет в контекст язык клиента. Если ни один из языков клиента не поддерживается, контекст не меняется.
+func localeContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	values := md.Get(acceptLanguageMetadataKey)
+	if len(values) == 0 {
+		return ctx
+	}
+
+	lang, ok := locale.Negotiate(values[0])
+	if !ok {
+		return ctx
+	}
+
+	return locale.WithLocale(ctx, lang)
+}
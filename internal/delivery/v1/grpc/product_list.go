@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/proto"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (g *ProductService) ListProducts(ctx context.Context, req *proto.ListProductsRequest) (*proto.ListProductsResponse, error) {
+	const op = "grpc.ListProducts"
+
+	currency, err := toCurrency(req.Currency)
+	if err != nil {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	filter := usecase.ProductFilter{
+		CategoryID:           req.CategoryId,
+		IncludeSubcategories: req.IncludeSubcategories,
+		Currency:             currency,
+		MinPrice:             req.MinPrice,
+		MaxPrice:             req.MaxPrice,
+		Archived:             req.Archived,
+		CreatedFrom:          toTime(req.CreatedFrom),
+		CreatedTo:            toTime(req.CreatedTo),
+		UpdatedFrom:          toTime(req.UpdatedFrom),
+		UpdatedTo:            toTime(req.UpdatedTo),
+	}
+
+	res, err := g.prUC.ListProducts(ctx, usecase.NewListProductsReq(filter, toSortField(req.SortBy), req.Descending, int(req.Limit), req.Cursor))
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	products := make([]*proto.CatalogProduct, 0, len(res.Products))
+	for _, product := range res.Products {
+		products = append(products, toGRPCCatalogProduct(&product))
+	}
+
+	return &proto.ListProductsResponse{
+		Products:   products,
+		NextCursor: res.NextCursor,
+	}, nil
+}
+
+func toSortField(field proto.ProductSortField) usecase.ProductSortField {
+	switch field {
+	case proto.ProductSortField_PRODUCT_SORT_FIELD_NAME:
+		return usecase.SortByName
+	case proto.ProductSortField_PRODUCT_SORT_FIELD_PRICE:
+		return usecase.SortByPrice
+	case proto.ProductSortField_PRODUCT_SORT_FIELD_CREATED_AT:
+		return usecase.SortByCreatedAt
+	case proto.ProductSortField_PRODUCT_SORT_FIELD_UPDATED_AT:
+		return usecase.SortByUpdatedAt
+	default:
+		return usecase.SortByID
+	}
+}
+
+func toGRPCCatalogProduct(details *usecase.ProductDetails) *proto.CatalogProduct {
+	product := &proto.CatalogProduct{
+		Id:           details.Product.ID,
+		Name:         details.Product.Name,
+		CategoryId:   details.Product.CategoryID,
+		CategoryName: details.CategoryName,
+		Price:        details.Product.Price.Amount,
+		Currency:     string(details.Product.Price.Currency),
+		Sku:          details.Product.SKU,
+		Barcodes:     details.Product.Barcodes,
+		Unit:         string(details.Product.Unit),
+		IsWeighted:   details.Product.Unit.IsWeighted(),
+		IsArchived:   details.Product.IsArchived,
+		Status:       string(details.Product.Status),
+		CreatedAt:    timestamppb.New(details.Product.CreatedAt),
+		Version:      details.Product.Version,
+	}
+
+	if details.Product.UpdatedAt != nil {
+		product.UpdatedAt = timestamppb.New(*details.Product.UpdatedAt)
+	}
+
+	return product
+}
+
+// toCurrency проверяет необязательный код валюты запроса.
+func toCurrency(code *string) (*domain.Currency, error) {
+	if code == nil {
+		return nil, nil
+	}
+
+	currency, err := domain.ParseCurrency(*code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currency, nil
+}
+
+// toTime преобразует необязательный timestamp запроса во время UTC.
+func toTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime().UTC()
+	return &t
+}
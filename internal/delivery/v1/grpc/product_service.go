@@ -2,11 +2,16 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/internal/proto"
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/e"
 	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type ProductService struct {
@@ -35,21 +40,237 @@ func (g *ProductService) GetProductsInfo(ctx context.Context, req *proto.Product
 	res, err := g.prUC.GetProductsInfo(ctx, usecase.NewGetProductsReq(uniqueProducts))
 	if err != nil {
 		g.logger.Errorf(e.Wrap(op, err), "%s", op)
-		return nil, GRPCErrorResponse(e.Wrap(op, err))
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
 	}
 
 	return &proto.ProductsInfoResponse{
 		Products:         toArrGRPCProduct(res.Products),
 		ProductsNotFound: res.NotFoundProducts,
+		Redirects:        res.Redirects,
 	}, nil
 }
 
+func (g *ProductService) GetProductByBarcode(ctx context.Context, req *proto.GetProductByBarcodeRequest) (*proto.Product, error) {
+	const op = "grpc.GetProductByBarcode"
+
+	product, err := g.prUC.GetProductByBarcode(ctx, req.Barcode)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCProduct(product), nil
+}
+
+func (g *ProductService) PriceByWeight(ctx context.Context, req *proto.PriceByWeightRequest) (*proto.PriceByWeightResponse, error) {
+	const op = "grpc.PriceByWeight"
+
+	if req.ProductId <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	res, err := g.prUC.PriceByWeight(ctx, usecase.NewPriceByWeightReq(req.ProductId, req.WeightGrams))
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return &proto.PriceByWeightResponse{
+		Product:     toGRPCProduct(&res.Product),
+		WeightGrams: res.WeightGrams,
+		Total:       res.Total.Amount,
+		Currency:    string(res.Total.Currency),
+	}, nil
+}
+
+func (g *ProductService) RecognizeProduct(ctx context.Context, req *proto.RecognizeProductRequest) (*proto.RecognizeProductResponse, error) {
+	const op = "grpc.RecognizeProduct"
+
+	frames := make([]usecase.ProductImage, 0, len(req.Frames)+1)
+	if len(req.ImageData) > 0 {
+		frames = append(frames, toProductImage(req.ImageData, "grpc-image"))
+	}
+	for i, frame := range req.Frames {
+		frames = append(frames, toProductImage(frame, fmt.Sprintf("grpc-frame-%d", i)))
+	}
+
+	var attrs domain.Attributes
+	if req.Attributes != nil {
+		attrs = req.Attributes.AsMap()
+	}
+
+	res, err := g.prUC.RecognizeProduct(ctx, usecase.NewRecognizeProductReq(
+		frames, int(req.Limit), toFusion(req.Fusion), req.CategoryId, req.IncludeStock, attrs,
+	))
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCRecognizeProductResponse(res), nil
+}
+
+func (g *ProductService) ArchiveProduct(ctx context.Context, req *proto.ArchiveProductRequest) (*proto.ProductEventResponse, error) {
+	const op = "grpc.ArchiveProduct"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	event, err := g.prUC.ArchiveProduct(ctx, req.Id)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCProductEventResponse(event), nil
+}
+
+func (g *ProductService) UnarchiveProduct(ctx context.Context, req *proto.UnarchiveProductRequest) (*proto.ProductEventResponse, error) {
+	const op = "grpc.UnarchiveProduct"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	event, err := g.prUC.UnarchiveProduct(ctx, req.Id)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCProductEventResponse(event), nil
+}
+
+func (g *ProductService) DeleteProduct(ctx context.Context, req *proto.DeleteProductRequest) (*proto.ProductEventResponse, error) {
+	const op = "grpc.DeleteProduct"
+
+	if req.Id <= 0 {
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, e.ErrInvalidID))
+	}
+
+	event, err := g.prUC.DeleteProduct(ctx, req.Id)
+	if err != nil {
+		g.logger.Errorf(e.Wrap(op, err), "%s", op)
+		return nil, GRPCErrorResponse(ctx, e.Wrap(op, err))
+	}
+
+	return toGRPCProductEventResponse(event), nil
+}
+
+func toGRPCProductEventResponse(event *usecase.OutboxEvent) *proto.ProductEventResponse {
+	return &proto.ProductEventResponse{EventId: event.EventID.String()}
+}
+
+// toProductImage формирует изображение из байтов запроса, определяя MIME-тип по содержимому.
+func toProductImage(data []byte, name string) usecase.ProductImage {
+	mimeType := http.DetectContentType(data[:min(len(data), 512)])
+	return *usecase.NewProductImage(data, mimeType, int64(len(data)), name)
+}
+
+func toGRPCRecognizeProductResponse(res *usecase.RecognizeProductRes) *proto.RecognizeProductResponse {
+	candidates := make([]*proto.RecognitionCandidate, len(res.Candidates))
+	for i, c := range res.Candidates {
+		candidates[i] = &proto.RecognitionCandidate{
+			Product: toGRPCProduct(&c.Product),
+			Score:   c.Score,
+			Hits:    int32(c.Hits),
+			Stock:   toGRPCStockLevel(c.Stock),
+		}
+	}
+
+	return &proto.RecognizeProductResponse{
+		Verdict:      toGRPCVerdict(res.Verdict),
+		Candidates:   candidates,
+		ModelVersion: res.ModelVersion,
+		VariantGroup: toGRPCVariantGroup(res.VariantGroup),
+	}
+}
+
+// toGRPCVariantGroup возвращает nil, если группа не распознана.
+func toGRPCVariantGroup(details *usecase.VariantGroupDetails) *proto.VariantGroup {
+	if details == nil {
+		return nil
+	}
+
+	return &proto.VariantGroup{
+		Id:       details.Group.ID,
+		Name:     details.Group.Name,
+		Axes:     details.Group.Axes,
+		Variants: toArrGRPCProduct(details.Variants),
+	}
+}
+
+// toGRPCAttributes возвращает nil для продукта без атрибутов. Значения атрибутов — строки,
+// числа и bool, поэтому преобразование в Struct не завершается ошибкой.
+func toGRPCAttributes(attrs domain.Attributes) *structpb.Struct {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	res, err := structpb.NewStruct(attrs)
+	if err != nil {
+		return nil
+	}
+
+	return res
+}
+
+// toGRPCStockLevel возвращает nil для товара без учёта остатка.
+func toGRPCStockLevel(level *domain.StockLevel) *proto.StockLevel {
+	if level == nil {
+		return nil
+	}
+
+	return &proto.StockLevel{
+		OnHand:    level.OnHand,
+		Reserved:  level.Reserved,
+		Available: level.Available(),
+		InStock:   level.InStock(),
+	}
+}
+
+func toFusion(fusion proto.FusionStrategy) string {
+	switch fusion {
+	case proto.FusionStrategy_FUSION_STRATEGY_RRF:
+		return cfg.FusionRRF
+	case proto.FusionStrategy_FUSION_STRATEGY_CENTROID:
+		return cfg.FusionCentroid
+	default:
+		return ""
+	}
+}
+
+func toGRPCVerdict(verdict usecase.RecognitionVerdict) proto.RecognitionVerdict {
+	switch verdict {
+	case usecase.VerdictAccepted:
+		return proto.RecognitionVerdict_RECOGNITION_VERDICT_ACCEPTED
+	case usecase.VerdictAmbiguous:
+		return proto.RecognitionVerdict_RECOGNITION_VERDICT_AMBIGUOUS
+	case usecase.VerdictVariant:
+		return proto.RecognitionVerdict_RECOGNITION_VERDICT_VARIANT
+	case usecase.VerdictUnknown:
+		return proto.RecognitionVerdict_RECOGNITION_VERDICT_UNKNOWN
+	default:
+		return proto.RecognitionVerdict_RECOGNITION_VERDICT_UNSPECIFIED
+	}
+}
+
 func toGRPCProduct(pr *usecase.ProductInfo) *proto.Product {
 	return &proto.Product{
-		Id:       pr.ID,
-		Name:     pr.Name,
-		Category: pr.CategoryName,
-		Price:    pr.Price,
+		Id:             pr.ID,
+		Name:           pr.Name,
+		Category:       pr.CategoryName,
+		Price:          pr.Price.Amount,
+		Currency:       string(pr.Price.Currency),
+		CategoryPath:   pr.CategoryPath,
+		Version:        pr.Version,
+		Sku:            pr.SKU,
+		Barcodes:       pr.Barcodes,
+		Unit:           string(pr.Unit),
+		IsWeighted:     pr.Unit.IsWeighted(),
+		Attributes:     toGRPCAttributes(pr.Attributes),
+		VariantGroupId: pr.VariantGroupID,
 	}
 }
 
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/DRSN-tech/go-backend/internal/proto"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"google.golang.org/grpc/status"
+)
+
+// StreamRecognize распознаёт товар в потоке кадров с камеры кассы.
+// На каждый обработанный кадр сервер отправляет обновление с кандидатами и признаком стабильности.
+// Пока кадр обрабатывается, в памяти хранится не более одного ожидающего кадра:
+// более старый ожидающий кадр заменяется свежим, а счётчик пропущенных кадров возвращается клиенту.
+// Ошибка распознавания кадра не завершает поток: клиент получает обновление этого кадра со статусом ошибки.
+func (g *ProductService) StreamRecognize(stream proto.ProductService_StreamRecognizeServer) error {
+	const op = "grpc.StreamRecognize"
+	ctx := stream.Context()
+
+	frames := make(chan *proto.RecognitionFrame, 1)
+	recvErr := make(chan error, 1)
+	var dropped atomic.Int64
+
+	// Единственный писатель в frames, поэтому отправка после вычитывания старого кадра не блокируется
+	go func() {
+		defer close(frames)
+		for {
+			frame, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case frames <- frame:
+			default:
+				select {
+				case <-frames:
+					dropped.Add(1)
+				default:
+				}
+				frames <- frame
+			}
+		}
+	}()
+
+	tracker := g.prUC.NewRecognitionTracker()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case frame, ok := <-frames:
+			if !ok {
+				if err := <-recvErr; !errors.Is(err, io.EOF) {
+					return err
+				}
+				return nil
+			}
+
+			image := toProductImage(frame.ImageData, fmt.Sprintf("grpc-stream-frame-%d", frame.FrameId))
+			var update *proto.RecognitionUpdate
+			res, err := g.prUC.RecognizeProduct(ctx, usecase.NewRecognizeProductReq([]usecase.ProductImage{image}, int(frame.Limit), "", frame.CategoryId, frame.IncludeStock, nil))
+			if err != nil {
+				if ctx.Err() != nil {
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				g.logger.Errorf(e.Wrap(op, err), "%s: frame_id: %d", op, frame.FrameId)
+				update = toGRPCRecognitionErrorUpdate(frame.FrameId, grpcErrorStatus(ctx, e.Wrap(op, err)), dropped.Load())
+			} else {
+				update = toGRPCRecognitionUpdate(frame.FrameId, res, tracker.Observe(res), dropped.Load())
+			}
+
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toGRPCRecognitionUpdate(frameID int64, res *usecase.RecognizeProductRes, stability usecase.RecognitionStability, dropped int64) *proto.RecognitionUpdate {
+	return &proto.RecognitionUpdate{
+		FrameId:         frameID,
+		Result:          toGRPCRecognizeProductResponse(res),
+		Stable:          stability.Stable,
+		StableProductId: stability.ProductID,
+		Streak:          int32(stability.Streak),
+		DroppedFrames:   dropped,
+	}
+}
+
+// toGRPCRecognitionErrorUpdate формирует обновление кадра, распознать который не удалось.
+// Серия стабильности при этом не меняется: ошибочный кадр не учитывается трекером.
+func toGRPCRecognitionErrorUpdate(frameID int64, st *status.Status, dropped int64) *proto.RecognitionUpdate {
+	return &proto.RecognitionUpdate{
+		FrameId:       frameID,
+		DroppedFrames: dropped,
+		Error:         st.Proto(),
+	}
+}
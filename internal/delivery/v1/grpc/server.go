@@ -18,15 +18,21 @@ type GRPCServer struct {
 	cfg    *cfg.GRPCConfig
 }
 
-func NewGRPCServer(cfg *cfg.GRPCConfig) *GRPCServer {
+// NewGRPCServer создаёт gRPC-сервер. Язык из метаданных accept-language и магазин из x-store-id
+// проверяются перехватчиками и передаются в контексте вызова.
+func NewGRPCServer(cfg *cfg.GRPCConfig, storeUC usecase.StoreUC, logger logger.Logger) *GRPCServer {
 	return &GRPCServer{
-		server: grpc.NewServer(),
-		cfg:    cfg,
+		server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(localeUnaryInterceptor(), storeUnaryInterceptor(storeUC, logger)),
+			grpc.ChainStreamInterceptor(localeStreamInterceptor(), storeStreamInterceptor(storeUC, logger)),
+		),
+		cfg: cfg,
 	}
 }
 
-func (s *GRPCServer) RegisterServices(prUC usecase.ProductUC, logger logger.Logger) {
+func (s *GRPCServer) RegisterServices(prUC usecase.ProductUC, catUC usecase.CategoryUC, logger logger.Logger) {
 	proto.RegisterProductServiceServer(s.server, NewProductService(prUC, logger))
+	proto.RegisterCategoryServiceServer(s.server, NewCategoryService(catUC, logger))
 }
 
 func (s *GRPCServer) Start() error {
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/DRSN-tech/go-backend/pkg/storectx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// storeIDMetadataKey — ключ метаданных с ID магазина, ассортиментом которого ограничивается вызов
+const storeIDMetadataKey = "x-store-id"
+
+// storeUnaryInterceptor ограничивает вызов ассортиментом магазина из метаданных.
+func storeUnaryInterceptor(storeUC usecase.StoreUC, logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		storeCtx, err := storeContext(ctx, storeUC)
+		if err != nil {
+			logger.Warnf("%s: %s", info.FullMethod, err.Error())
+			return nil, GRPCErrorResponse(ctx, err)
+		}
+
+		return handler(storeCtx, req)
+	}
+}
+
+// storeStreamInterceptor ограничивает поток ассортиментом магазина из метаданных.
+func storeStreamInterceptor(storeUC usecase.StoreUC, logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := storeContext(stream.Context(), storeUC)
+		if err != nil {
+			logger.Warnf("%s: %s", info.FullMethod, err.Error())
+			return GRPCErrorResponse(stream.Context(), err)
+		}
+
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// storeContext проверяет магазин из метаданных и добавляет его в контекст. Вызов без магазина не ограничивается.
+func storeContext(ctx context.Context, storeUC usecase.StoreUC) (context.Context, error) {
+	const op = "grpc.storeContext"
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	values := md.Get(storeIDMetadataKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	storeID, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || storeID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	if _, err := storeUC.GetStore(ctx, storeID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return storectx.WithStoreID(ctx, storeID), nil
+}
+
+// contextStream подменяет контекст потока контекстом, дополненным перехватчиком
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
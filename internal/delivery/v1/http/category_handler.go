@@ -0,0 +1,350 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUC
+	logger          logger.Logger
+}
+
+func NewCategoryHandler(categoryUsecase usecase.CategoryUC, logger logger.Logger) *CategoryHandler {
+	return &CategoryHandler{categoryUsecase: categoryUsecase, logger: logger}
+}
+
+// createCategory
+//
+//	@Summary		Создание категории
+//	@Description	Идемпотентно создаёт категорию: при существующем названии с тем же родителем возвращается имеющаяся категория
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateCategoryRequest	true	"Название и родитель категории"
+//	@Success		201		{object}	CategoryResponse		"Категория"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Родительская категория не найдена"
+//	@Failure		409		{object}	ErrorResponse			"Название занято или родитель архивный"
+//	@Router			/categories [post]
+func (c *CategoryHandler) createCategory(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req CreateCategoryRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	category, err := c.categoryUsecase.CreateCategory(r.Context(), usecase.NewCreateCategoryReq(req.Name, req.ParentID))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toCategoryResponse(category))
+}
+
+// listCategories
+//
+//	@Summary		Список категорий
+//	@Description	Возвращает категории в порядке обхода дерева с путём от корня и количеством неархивных товаров
+//	@Tags			categories
+//	@Produce		json
+//	@Param			archived	query		bool					false	"Только архивные (true) или только активные (false) категории"
+//	@Success		200			{object}	ListCategoriesResponse	"Категории"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Router			/categories [get]
+func (c *CategoryHandler) listCategories(w http.ResponseWriter, r *http.Request) {
+	archived, err := parseOptionalBool(r.URL.Query().Get("archived"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	categories, err := c.categoryUsecase.ListCategories(r.Context(), archived)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListCategoriesResponse(categories))
+}
+
+// getCategory
+//
+//	@Summary		Получение категории
+//	@Description	Возвращает категорию, включая архивную, с путём от корня и количеством неархивных товаров
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id	path		int					true	"ID категории"
+//	@Success		200	{object}	CategoryResponse	"Категория"
+//	@Failure		400	{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse		"Категория не найдена"
+//	@Router			/categories/{id} [get]
+func (c *CategoryHandler) getCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	details, err := c.categoryUsecase.GetCategory(r.Context(), id)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCategoryDetailsResponse(details))
+}
+
+// renameCategory
+//
+//	@Summary		Переименование категории
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"ID категории"
+//	@Param			request	body		RenameCategoryRequest	true	"Новое название категории"
+//	@Success		200		{object}	CategoryResponse		"Категория после изменения"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Категория не найдена"
+//	@Failure		409		{object}	ErrorResponse			"Название занято другой категорией"
+//	@Router			/categories/{id} [patch]
+func (c *CategoryHandler) renameCategory(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	var req RenameCategoryRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	category, err := c.categoryUsecase.RenameCategory(r.Context(), id, req.Name)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCategoryResponse(category))
+}
+
+// deleteCategory
+//
+//	@Summary		Удаление категории
+//	@Description	По умолчанию категория архивируется; архивировать категорию с неархивными товарами или подкатегориями нельзя.
+//	@Description	При hard=true категория удаляется безвозвратно, если в ней нет подкатегорий и ни одного товара, включая архивные.
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id		path		int					true	"ID категории"
+//	@Param			hard	query		bool				false	"Безвозвратное удаление"
+//	@Success		200		{object}	CategoryResponse	"Категория после архивации"
+//	@Success		204		"Категория удалена"
+//	@Failure		400		{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse		"Категория не найдена"
+//	@Failure		409		{object}	ErrorResponse		"В категории есть товары или подкатегории"
+//	@Router			/categories/{id} [delete]
+func (c *CategoryHandler) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	hard, err := parseBool(r.URL.Query().Get("hard"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if hard {
+		if err := c.categoryUsecase.DeleteCategory(r.Context(), id); err != nil {
+			c.logger.Warnf("%s", err.Error())
+			WriteError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	category, err := c.categoryUsecase.ArchiveCategory(r.Context(), id)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCategoryResponse(category))
+}
+
+// moveCategory
+//
+//	@Summary		Перенос категории
+//	@Description	Переносит категорию вместе с подкатегориями к новому родителю. parent_id = null делает категорию корневой.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"ID категории"
+//	@Param			request	body		MoveCategoryRequest	true	"Новый родитель"
+//	@Success		200		{object}	CategoryResponse	"Категория после переноса"
+//	@Failure		400		{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse		"Категория не найдена"
+//	@Failure		409		{object}	ErrorResponse		"Перенос в собственное поддерево или в архивную категорию"
+//	@Router			/categories/{id}/move [post]
+func (c *CategoryHandler) moveCategory(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	var req MoveCategoryRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	category, err := c.categoryUsecase.MoveCategory(r.Context(), id, req.ParentID)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCategoryResponse(category))
+}
+
+// unarchiveCategory
+//
+//	@Summary		Восстановление категории из архива
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id	path		int					true	"ID категории"
+//	@Success		200	{object}	CategoryResponse	"Категория после восстановления"
+//	@Failure		400	{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse		"Категория не найдена"
+//	@Failure		409	{object}	ErrorResponse		"Родительская категория архивная"
+//	@Router			/categories/{id}/unarchive [post]
+func (c *CategoryHandler) unarchiveCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	category, err := c.categoryUsecase.UnarchiveCategory(r.Context(), id)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCategoryResponse(category))
+}
+
+// getAttributeSchema
+//
+//	@Summary		Схема атрибутов категории
+//	@Description	Возвращает собственную схему атрибутов категории и определения, действующие для её товаров с учётом схем предков
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id	path		int						true	"ID категории"
+//	@Success		200	{object}	AttributeSchemaResponse	"Схема атрибутов"
+//	@Failure		400	{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse			"Категория не найдена"
+//	@Router			/categories/{id}/attributes [get]
+func (c *CategoryHandler) getAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	details, err := c.categoryUsecase.GetAttributeSchema(r.Context(), id)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toAttributeSchemaResponse(details))
+}
+
+// setAttributeSchema
+//
+//	@Summary		Изменение схемы атрибутов категории
+//	@Description	Заменяет собственную схему атрибутов категории. Определение подкатегории заменяет одноимённое определение предка.
+//	@Description	Атрибуты существующих товаров проверяются по новой схеме при их следующем изменении.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"ID категории"
+//	@Param			request	body		SetAttributeSchemaRequest	true	"Определения атрибутов"
+//	@Success		200		{object}	AttributeSchemaResponse		"Схема атрибутов после изменения"
+//	@Failure		400		{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse				"Категория не найдена"
+//	@Router			/categories/{id}/attributes [put]
+func (c *CategoryHandler) setAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	var req SetAttributeSchemaRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	schema := domain.NewAttributeSchema(id, toAttributeDefs(req.Attributes))
+	details, err := c.categoryUsecase.SetAttributeSchema(r.Context(), schema)
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toAttributeSchemaResponse(details))
+}
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+type CheckoutHandler struct {
+	checkoutUsecase usecase.CheckoutUC
+	logger          logger.Logger
+}
+
+func NewCheckoutHandler(checkoutUsecase usecase.CheckoutUC, logger logger.Logger) *CheckoutHandler {
+	return &CheckoutHandler{checkoutUsecase: checkoutUsecase, logger: logger}
+}
+
+// createCheckout
+//
+//	@Summary		Создание корзины
+//	@Description	Создаёт пустую сессию оформления покупки. Сессия хранится ограниченное время с момента последнего изменения.
+//	@Tags			checkout
+//	@Produce		json
+//	@Param			X-Store-ID	header		int					false	"ID магазина"
+//	@Success		201			{object}	CheckoutResponse	"Корзина"
+//	@Router			/checkout [post]
+func (c *CheckoutHandler) createCheckout(w http.ResponseWriter, r *http.Request) {
+	res, err := c.checkoutUsecase.CreateCheckout(r.Context())
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toCheckoutResponse(res))
+}
+
+// getCheckout
+//
+//	@Summary		Получение корзины
+//	@Description	Возвращает позиции корзины и итог по текущим ценам товаров
+//	@Tags			checkout
+//	@Produce		json
+//	@Param			id	path		string				true	"ID корзины"
+//	@Success		200	{object}	CheckoutResponse	"Корзина"
+//	@Failure		404	{object}	ErrorResponse		"Корзина не найдена или истекла"
+//	@Failure		409	{object}	ErrorResponse		"Позиции корзины в разных валютах"
+//	@Router			/checkout/{id} [get]
+func (c *CheckoutHandler) getCheckout(w http.ResponseWriter, r *http.Request) {
+	res, err := c.checkoutUsecase.GetCheckout(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCheckoutResponse(res))
+}
+
+// addCheckoutItem
+//
+//	@Summary		Добавление товара в корзину по ID или штрихкоду
+//	@Description	Добавляет товар, выбранный по ID или отсканированный по штрихкоду. Повторное добавление штучного товара
+//	@Description	тем же способом увеличивает кол-во в имеющейся позиции. Для весового товара обязателен weight_grams.
+//	@Tags			checkout
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"ID корзины"
+//	@Param			request		body		AddCheckoutItemRequest	true	"Товар и его кол-во или вес"
+//	@Param			X-Store-ID	header		int						false	"ID магазина"
+//	@Success		200			{object}	CheckoutResponse		"Корзина"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Корзина или товар не найдены"
+//	@Failure		409			{object}	ErrorResponse			"Корзина оформлена, переполнена или изменена параллельно"
+//	@Router			/checkout/{id}/items [post]
+func (c *CheckoutHandler) addCheckoutItem(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req AddCheckoutItemRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := c.checkoutUsecase.AddCheckoutItem(r.Context(), usecase.NewAddCheckoutItemReq(
+		chi.URLParam(r, "id"), req.ProductID, req.Barcode, req.Quantity, req.WeightGrams,
+	))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCheckoutResponse(res))
+}
+
+// addRecognizedItem
+//
+//	@Summary		Добавление распознанного товара в корзину
+//	@Description	Распознаёт товар по одному или нескольким кадрам и добавляет его в корзину, если вердикт accepted.
+//	@Description	Позиция сохраняет score распознавания и версию модели. При другом вердикте корзина не изменяется,
+//	@Description	а кандидаты возвращаются для ручного выбора.
+//	@Tags			checkout
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id				path		string						true	"ID корзины"
+//	@Param			images			formData	[]file						true	"Кадры товара"	collectionFormat(multi)
+//	@Param			quantity		formData	int							false	"Кол-во штучного товара, по умолчанию 1"
+//	@Param			weight_grams	formData	int							false	"Вес весового товара в граммах"
+//	@Param			category_id		formData	int							false	"Ограничение поиска поддеревом категории"
+//	@Param			fusion			formData	string						false	"Стратегия объединения кадров"	Enums(rrf, centroid)
+//	@Param			limit			formData	int							false	"Макс. кол-во кандидатов"
+//	@Param			X-Store-ID		header		int							false	"ID магазина"
+//	@Success		200				{object}	AddRecognizedItemResponse	"Корзина и результат распознавания"
+//	@Failure		400				{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404				{object}	ErrorResponse				"Корзина не найдена"
+//	@Failure		409				{object}	ErrorResponse				"Корзина оформлена, переполнена или изменена параллельно"
+//	@Router			/checkout/{id}/items/recognize [post]
+func (c *CheckoutHandler) addRecognizedItem(w http.ResponseWriter, r *http.Request) {
+	const (
+		maxTotalRequestSize = 100 << 20
+		maxMemory           = 32 << 20
+	)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTotalRequestSize)
+
+	if err := ensureMultipartForm(r, maxMemory); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), r.Header.Get("Content-Type"))
+		WriteError(w, err)
+		return
+	}
+
+	quantity, err := parseOptionalAmount(r.FormValue("quantity"), e.ErrInvalidQuantity)
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	weightGrams, err := parseOptionalAmount(r.FormValue("weight_grams"), e.ErrInvalidWeight)
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	limit, err := parseLimit(r.FormValue("limit"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	categoryID, err := parseOptionalID(r.FormValue("category_id"))
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	frames, err := parseFrames(r.MultipartForm.File["images"])
+	if err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	recognition := usecase.NewRecognizeProductReq(frames, limit, r.FormValue("fusion"), categoryID, false, nil)
+	res, err := c.checkoutUsecase.AddRecognizedItem(r.Context(), usecase.NewAddRecognizedItemReq(
+		chi.URLParam(r, "id"), recognition, quantity, weightGrams,
+	))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toAddRecognizedItemResponse(res))
+}
+
+// updateCheckoutLine
+//
+//	@Summary		Изменение позиции корзины
+//	@Description	Изменяет кол-во штучного товара или вес весового товара в позиции
+//	@Tags			checkout
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"ID корзины"
+//	@Param			lineId	path		string						true	"ID позиции"
+//	@Param			request	body		UpdateCheckoutLineRequest	true	"Новое кол-во или вес"
+//	@Success		200		{object}	CheckoutResponse			"Корзина"
+//	@Failure		400		{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse				"Корзина или позиция не найдены"
+//	@Failure		409		{object}	ErrorResponse				"Корзина оформлена или изменена параллельно"
+//	@Router			/checkout/{id}/items/{lineId} [patch]
+func (c *CheckoutHandler) updateCheckoutLine(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req UpdateCheckoutLineRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		c.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := c.checkoutUsecase.UpdateCheckoutLine(r.Context(), usecase.NewUpdateCheckoutLineReq(
+		chi.URLParam(r, "id"), chi.URLParam(r, "lineId"), req.Quantity, req.WeightGrams,
+	))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCheckoutResponse(res))
+}
+
+// removeCheckoutLine
+//
+//	@Summary		Удаление позиции корзины
+//	@Tags			checkout
+//	@Produce		json
+//	@Param			id		path		string				true	"ID корзины"
+//	@Param			lineId	path		string				true	"ID позиции"
+//	@Success		200		{object}	CheckoutResponse	"Корзина"
+//	@Failure		404		{object}	ErrorResponse		"Корзина или позиция не найдены"
+//	@Failure		409		{object}	ErrorResponse		"Корзина оформлена или изменена параллельно"
+//	@Router			/checkout/{id}/items/{lineId} [delete]
+func (c *CheckoutHandler) removeCheckoutLine(w http.ResponseWriter, r *http.Request) {
+	res, err := c.checkoutUsecase.RemoveCheckoutLine(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "lineId"))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCheckoutResponse(res))
+}
+
+// completeCheckout
+//
+//	@Summary		Оформление покупки
+//	@Description	Фиксирует корзину по текущим ценам и публикует событие checkout_completed для аналитики.
+//	@Description	Корзина с недоступными позициями не оформляется. Если корзина оформлена, но событие не сохранилось,
+//	@Description	повторный запрос публикует его заново.
+//	@Tags			checkout
+//	@Produce		json
+//	@Param			id	path		string						true	"ID корзины"
+//	@Success		200	{object}	CompleteCheckoutResponse	"Оформленная корзина и ID события"
+//	@Failure		404	{object}	ErrorResponse				"Корзина не найдена"
+//	@Failure		409	{object}	ErrorResponse				"Корзина уже оформлена, пуста или содержит недоступные позиции"
+//	@Router			/checkout/{id}/complete [post]
+func (c *CheckoutHandler) completeCheckout(w http.ResponseWriter, r *http.Request) {
+	res, err := c.checkoutUsecase.CompleteCheckout(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		c.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toCompleteCheckoutResponse(res))
+}
@@ -8,62 +8,245 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/locale"
+	"github.com/google/uuid"
 	"github.com/jimlawless/whereami"
-	"github.com/shopspring/decimal"
 )
 
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code      int    `json:"code"`
+	ErrorCode string `json:"error_code" example:"product_not_found"`
+	Message   string `json:"message"`
 }
 
 type ProductMetadata struct {
-	Name         string
-	CategoryName string
-	Price        int64
+	Name           string
+	CategoryName   string
+	Price          domain.Money
+	LocalizedNames domain.LocalizedNames
+	Identifiers    usecase.ProductIdentifiers
+	Variant        usecase.ProductVariant
+	OnDuplicate    domain.DuplicatePolicy
 }
 
-func NewErrorResponse(code int, message string) *ErrorResponse {
+func NewErrorResponse(code int, errorCode string, message string) *ErrorResponse {
 	return &ErrorResponse{
-		Code:    code,
-		Message: message,
+		Code:      code,
+		ErrorCode: errorCode,
+		Message:   message,
 	}
 }
 
-func ToHTTPResponse(err error) (int, string) {
+// ToHTTPResponse возвращает HTTP-статус, код ошибки и сообщение на языке lang
+func ToHTTPResponse(err error, lang string) (int, string, string) {
+	status, respErr := httpStatus(err)
+	return status, e.Code(respErr), e.Message(respErr, lang)
+}
+
+// httpStatus сопоставляет ошибку с HTTP-статусом и ошибкой с кодом, которая попадает в ответ
+func httpStatus(err error) (int, error) {
 	switch {
 	case errors.Is(err, e.ErrStatusBadRequest):
-		return http.StatusBadRequest, e.ErrStatusBadRequest.Error()
+		return http.StatusBadRequest, e.ErrStatusBadRequest
 	case errors.Is(err, e.ErrExpectedMultipart):
-		return http.StatusBadRequest, e.ErrExpectedMultipart.Error()
+		return http.StatusBadRequest, e.ErrExpectedMultipart
 	case errors.Is(err, e.ErrMissingFields):
-		return http.StatusBadRequest, e.ErrMissingFields.Error()
+		return http.StatusBadRequest, e.ErrMissingFields
 	case errors.Is(err, e.ErrInvalidPrice):
-		return http.StatusBadRequest, e.ErrInvalidPrice.Error()
+		return http.StatusBadRequest, e.ErrInvalidPrice
 	case errors.Is(err, e.ErrPricePrecision):
-		return http.StatusBadRequest, e.ErrPricePrecision.Error()
+		return http.StatusBadRequest, e.ErrPricePrecision
 	case errors.Is(err, e.ErrTooManyImages):
-		return http.StatusBadRequest, e.ErrTooManyImages.Error()
+		return http.StatusBadRequest, e.ErrTooManyImages
 	case errors.Is(err, e.ErrNoImages):
-		return http.StatusBadRequest, e.ErrNoImages.Error()
+		return http.StatusBadRequest, e.ErrNoImages
 	case errors.Is(err, e.ErrNoChanges):
-		return http.StatusBadRequest, e.ErrNoChanges.Error()
+		return http.StatusBadRequest, e.ErrNoChanges
 	case errors.Is(err, e.ErrUnsupportedMediaType):
-		return http.StatusBadRequest, e.ErrUnsupportedMediaType.Error()
+		return http.StatusBadRequest, e.ErrUnsupportedMediaType
+	case errors.Is(err, e.ErrInvalidLimit):
+		return http.StatusBadRequest, e.ErrInvalidLimit
+	case errors.Is(err, e.ErrTooManyFrames):
+		return http.StatusBadRequest, e.ErrTooManyFrames
+	case errors.Is(err, e.ErrInvalidFusion):
+		return http.StatusBadRequest, e.ErrInvalidFusion
+	case errors.Is(err, e.ErrInvalidID):
+		return http.StatusBadRequest, e.ErrInvalidID
+	case errors.Is(err, e.ErrInvalidVersion):
+		return http.StatusBadRequest, e.ErrInvalidVersion
+	case errors.Is(err, e.ErrInvalidJSON):
+		return http.StatusBadRequest, e.ErrInvalidJSON
+	case errors.Is(err, e.ErrProductNameRequired):
+		return http.StatusBadRequest, e.ErrProductNameRequired
+	case errors.Is(err, e.ErrCategoryNameRequired):
+		return http.StatusBadRequest, e.ErrCategoryNameRequired
+	case errors.Is(err, e.ErrPriceMustBePositive):
+		return http.StatusBadRequest, e.ErrPriceMustBePositive
+	case errors.Is(err, e.ErrInvalidCursor):
+		return http.StatusBadRequest, e.ErrInvalidCursor
+	case errors.Is(err, e.ErrInvalidSort):
+		return http.StatusBadRequest, e.ErrInvalidSort
+	case errors.Is(err, e.ErrInvalidFilter):
+		return http.StatusBadRequest, e.ErrInvalidFilter
+	case errors.Is(err, e.ErrPriceNotInFuture):
+		return http.StatusBadRequest, e.ErrPriceNotInFuture
+	case errors.Is(err, e.ErrUnsupportedCurrency):
+		return http.StatusBadRequest, e.ErrUnsupportedCurrency
+	case errors.Is(err, e.ErrUnsupportedLocale):
+		return http.StatusBadRequest, e.ErrUnsupportedLocale
+	case errors.Is(err, e.ErrInvalidStatus):
+		return http.StatusBadRequest, e.ErrInvalidStatus
+	case errors.Is(err, e.ErrInvalidReviewer):
+		return http.StatusBadRequest, e.ErrInvalidReviewer
+	case errors.Is(err, e.ErrReviewCommentRequired):
+		return http.StatusBadRequest, e.ErrReviewCommentRequired
+	case errors.Is(err, e.ErrInvalidReviewComment):
+		return http.StatusBadRequest, e.ErrInvalidReviewComment
+	case errors.Is(err, e.ErrMergeSameProduct):
+		return http.StatusBadRequest, e.ErrMergeSameProduct
+	case errors.Is(err, e.ErrInvalidDuplicatePolicy):
+		return http.StatusBadRequest, e.ErrInvalidDuplicatePolicy
+	case errors.Is(err, e.ErrInvalidSKU):
+		return http.StatusBadRequest, e.ErrInvalidSKU
+	case errors.Is(err, e.ErrInvalidBarcode):
+		return http.StatusBadRequest, e.ErrInvalidBarcode
+	case errors.Is(err, e.ErrInvalidUnit):
+		return http.StatusBadRequest, e.ErrInvalidUnit
+	case errors.Is(err, e.ErrInvalidWeight):
+		return http.StatusBadRequest, e.ErrInvalidWeight
+	case errors.Is(err, e.ErrProductNotWeighted):
+		return http.StatusBadRequest, e.ErrProductNotWeighted
+	case errors.Is(err, e.ErrInvalidQuantity):
+		return http.StatusBadRequest, e.ErrInvalidQuantity
+	case errors.Is(err, e.ErrInvalidPromotion):
+		return http.StatusBadRequest, e.ErrInvalidPromotion
+	case errors.Is(err, e.ErrNoProducts):
+		return http.StatusBadRequest, e.ErrNoProducts
+	case errors.Is(err, e.ErrInvalidStore):
+		return http.StatusBadRequest, e.ErrInvalidStore
+	case errors.Is(err, e.ErrStoreRequired):
+		return http.StatusBadRequest, e.ErrStoreRequired
+	case errors.Is(err, e.ErrInvalidAttributeSchema):
+		return http.StatusBadRequest, e.ErrInvalidAttributeSchema
+	case errors.Is(err, e.ErrUnknownAttribute):
+		return http.StatusBadRequest, e.ErrUnknownAttribute
+	case errors.Is(err, e.ErrInvalidAttribute):
+		return http.StatusBadRequest, e.ErrInvalidAttribute
+	case errors.Is(err, e.ErrAttributeRequired):
+		return http.StatusBadRequest, e.ErrAttributeRequired
+	case errors.Is(err, e.ErrInvalidVariantGroup):
+		return http.StatusBadRequest, e.ErrInvalidVariantGroup
+	case errors.Is(err, e.ErrVariantAxisMissing):
+		return http.StatusBadRequest, e.ErrVariantAxisMissing
+	case errors.Is(err, e.ErrProductNotFound):
+		return http.StatusNotFound, e.ErrProductNotFound
+	case errors.Is(err, e.ErrImageNotFound):
+		return http.StatusNotFound, e.ErrImageNotFound
+	case errors.Is(err, e.ErrCategoryNotFound):
+		return http.StatusNotFound, e.ErrCategoryNotFound
+	case errors.Is(err, e.ErrPriceNotFound):
+		return http.StatusNotFound, e.ErrPriceNotFound
+	case errors.Is(err, e.ErrCheckoutNotFound):
+		return http.StatusNotFound, e.ErrCheckoutNotFound
+	case errors.Is(err, e.ErrLineNotFound):
+		return http.StatusNotFound, e.ErrLineNotFound
+	case errors.Is(err, e.ErrPromotionNotFound):
+		return http.StatusNotFound, e.ErrPromotionNotFound
+	case errors.Is(err, e.ErrStoreNotFound):
+		return http.StatusNotFound, e.ErrStoreNotFound
+	case errors.Is(err, e.ErrStockNotFound):
+		return http.StatusNotFound, e.ErrStockNotFound
+	case errors.Is(err, e.ErrReservationNotFound):
+		return http.StatusNotFound, e.ErrReservationNotFound
+	case errors.Is(err, e.ErrVariantGroupNotFound):
+		return http.StatusNotFound, e.ErrVariantGroupNotFound
+	case errors.Is(err, e.ErrProductNameTaken):
+		return http.StatusConflict, e.ErrProductNameTaken
+	case errors.Is(err, e.ErrCategoryNameTaken):
+		return http.StatusConflict, e.ErrCategoryNameTaken
+	case errors.Is(err, e.ErrCategoryHasProducts):
+		return http.StatusConflict, e.ErrCategoryHasProducts
+	case errors.Is(err, e.ErrCategoryArchived):
+		return http.StatusConflict, e.ErrCategoryArchived
+	case errors.Is(err, e.ErrCategoryHasChildren):
+		return http.StatusConflict, e.ErrCategoryHasChildren
+	case errors.Is(err, e.ErrCategoryCycle):
+		return http.StatusConflict, e.ErrCategoryCycle
+	case errors.Is(err, e.ErrVersionMismatch):
+		return http.StatusConflict, e.ErrVersionMismatch
+	case errors.Is(err, e.ErrSKUTaken):
+		return http.StatusConflict, e.ErrSKUTaken
+	case errors.Is(err, e.ErrBarcodeTaken):
+		return http.StatusConflict, e.ErrBarcodeTaken
+	case errors.Is(err, e.ErrCheckoutCompleted):
+		return http.StatusConflict, e.ErrCheckoutCompleted
+	case errors.Is(err, e.ErrCheckoutConflict):
+		return http.StatusConflict, e.ErrCheckoutConflict
+	case errors.Is(err, e.ErrCheckoutEmpty):
+		return http.StatusConflict, e.ErrCheckoutEmpty
+	case errors.Is(err, e.ErrTooManyLines):
+		return http.StatusConflict, e.ErrTooManyLines
+	case errors.Is(err, e.ErrCurrencyMismatch):
+		return http.StatusConflict, e.ErrCurrencyMismatch
+	case errors.Is(err, e.ErrLinesUnavailable):
+		return http.StatusConflict, e.ErrLinesUnavailable
+	case errors.Is(err, e.ErrStoreCodeTaken):
+		return http.StatusConflict, e.ErrStoreCodeTaken
+	case errors.Is(err, e.ErrStoreMismatch):
+		return http.StatusConflict, e.ErrStoreMismatch
+	case errors.Is(err, e.ErrInsufficientStock):
+		return http.StatusConflict, e.ErrInsufficientStock
+	case errors.Is(err, e.ErrReservationClosed):
+		return http.StatusConflict, e.ErrReservationClosed
+	case errors.Is(err, e.ErrReservationExpired):
+		return http.StatusConflict, e.ErrReservationExpired
+	case errors.Is(err, e.ErrVariantGroupNameTaken):
+		return http.StatusConflict, e.ErrVariantGroupNameTaken
+	case errors.Is(err, e.ErrVariantConflict):
+		return http.StatusConflict, e.ErrVariantConflict
+	case errors.Is(err, e.ErrInvalidTransition):
+		return http.StatusConflict, e.ErrInvalidTransition
+	case errors.Is(err, e.ErrProductMerged):
+		return http.StatusConflict, e.ErrProductMerged
+	case errors.Is(err, e.ErrMergeTargetArchived):
+		return http.StatusConflict, e.ErrMergeTargetArchived
+	case errors.Is(err, e.ErrPossibleDuplicate):
+		return http.StatusConflict, e.ErrPossibleDuplicate
+	case errors.Is(err, e.ErrVersionRequired):
+		return http.StatusPreconditionRequired, e.ErrVersionRequired
 	default:
-		return http.StatusInternalServerError, e.ErrInternalServerError.Error()
+		return http.StatusInternalServerError, e.ErrInternalServerError
 	}
 }
 
 func WriteError(w http.ResponseWriter, err error) {
-	code, msg := ToHTTPResponse(err)
+	lang := w.Header().Get(contentLanguageHeader)
+	if lang == "" {
+		lang = locale.Default
+	}
+
+	status, code, msg := ToHTTPResponse(err, lang)
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	json.NewEncoder(w).Encode(NewErrorResponse(code, msg))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(NewErrorResponse(status, code, msg))
+}
+
+// WriteDuplicatesError записывает отказ в регистрации вероятного дубликата со списком похожих товаров.
+func WriteDuplicatesError(w http.ResponseWriter, err *usecase.DuplicateProductsError) {
+	lang := w.Header().Get(contentLanguageHeader)
+	if lang == "" {
+		lang = locale.Default
+	}
+
+	status, code, msg := ToHTTPResponse(err, lang)
+	WriteSuccess(w, status, toDuplicateProductsErrorResponse(status, code, msg, err.Candidates))
 }
 
 func WriteSuccess(w http.ResponseWriter, status int, data interface{}) {
@@ -72,48 +255,19 @@ func WriteSuccess(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-// parsePriceToCents converts a string like "599.99" or "600" to int64 cents.
-// Returns error if:
-// - invalid format
-// - more than 2 decimal places
-// - negative value
-// - exceeds reasonable limit (e.g. 10^9 rubles)
-func parsePriceToCents(s string) (int64, error) {
-	if strings.TrimSpace(s) == "" {
-		return 0, errors.New("price is empty")
+// parseMoney преобразует цену в основных единицах валюты, например "599.99", в domain.Money.
+// Пустой код валюты означает domain.DefaultCurrency.
+func parseMoney(price string, currency string) (domain.Money, error) {
+	if strings.TrimSpace(price) == "" {
+		return domain.Money{}, errors.New("price is empty")
 	}
 
-	d, err := decimal.NewFromString(s)
+	cur, err := domain.ParseCurrency(currency)
 	if err != nil {
-		return 0, e.ErrInvalidPrice
-	}
-
-	// Reject negative
-	if d.LessThan(decimal.Zero) {
-		return 0, e.ErrInvalidPrice
-	}
-
-	// Enforce max value (e.g. 1 billion rubles = 100_000_000_000 cents)
-	maxPrice := decimal.NewFromInt(1_000_000_000).Mul(decimal.NewFromInt(100)) // 1B rub in cents
-	if d.GreaterThan(maxPrice) {
-		return 0, e.ErrInvalidPrice
-	}
-
-	// Check decimal places
-	if d.Exponent() < -2 {
-		return 0, e.ErrPricePrecision // "price must have at most 2 decimal places"
-	}
-
-	// Convert to cents: multiply by 100 and round
-	cents := d.Mul(decimal.NewFromInt(100)).Round(0)
-
-	// Safely convert to int64
-	centsInt := cents.IntPart()
-	if centsInt < 0 || centsInt > 9223372036854775807 { // int64 max, but we have maxPrice
-		return 0, e.ErrInvalidPrice
+		return domain.Money{}, err
 	}
 
-	return centsInt, nil
+	return domain.ParseMoney(price, cur)
 }
 
 func ensureMultipartForm(r *http.Request, maxMemory int64) error {
@@ -132,23 +286,133 @@ func parseProductForm(r *http.Request) (*ProductMetadata, error) {
 		return nil, e.Wrap(fmt.Sprintf("name: %s, category_name: %s, price: %s\n", name, category_name, priceStr), e.ErrMissingFields)
 	}
 
-	priceCents, err := parsePriceToCents(priceStr)
+	price, err := parseMoney(priceStr, r.FormValue("currency"))
+	if err != nil {
+		return nil, err
+	}
+
+	localizedNames, err := parseLocalizedNames(r.FormValue("localized_names"))
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers, err := parseProductIdentifiers(r)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := parseProductVariant(r)
+	if err != nil {
+		return nil, err
+	}
+
+	onDuplicate, err := domain.ParseDuplicatePolicy(r.FormValue("on_duplicate"))
 	if err != nil {
 		return nil, err
 	}
 
 	return &ProductMetadata{
-		Name:         name,
-		CategoryName: category_name,
-		Price:        priceCents,
+		Name:           name,
+		CategoryName:   category_name,
+		Price:          price,
+		LocalizedNames: localizedNames,
+		Identifiers:    identifiers,
+		Variant:        variant,
+		OnDuplicate:    onDuplicate,
 	}, nil
 }
 
+// parseLocalizedNames разбирает необязательный JSON-объект переводов названия, например {"en": "Milk"}.
+// Пустое значение означает nil. Языки и названия проверяются в usecase.
+func parseLocalizedNames(s string) (domain.LocalizedNames, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var names domain.LocalizedNames
+	if err := json.Unmarshal([]byte(s), &names); err != nil || names == nil {
+		return nil, e.Wrap("localized_names", e.ErrInvalidJSON)
+	}
+
+	return names, nil
+}
+
+// parseProductVariant читает из формы атрибуты (JSON-объект) и группу вариантов.
+// Отсутствующие поля не изменяют существующий продукт, variant_group_id = 0 исключает его из группы.
+func parseProductVariant(r *http.Request) (usecase.ProductVariant, error) {
+	var variant usecase.ProductVariant
+
+	attrs, err := parseAttributes(r.FormValue("attributes"))
+	if err != nil {
+		return usecase.ProductVariant{}, err
+	}
+	variant.Attributes = attrs
+
+	if s := strings.TrimSpace(r.FormValue("variant_group_id")); s != "" {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || id < 0 {
+			return usecase.ProductVariant{}, e.ErrInvalidID
+		}
+		variant.VariantGroupID = &id
+	}
+
+	return variant, nil
+}
+
+// parseAttributes разбирает необязательный JSON-объект атрибутов. Пустое значение означает nil.
+// Типы значений проверяются по схеме категории в usecase.
+func parseAttributes(s string) (domain.Attributes, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var attrs domain.Attributes
+	if err := json.Unmarshal([]byte(s), &attrs); err != nil || attrs == nil {
+		return nil, e.Wrap("attributes", e.ErrInvalidAttribute)
+	}
+
+	return attrs, nil
+}
+
+// parseProductIdentifiers читает из формы артикул, штрихкоды (повторяющееся поле barcode) и единицу измерения.
+// Отсутствующие поля не изменяют существующий продукт.
+func parseProductIdentifiers(r *http.Request) (usecase.ProductIdentifiers, error) {
+	var ids usecase.ProductIdentifiers
+
+	if _, ok := r.MultipartForm.Value["sku"]; ok {
+		sku := r.FormValue("sku")
+		ids.SKU = &sku
+	}
+
+	if barcodes, ok := r.MultipartForm.Value["barcode"]; ok {
+		ids.Barcodes = barcodes
+	}
+
+	unit, err := parseOptionalUnit(r.FormValue("unit"))
+	if err != nil {
+		return usecase.ProductIdentifiers{}, err
+	}
+	ids.Unit = unit
+
+	return ids, nil
+}
+
+// parseOptionalUnit проверяет необязательную единицу измерения.
+func parseOptionalUnit(s string) (*domain.Unit, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	unit, err := domain.ParseUnit(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unit, nil
+}
+
 func parseImages(files []*multipart.FileHeader) ([]usecase.ProductImage, error) {
-	const (
-		maxImageCount = 10
-		maxFileSize   = 15 << 20
-	)
+	const maxFileSize = 15 << 20
 
 	if len(files) == 0 {
 		return nil, e.ErrNoImages
@@ -156,7 +420,7 @@ func parseImages(files []*multipart.FileHeader) ([]usecase.ProductImage, error)
 
 	log.Println("DEBUG len(files):", len(files))
 
-	if len(files) > maxImageCount {
+	if len(files) > domain.MaxProductImages {
 		return nil, e.ErrTooManyImages
 	}
 
@@ -176,6 +440,453 @@ func parseImages(files []*multipart.FileHeader) ([]usecase.ProductImage, error)
 	return images, nil
 }
 
+// parseFrames читает кадры одного товара для распознавания. Кол-во кадров проверяет usecase.
+func parseFrames(files []*multipart.FileHeader) ([]usecase.ProductImage, error) {
+	const maxFileSize = 15 << 20
+
+	if len(files) == 0 {
+		return nil, e.ErrNoImages
+	}
+
+	frames := make([]usecase.ProductImage, 0, len(files))
+	for _, fh := range files {
+		data, mimeType, err := readFile(fh, maxFileSize)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, *usecase.NewProductImage(data, mimeType, int64(len(data)), fh.Filename))
+	}
+
+	return frames, nil
+}
+
+// parseLimit разбирает необязательный параметр limit. Пустое значение означает 0 (значение по умолчанию).
+func parseLimit(s string) (int, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(s)
+	if err != nil || limit < 0 {
+		return 0, e.ErrInvalidLimit
+	}
+
+	return limit, nil
+}
+
+// parseWeightGrams разбирает обязательное показание весов в граммах. Диапазон веса проверяет domain.
+func parseWeightGrams(s string) (int64, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, e.Wrap("weight_grams", e.ErrMissingFields)
+	}
+
+	grams, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, e.ErrInvalidWeight
+	}
+
+	return grams, nil
+}
+
+// parseOptionalAmount разбирает необязательное кол-во или вес из формы. Пустое значение означает 0,
+// некорректное — ошибку errInvalid. Диапазон значения проверяет usecase.
+func parseOptionalAmount(s string, errInvalid error) (int64, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errInvalid
+	}
+
+	return v, nil
+}
+
+// parseID разбирает положительный идентификатор из параметра пути.
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, e.ErrInvalidID
+	}
+
+	return id, nil
+}
+
+// parseOptionalID разбирает необязательный положительный идентификатор. Пустое значение означает nil.
+func parseOptionalID(s string) (*int64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	id, err := parseID(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// parseBool разбирает необязательный булев параметр. Пустое значение означает false.
+func parseBool(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, e.ErrStatusBadRequest
+	}
+
+	return v, nil
+}
+
+// parseImageID проверяет, что ID изображения является UUID вектора.
+func parseReservationID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, e.ErrInvalidID
+	}
+
+	return id, nil
+}
+
+func parseImageID(s string) (string, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", e.ErrInvalidID
+	}
+
+	return id.String(), nil
+}
+
+// parseListProductsQuery разбирает параметры запроса списка товаров.
+// Цены передаются в основных единицах валюты фильтра (по умолчанию domain.DefaultCurrency),
+// время — в формате RFC 3339.
+func parseListProductsQuery(r *http.Request) (*usecase.ListProductsReq, error) {
+	q := r.URL.Query()
+
+	var (
+		filter usecase.ProductFilter
+		err    error
+	)
+
+	if filter.CategoryID, err = parseOptionalInt(q.Get("category_id")); err != nil {
+		return nil, e.Wrap("category_id", err)
+	}
+	if filter.IncludeSubcategories, err = parseBool(q.Get("include_subcategories")); err != nil {
+		return nil, e.Wrap("include_subcategories", err)
+	}
+	if filter.Currency, err = parseOptionalCurrency(q.Get("currency")); err != nil {
+		return nil, e.Wrap("currency", err)
+	}
+	if filter.MinPrice, err = parseOptionalPrice(q.Get("min_price"), q.Get("currency")); err != nil {
+		return nil, e.Wrap("min_price", err)
+	}
+	if filter.MaxPrice, err = parseOptionalPrice(q.Get("max_price"), q.Get("currency")); err != nil {
+		return nil, e.Wrap("max_price", err)
+	}
+	if filter.Archived, err = parseOptionalBool(q.Get("archived")); err != nil {
+		return nil, e.Wrap("archived", err)
+	}
+	if filter.Status, err = parseOptionalStatus(q.Get("status")); err != nil {
+		return nil, e.Wrap("status", err)
+	}
+	if filter.CreatedFrom, err = parseOptionalTime(q.Get("created_from")); err != nil {
+		return nil, e.Wrap("created_from", err)
+	}
+	if filter.CreatedTo, err = parseOptionalTime(q.Get("created_to")); err != nil {
+		return nil, e.Wrap("created_to", err)
+	}
+	if filter.UpdatedFrom, err = parseOptionalTime(q.Get("updated_from")); err != nil {
+		return nil, e.Wrap("updated_from", err)
+	}
+	if filter.UpdatedTo, err = parseOptionalTime(q.Get("updated_to")); err != nil {
+		return nil, e.Wrap("updated_to", err)
+	}
+
+	var desc bool
+	switch q.Get("order") {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return nil, e.ErrInvalidSort
+	}
+
+	limit, err := parseLimit(q.Get("limit"))
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.NewListProductsReq(filter, usecase.ProductSortField(q.Get("sort")), desc, limit, q.Get("cursor")), nil
+}
+
+func parseOptionalInt(s string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, e.ErrInvalidFilter
+	}
+
+	return &v, nil
+}
+
+func parseOptionalCurrency(s string) (*domain.Currency, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := domain.ParseCurrency(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func parseOptionalPrice(s string, currency string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := parseMoney(s, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v.Amount, nil
+}
+
+func parseOptionalBool(s string) (*bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, e.ErrInvalidFilter
+	}
+
+	return &v, nil
+}
+
+func parseOptionalStatus(s string) (*domain.ProductStatus, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	status, err := domain.ParseProductStatus(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, e.ErrInvalidFilter
+	}
+
+	// Время в БД хранится в UTC без часового пояса
+	v = v.UTC()
+	return &v, nil
+}
+
+// formatETag формирует значение заголовка ETag из версии продукта.
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch разбирает заголовок If-Match с версией продукта. Пустое значение означает, что версия не передана.
+func parseIfMatch(s string) (*int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(s, "W/"), `"`), 10, 64)
+	if err != nil || version <= 0 {
+		return nil, e.ErrInvalidVersion
+	}
+
+	return &version, nil
+}
+
+// parseUpdateProductRequest декодирует JSON-тело запроса на изменение продукта.
+// Цена передаётся в основных единицах валюты и переводится в минимальные; валюта меняется только вместе с ценой.
+func parseUpdateProductRequest(r *http.Request) (*UpdateProductRequest, *domain.Money, usecase.ProductIdentifiers, error) {
+	var req UpdateProductRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return nil, nil, usecase.ProductIdentifiers{}, e.Wrap(err.Error(), e.ErrInvalidJSON)
+	}
+
+	ids := usecase.ProductIdentifiers{
+		SKU:      req.SKU,
+		Barcodes: req.Barcodes,
+	}
+	if req.Unit != nil {
+		unit, err := domain.ParseUnit(*req.Unit)
+		if err != nil {
+			return nil, nil, usecase.ProductIdentifiers{}, err
+		}
+		ids.Unit = &unit
+	}
+
+	if req.Price == nil {
+		if req.Currency != nil {
+			return nil, nil, usecase.ProductIdentifiers{}, e.Wrap("currency without price", e.ErrMissingFields)
+		}
+
+		return &req, nil, ids, nil
+	}
+
+	var currency string
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+
+	price, err := parseMoney(req.Price.String(), currency)
+	if err != nil {
+		return nil, nil, usecase.ProductIdentifiers{}, err
+	}
+
+	return &req, &price, ids, nil
+}
+
+// parseSchedulePriceRequest декодирует JSON-тело запроса на планирование цены.
+// Цена передаётся в основных единицах валюты и переводится в минимальные.
+func parseSchedulePriceRequest(r *http.Request) (*SchedulePriceRequest, domain.Money, error) {
+	var req SchedulePriceRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		return nil, domain.Money{}, err
+	}
+
+	if req.Price == "" || req.EffectiveFrom.IsZero() {
+		return nil, domain.Money{}, e.ErrMissingFields
+	}
+
+	price, err := parseMoney(req.Price.String(), req.Currency)
+	if err != nil {
+		return nil, domain.Money{}, err
+	}
+
+	return &req, price, nil
+}
+
+// parseCreatePromotionRequest декодирует JSON-тело запроса на создание акции.
+// Сумма фиксированной скидки передаётся в основных единицах валюты и переводится в минимальные.
+// Без is_active акция создаётся действующей.
+func parseCreatePromotionRequest(r *http.Request) (*domain.Promotion, error) {
+	var req CreatePromotionRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	promotion := &domain.Promotion{
+		Name:        req.Name,
+		Kind:        domain.PromotionKind(req.Kind),
+		ProductID:   req.ProductID,
+		CategoryID:  req.CategoryID,
+		Percent:     req.Percent,
+		BuyQuantity: req.BuyQuantity,
+		PayQuantity: req.PayQuantity,
+		Priority:    req.Priority,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+
+	if req.Amount == nil {
+		if req.Currency != nil {
+			return nil, e.Wrap("currency without amount", e.ErrMissingFields)
+		}
+
+		return promotion, nil
+	}
+
+	var currency string
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+
+	amount, err := parseMoney(req.Amount.String(), currency)
+	if err != nil {
+		return nil, err
+	}
+	promotion.Amount = &amount
+
+	return promotion, nil
+}
+
+// parseSetStoreProductRequest декодирует JSON-тело запроса на изменение товара в ассортименте магазина.
+// Цена магазина передаётся в основных единицах валюты и переводится в минимальные.
+func parseSetStoreProductRequest(r *http.Request, storeID int64, productID int64) (*domain.StoreProduct, error) {
+	var req SetStoreProductRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	product := domain.NewStoreProduct(storeID, productID, req.IsAvailable == nil || *req.IsAvailable, nil)
+
+	if req.Price == nil {
+		if req.Currency != nil {
+			return nil, e.Wrap("currency without price", e.ErrMissingFields)
+		}
+
+		return product, nil
+	}
+
+	var currency string
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+
+	price, err := parseMoney(req.Price.String(), currency)
+	if err != nil {
+		return nil, err
+	}
+	product.Price = &price
+
+	return product, nil
+}
+
+// parseStockQuantityRequest декодирует JSON-тело запроса на списание или резервирование остатка.
+func parseStockQuantityRequest(r *http.Request) (int64, error) {
+	var req StockQuantityRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		return 0, err
+	}
+
+	if req.Quantity == nil {
+		return 0, e.ErrMissingFields
+	}
+
+	return *req.Quantity, nil
+}
+
+// parseJSONBody декодирует JSON-тело запроса в dst, отклоняя неизвестные поля.
+func parseJSONBody(r *http.Request, dst any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return e.Wrap(err.Error(), e.ErrInvalidJSON)
+	}
+
+	return nil
+}
+
 func readFile(fh *multipart.FileHeader, maxSize int64) ([]byte, string, error) {
 	src, err := fh.Open()
 	if err != nil {
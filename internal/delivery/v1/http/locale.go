@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/pkg/locale"
+)
+
+const (
+	// acceptLanguageHeader — заголовок с языками, которые предпочитает клиент
+	acceptLanguageHeader = "Accept-Language"
+	// contentLanguageHeader — заголовок с языком ответа
+	contentLanguageHeader = "Content-Language"
+)
+
+// localeScope выбирает язык ответа по заголовку Accept-Language и передаёт его в контексте запроса.
+// Если ни один из поддерживаемых языков не подходит, названия товаров не переводятся, а ошибки возвращаются на языке по умолчанию.
+func localeScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang, ok := locale.Negotiate(r.Header.Get(acceptLanguageHeader))
+		if !ok {
+			w.Header().Set(contentLanguageHeader, locale.Default)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(contentLanguageHeader, lang)
+		next.ServeHTTP(w, r.WithContext(locale.WithLocale(r.Context(), lang)))
+	})
+}
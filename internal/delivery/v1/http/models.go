@@ -0,0 +1,1124 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+)
+
+// ProductResponse — информация о продукте в HTTP-ответе.
+type ProductResponse struct {
+	ID             int64          `json:"id"`
+	Name           string         `json:"name"`
+	CategoryName   string         `json:"category_name"`
+	CategoryPath   []string       `json:"category_path"`
+	Price          int64          `json:"price"`
+	Currency       string         `json:"currency" example:"RUB"`
+	PriceDisplay   string         `json:"price_display" example:"599.99 RUB"`
+	SKU            *string        `json:"sku,omitempty" example:"MLK-3.2-1L"`
+	Barcodes       []string       `json:"barcodes"`
+	Unit           string         `json:"unit" enums:"piece,kg,l"`
+	IsWeighted     bool           `json:"is_weighted"`
+	Attributes     map[string]any `json:"attributes,omitempty" swaggertype:"object"`
+	VariantGroupID *int64         `json:"variant_group_id,omitempty"`
+	Version        int64          `json:"version"`
+}
+
+// UpdateProductRequest — частичное изменение продукта. Отсутствующие поля не изменяются.
+// Пустой sku удаляет артикул, пустой список barcodes удаляет все штрихкоды, пустой объект attributes
+// удаляет все атрибуты, пустой объект localized_names удаляет все переводы названия,
+// variant_group_id = 0 исключает продукт из группы вариантов.
+type UpdateProductRequest struct {
+	Name           *string           `json:"name,omitempty"`
+	CategoryName   *string           `json:"category_name,omitempty"`
+	LocalizedNames map[string]string `json:"localized_names,omitempty" example:"en:Milk 3.2%"`
+	Price          *json.Number      `json:"price,omitempty" swaggertype:"number" example:"599.99"`
+	Currency       *string           `json:"currency,omitempty" example:"RUB"`
+	SKU            *string           `json:"sku,omitempty" example:"MLK-3.2-1L"`
+	Barcodes       []string          `json:"barcodes,omitempty"`
+	Unit           *string           `json:"unit,omitempty" enums:"piece,kg,l"`
+	Attributes     map[string]any    `json:"attributes,omitempty" swaggertype:"object"`
+	VariantGroupID *int64            `json:"variant_group_id,omitempty"`
+}
+
+// ProductDetailsResponse — продукт со статусом жизненного цикла и датами изменения.
+type ProductDetailsResponse struct {
+	ID             int64             `json:"id"`
+	Name           string            `json:"name"`
+	LocalizedNames map[string]string `json:"localized_names" example:"en:Milk 3.2%"`
+	CategoryID     int64             `json:"category_id"`
+	CategoryName   string            `json:"category_name"`
+	Price          int64             `json:"price"`
+	Currency       string            `json:"currency" example:"RUB"`
+	PriceDisplay   string            `json:"price_display" example:"599.99 RUB"`
+	SKU            *string           `json:"sku,omitempty" example:"MLK-3.2-1L"`
+	Barcodes       []string          `json:"barcodes"`
+	Unit           string            `json:"unit" enums:"piece,kg,l"`
+	IsWeighted     bool              `json:"is_weighted"`
+	Attributes     map[string]any    `json:"attributes" swaggertype:"object"`
+	VariantGroupID *int64            `json:"variant_group_id,omitempty"`
+	Status         string            `json:"status" enums:"draft,pending_review,active,archived"`
+	IsArchived     bool              `json:"is_archived"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      *time.Time        `json:"updated_at,omitempty"`
+	Version        int64             `json:"version"`
+}
+
+// UpdateProductResponse — продукт после изменения и ID события изменения.
+type UpdateProductResponse struct {
+	Product ProductDetailsResponse `json:"product"`
+	EventID string                 `json:"event_id"`
+}
+
+// ListProductsResponse — страница списка товаров. next_cursor отсутствует на последней странице.
+type ListProductsResponse struct {
+	Items      []ProductDetailsResponse `json:"items"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
+
+// ReviewRequest — решение по товару. reviewer обязателен при одобрении и отклонении, comment — при отклонении.
+type ReviewRequest struct {
+	Reviewer string `json:"reviewer" example:"moderator@drsn.tech"`
+	Comment  string `json:"comment" example:"Нужно фото упаковки со штрихкодом"`
+}
+
+// ProductTransitionResponse — запись истории статусов товара.
+type ProductTransitionResponse struct {
+	ID         int64     `json:"id"`
+	FromStatus string    `json:"from_status" enums:"draft,pending_review,active,archived"`
+	ToStatus   string    `json:"to_status" enums:"draft,pending_review,active,archived"`
+	Reviewer   *string   `json:"reviewer,omitempty"`
+	Comment    *string   `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListProductTransitionsResponse — история статусов товара, начиная с самой ранней записи.
+type ListProductTransitionsResponse struct {
+	Items []ProductTransitionResponse `json:"items"`
+}
+
+// ReviewResultResponse — новый статус и версия товара, запись истории и ID события перехода.
+type ReviewResultResponse struct {
+	ProductID  int64                     `json:"product_id"`
+	Status     string                    `json:"status" enums:"draft,pending_review,active,archived"`
+	Version    int64                     `json:"version"`
+	Transition ProductTransitionResponse `json:"transition"`
+	EventID    string                    `json:"event_id"`
+}
+
+// DuplicateCandidateResponse — опубликованный товар, изображения которого похожи на изображения регистрируемого товара.
+type DuplicateCandidateResponse struct {
+	ProductID int64   `json:"product_id"`
+	Name      string  `json:"name"`
+	Score     float32 `json:"score" example:"0.97"`
+}
+
+// DuplicateProductsErrorResponse — ошибка регистрации товара. Для possible_duplicate duplicates содержит
+// вероятные дубликаты, начиная с самого похожего.
+type DuplicateProductsErrorResponse struct {
+	Code       int                          `json:"code"`
+	ErrorCode  string                       `json:"error_code" example:"possible_duplicate"`
+	Message    string                       `json:"message"`
+	Duplicates []DuplicateCandidateResponse `json:"duplicates,omitempty"`
+}
+
+// DuplicateFlagResponse — вероятный дубликат, отмеченный при регистрации товара.
+type DuplicateFlagResponse struct {
+	CandidateID int64     `json:"candidate_id"`
+	Score       float32   `json:"score" example:"0.97"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ListDuplicateFlagsResponse — вероятные дубликаты товара, начиная с самого похожего.
+type ListDuplicateFlagsResponse struct {
+	Items []DuplicateFlagResponse `json:"items"`
+}
+
+// MergeProductRequest — слияние товара-дубликата из пути запроса с товаром target_id.
+type MergeProductRequest struct {
+	TargetID int64 `json:"target_id" example:"42"`
+}
+
+// MergeProductResponse — результат слияния: версии товаров после слияния, ID перенесённых векторов
+// и ID событий слияния источника и добавления векторов получателю.
+type MergeProductResponse struct {
+	SourceID      int64    `json:"source_id"`
+	TargetID      int64    `json:"target_id"`
+	SourceVersion int64    `json:"source_version"`
+	TargetVersion int64    `json:"target_version"`
+	EmbeddingIDs  []string `json:"embedding_ids"`
+	SourceEventID string   `json:"source_event_id"`
+	TargetEventID string   `json:"target_event_id"`
+}
+
+// AddProductImagesResponse — ID добавленных изображений и ID события изменения товара.
+type AddProductImagesResponse struct {
+	ImageIDs []string `json:"image_ids"`
+	EventID  string   `json:"event_id"`
+}
+
+// SchedulePriceRequest — планирование цены товара. Цена передаётся в основных единицах валюты,
+// по умолчанию в рублях, время — в формате RFC 3339.
+type SchedulePriceRequest struct {
+	Price         json.Number `json:"price" swaggertype:"number" example:"499.99"`
+	Currency      string      `json:"currency,omitempty" example:"RUB"`
+	EffectiveFrom time.Time   `json:"effective_from"`
+}
+
+// ProductPriceResponse — цена товара в минимальных единицах валюты, действующая с effective_from.
+// applied_at отсутствует у ещё не применённой запланированной цены.
+type ProductPriceResponse struct {
+	ID            int64      `json:"id"`
+	ProductID     int64      `json:"product_id"`
+	Price         int64      `json:"price"`
+	Currency      string     `json:"currency" example:"RUB"`
+	PriceDisplay  string     `json:"price_display" example:"499.99 RUB"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty"`
+	IsScheduled   bool       `json:"is_scheduled"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// WeightedPriceResponse — стоимость весового товара: цена за килограмм и итог за взвешенное кол-во
+// в минимальных единицах валюты.
+type WeightedPriceResponse struct {
+	ProductID    int64  `json:"product_id"`
+	Name         string `json:"name"`
+	Unit         string `json:"unit" example:"kg"`
+	UnitPrice    int64  `json:"unit_price"`
+	WeightGrams  int64  `json:"weight_grams"`
+	Total        int64  `json:"total"`
+	Currency     string `json:"currency" example:"RUB"`
+	TotalDisplay string `json:"total_display" example:"123.45 RUB"`
+}
+
+// PriceHistoryResponse — история и запланированные цены товара, начиная с самой поздней.
+type PriceHistoryResponse struct {
+	Items []ProductPriceResponse `json:"items"`
+}
+
+// CreateCategoryRequest — создание категории. Без parent_id категория создаётся корневой.
+type CreateCategoryRequest struct {
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id,omitempty"`
+}
+
+// RenameCategoryRequest — переименование категории.
+type RenameCategoryRequest struct {
+	Name string `json:"name"`
+}
+
+// MoveCategoryRequest — перенос категории. parent_id = null делает категорию корневой.
+type MoveCategoryRequest struct {
+	ParentID *int64 `json:"parent_id"`
+}
+
+// CategoryResponse — категория с количеством неархивных товаров.
+// Путь и количество товаров возвращаются только при чтении категорий.
+type CategoryResponse struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	ParentID     *int64     `json:"parent_id,omitempty"`
+	Path         []string   `json:"path,omitempty"`
+	IsArchived   bool       `json:"is_archived"`
+	ProductCount *int64     `json:"product_count,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListCategoriesResponse — список категорий.
+type ListCategoriesResponse struct {
+	Items []CategoryResponse `json:"items"`
+}
+
+// AttributeDefinition — определение атрибута товаров категории. unit задаётся только для number,
+// values — только для enum.
+type AttributeDefinition struct {
+	Code     string   `json:"code" example:"volume"`
+	Name     string   `json:"name" example:"Объём"`
+	Type     string   `json:"type" enums:"string,number,bool,enum"`
+	Unit     string   `json:"unit,omitempty" example:"l"`
+	Values   []string `json:"values,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// SetAttributeSchemaRequest — собственная схема атрибутов категории; пустой список удаляет все её атрибуты.
+type SetAttributeSchemaRequest struct {
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// AttributeSchemaResponse — собственная схема категории и действующие для её товаров определения
+// с учётом схем предков: определение подкатегории заменяет одноимённое определение предка.
+type AttributeSchemaResponse struct {
+	CategoryID int64                 `json:"category_id"`
+	Attributes []AttributeDefinition `json:"attributes"`
+	Effective  []AttributeDefinition `json:"effective"`
+	UpdatedAt  *time.Time            `json:"updated_at,omitempty"`
+}
+
+// CreateVariantGroupRequest — создание группы вариантов. axes — коды атрибутов, по которым различаются варианты.
+type CreateVariantGroupRequest struct {
+	Name string   `json:"name" example:"Молоко 3,2%"`
+	Axes []string `json:"axes" example:"volume"`
+}
+
+// VariantGroupResponse — группа вариантов. variants заполняется только при чтении одной группы
+// и содержит её неархивные товары.
+type VariantGroupResponse struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Axes      []string          `json:"axes"`
+	Variants  []ProductResponse `json:"variants,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt *time.Time        `json:"updated_at,omitempty"`
+}
+
+// ListVariantGroupsResponse — список групп вариантов.
+type ListVariantGroupsResponse struct {
+	Items []VariantGroupResponse `json:"items"`
+}
+
+// RecognitionCandidateResponse — продукт-кандидат распознавания. stock заполняется при include_stock,
+// если остаток товара в магазине учитывается; отсутствие остатка у кандидата обычно означает ошибку распознавания.
+type RecognitionCandidateResponse struct {
+	Product ProductResponse     `json:"product"`
+	Score   float32             `json:"score"`
+	Hits    int                 `json:"hits"`
+	Stock   *StockLevelResponse `json:"stock,omitempty"`
+}
+
+// RecognizeProductResponse — результат распознавания продукта.
+// При verdict = accepted распознанным продуктом является первый кандидат. При verdict = variant
+// variant_group содержит распознанную группу со всеми вариантами.
+type RecognizeProductResponse struct {
+	Verdict      string                         `json:"verdict" enums:"accepted,variant,ambiguous,unknown"`
+	Candidates   []RecognitionCandidateResponse `json:"candidates"`
+	VariantGroup *VariantGroupResponse          `json:"variant_group,omitempty"`
+	ModelVersion string                         `json:"model_version"`
+}
+
+// AddCheckoutItemRequest — добавление товара в корзину по ID или штрихкоду; задаётся ровно одно из полей.
+// Для весового товара передаётся weight_grams, для штучного quantity (по умолчанию 1).
+type AddCheckoutItemRequest struct {
+	ProductID   *int64  `json:"product_id,omitempty"`
+	Barcode     *string `json:"barcode,omitempty" example:"4601234567893"`
+	Quantity    int64   `json:"quantity,omitempty" example:"1"`
+	WeightGrams int64   `json:"weight_grams,omitempty"`
+}
+
+// UpdateCheckoutLineRequest — изменение позиции корзины: quantity для штучного товара
+// или weight_grams для весового; задаётся ровно одно из полей.
+type UpdateCheckoutLineRequest struct {
+	Quantity    *int64 `json:"quantity,omitempty"`
+	WeightGrams *int64 `json:"weight_grams,omitempty"`
+}
+
+// CheckoutLineResponse — позиция корзины со стоимостью по текущей цене товара.
+// Недоступная позиция (товар архивирован или изменилась его единица измерения) не входит в итог.
+type CheckoutLineResponse struct {
+	ID               string           `json:"id"`
+	ProductID        int64            `json:"product_id"`
+	Product          *ProductResponse `json:"product,omitempty"`
+	Quantity         int64            `json:"quantity"`
+	WeightGrams      int64            `json:"weight_grams,omitempty"`
+	Method           string           `json:"method" enums:"recognition,id,barcode"`
+	Barcode          *string          `json:"barcode,omitempty"`
+	RecognitionScore *float32         `json:"recognition_score,omitempty"`
+	ModelVersion     *string          `json:"model_version,omitempty"`
+	Total            int64            `json:"total"`
+	Currency         string           `json:"currency,omitempty" example:"RUB"`
+	TotalDisplay     string           `json:"total_display,omitempty" example:"119.98 RUB"`
+	Available        bool             `json:"available"`
+	AddedAt          time.Time        `json:"added_at"`
+}
+
+// CheckoutResponse — корзина с позициями и итоговой стоимостью в минимальных единицах валюты.
+type CheckoutResponse struct {
+	ID           string                 `json:"id"`
+	Status       string                 `json:"status" enums:"open,completed"`
+	Lines        []CheckoutLineResponse `json:"lines"`
+	Total        int64                  `json:"total"`
+	Currency     string                 `json:"currency" example:"RUB"`
+	TotalDisplay string                 `json:"total_display" example:"119.98 RUB"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	CompletedAt  *time.Time             `json:"completed_at,omitempty"`
+}
+
+// AddRecognizedItemResponse — корзина и результат распознавания.
+// line_id отсутствует, если товар не распознан уверенно и не добавлен.
+type AddRecognizedItemResponse struct {
+	Checkout    CheckoutResponse         `json:"checkout"`
+	LineID      string                   `json:"line_id,omitempty"`
+	Recognition RecognizeProductResponse `json:"recognition"`
+}
+
+// CompleteCheckoutResponse — оформленная корзина и ID события checkout_completed.
+type CompleteCheckoutResponse struct {
+	Checkout CheckoutResponse `json:"checkout"`
+	EventID  string           `json:"event_id"`
+}
+
+// CreatePromotionRequest — создание акции на товар или категорию; задаётся ровно одно из product_id и category_id.
+// Для kind = percent задаётся percent, для fixed — amount в основных единицах валюты за штуку, килограмм или литр,
+// для bundle — buy_quantity и pay_quantity ("3 за 2"). Время передаётся в формате RFC 3339, ends_at не входит в период.
+type CreatePromotionRequest struct {
+	Name        string       `json:"name"`
+	Kind        string       `json:"kind" enums:"percent,fixed,bundle"`
+	ProductID   *int64       `json:"product_id,omitempty"`
+	CategoryID  *int64       `json:"category_id,omitempty"`
+	Percent     int64        `json:"percent,omitempty" example:"10"`
+	Amount      *json.Number `json:"amount,omitempty" swaggertype:"number" example:"50.00"`
+	Currency    *string      `json:"currency,omitempty" example:"RUB"`
+	BuyQuantity int64        `json:"buy_quantity,omitempty" example:"3"`
+	PayQuantity int64        `json:"pay_quantity,omitempty" example:"2"`
+	Priority    int64        `json:"priority,omitempty"`
+	StartsAt    *time.Time   `json:"starts_at,omitempty"`
+	EndsAt      *time.Time   `json:"ends_at,omitempty"`
+	IsActive    *bool        `json:"is_active,omitempty"`
+}
+
+// PromotionResponse — акция. Сумма фиксированной скидки указана в минимальных единицах валюты.
+type PromotionResponse struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Kind          string     `json:"kind" enums:"percent,fixed,bundle"`
+	ProductID     *int64     `json:"product_id,omitempty"`
+	CategoryID    *int64     `json:"category_id,omitempty"`
+	Percent       int64      `json:"percent,omitempty"`
+	Amount        *int64     `json:"amount,omitempty"`
+	Currency      string     `json:"currency,omitempty" example:"RUB"`
+	AmountDisplay string     `json:"amount_display,omitempty" example:"50.00 RUB"`
+	BuyQuantity   int64      `json:"buy_quantity,omitempty"`
+	PayQuantity   int64      `json:"pay_quantity,omitempty"`
+	Priority      int64      `json:"priority"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	EndsAt        *time.Time `json:"ends_at,omitempty"`
+	IsActive      bool       `json:"is_active"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListPromotionsResponse — список акций.
+type ListPromotionsResponse struct {
+	Items []PromotionResponse `json:"items"`
+}
+
+// PromotionItemRequest — товар и его кол-во или вес для расчёта скидок.
+type PromotionItemRequest struct {
+	ProductID   int64 `json:"product_id"`
+	Quantity    int64 `json:"quantity,omitempty" example:"1"`
+	WeightGrams int64 `json:"weight_grams,omitempty"`
+}
+
+// EvaluatePromotionsRequest — расчёт скидок для корзины или списка товаров; задаётся ровно одно из
+// checkout_id и items. Для списка товаров можно указать момент at, по умолчанию текущий.
+type EvaluatePromotionsRequest struct {
+	CheckoutID *string                `json:"checkout_id,omitempty"`
+	Items      []PromotionItemRequest `json:"items,omitempty"`
+	At         *time.Time             `json:"at,omitempty"`
+}
+
+// PromotionEvaluationResponse — результат проверки акции для позиции.
+type PromotionEvaluationResponse struct {
+	PromotionID     int64  `json:"promotion_id"`
+	Name            string `json:"name"`
+	Kind            string `json:"kind" enums:"percent,fixed,bundle"`
+	Outcome         string `json:"outcome" enums:"applied,outranked,not_applicable"`
+	Discount        int64  `json:"discount"`
+	DiscountDisplay string `json:"discount_display,omitempty" example:"12.00 RUB"`
+}
+
+// DiscountedLineResponse — позиция со скидкой и объяснением, какие акции проверены и какая применена.
+// Недоступная позиция не оценивается.
+type DiscountedLineResponse struct {
+	LineID      string                        `json:"line_id,omitempty"`
+	ProductID   int64                         `json:"product_id"`
+	Product     *ProductResponse              `json:"product,omitempty"`
+	Quantity    int64                         `json:"quantity"`
+	WeightGrams int64                         `json:"weight_grams,omitempty"`
+	Available   bool                          `json:"available"`
+	Total       int64                         `json:"total"`
+	Discount    int64                         `json:"discount"`
+	Promotions  []PromotionEvaluationResponse `json:"promotions"`
+}
+
+// DiscountedCartResponse — позиции со скидками и итоги в минимальных единицах валюты.
+type DiscountedCartResponse struct {
+	Lines        []DiscountedLineResponse `json:"lines"`
+	Subtotal     int64                    `json:"subtotal"`
+	Discount     int64                    `json:"discount"`
+	Total        int64                    `json:"total"`
+	Currency     string                   `json:"currency" example:"RUB"`
+	TotalDisplay string                   `json:"total_display" example:"107.98 RUB"`
+	EvaluatedAt  time.Time                `json:"evaluated_at"`
+}
+
+// CreateStoreRequest — создание магазина. Код состоит из латинских букв, цифр и символа "-".
+type CreateStoreRequest struct {
+	Code string `json:"code" example:"msk-001"`
+	Name string `json:"name"`
+}
+
+// StoreResponse — магазин.
+type StoreResponse struct {
+	ID        int64      `json:"id"`
+	Code      string     `json:"code" example:"msk-001"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListStoresResponse — список магазинов.
+type ListStoresResponse struct {
+	Items []StoreResponse `json:"items"`
+}
+
+// SetStoreProductRequest — товар в ассортименте магазина. Цена магазина передаётся в основных единицах валюты;
+// без price действует общая цена товара. Без is_available товар доступен для продажи.
+type SetStoreProductRequest struct {
+	IsAvailable *bool        `json:"is_available,omitempty"`
+	Price       *json.Number `json:"price,omitempty" swaggertype:"number" example:"589.99"`
+	Currency    *string      `json:"currency,omitempty" example:"RUB"`
+}
+
+// StoreProductResponse — товар в ассортименте магазина. Цена магазина указана в минимальных единицах валюты
+// и отсутствует, если действует общая цена товара. event_id заполняется при изменении ассортимента.
+type StoreProductResponse struct {
+	StoreID      int64      `json:"store_id"`
+	ProductID    int64      `json:"product_id"`
+	IsAvailable  bool       `json:"is_available"`
+	Price        *int64     `json:"price,omitempty"`
+	Currency     string     `json:"currency,omitempty" example:"RUB"`
+	PriceDisplay string     `json:"price_display,omitempty" example:"589.99 RUB"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	EventID      string     `json:"event_id,omitempty"`
+}
+
+// ListStoreProductsResponse — ассортимент магазина.
+type ListStoreProductsResponse struct {
+	Items []StoreProductResponse `json:"items"`
+}
+
+// SetStockRequest — фактический остаток товара в магазине в штуках, граммах или миллилитрах.
+type SetStockRequest struct {
+	OnHand *int64 `json:"on_hand" example:"120"`
+}
+
+// StockQuantityRequest — кол-во для списания или резервирования в штуках, граммах или миллилитрах.
+type StockQuantityRequest struct {
+	Quantity *int64 `json:"quantity" example:"1"`
+}
+
+// StockLevelResponse — остаток товара в магазине. reserved — сумма активных непросроченных резервов,
+// available — остаток, доступный для продажи и резервирования.
+type StockLevelResponse struct {
+	StoreID   int64      `json:"store_id"`
+	ProductID int64      `json:"product_id"`
+	OnHand    int64      `json:"on_hand"`
+	Reserved  int64      `json:"reserved"`
+	Available int64      `json:"available"`
+	InStock   bool       `json:"in_stock"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListStockResponse — остатки магазина.
+type ListStockResponse struct {
+	Items []StockLevelResponse `json:"items"`
+}
+
+// StockReservationResponse — резерв остатка. Активный резерв учитывается до expires_at.
+type StockReservationResponse struct {
+	ID        string     `json:"id"`
+	ProductID int64      `json:"product_id"`
+	Quantity  int64      `json:"quantity"`
+	Status    string     `json:"status" enums:"active,committed,released"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// StockChangeResponse — остаток после изменения, резерв для операций с резервом и ID события изменения.
+type StockChangeResponse struct {
+	Stock       StockLevelResponse        `json:"stock"`
+	Reservation *StockReservationResponse `json:"reservation,omitempty"`
+	EventID     string                    `json:"event_id"`
+}
+
+// MAPPERS
+
+func toProductResponse(pr *usecase.ProductInfo) ProductResponse {
+	return ProductResponse{
+		ID:             pr.ID,
+		Name:           pr.Name,
+		CategoryName:   pr.CategoryName,
+		CategoryPath:   pr.CategoryPath,
+		Price:          pr.Price.Amount,
+		Currency:       string(pr.Price.Currency),
+		PriceDisplay:   pr.Price.String(),
+		SKU:            pr.SKU,
+		Barcodes:       nonNilStrings(pr.Barcodes),
+		Unit:           string(pr.Unit),
+		IsWeighted:     pr.Unit.IsWeighted(),
+		Attributes:     pr.Attributes,
+		VariantGroupID: pr.VariantGroupID,
+		Version:        pr.Version,
+	}
+}
+
+func toProductDetailsResponse(details *usecase.ProductDetails) ProductDetailsResponse {
+	return ProductDetailsResponse{
+		ID:             details.Product.ID,
+		Name:           details.Product.Name,
+		LocalizedNames: nonNilLocalizedNames(details.Product.LocalizedNames),
+		CategoryID:     details.Product.CategoryID,
+		CategoryName:   details.CategoryName,
+		Price:          details.Product.Price.Amount,
+		Currency:       string(details.Product.Price.Currency),
+		PriceDisplay:   details.Product.Price.String(),
+		SKU:            details.Product.SKU,
+		Barcodes:       nonNilStrings(details.Product.Barcodes),
+		Unit:           string(details.Product.Unit),
+		IsWeighted:     details.Product.Unit.IsWeighted(),
+		Attributes:     nonNilAttributes(details.Product.Attributes),
+		VariantGroupID: details.Product.VariantGroupID,
+		Status:         string(details.Product.Status),
+		IsArchived:     details.Product.IsArchived,
+		CreatedAt:      details.Product.CreatedAt,
+		UpdatedAt:      details.Product.UpdatedAt,
+		Version:        details.Product.Version,
+	}
+}
+
+func toUpdateProductResponse(res *usecase.UpdateProductRes) *UpdateProductResponse {
+	return &UpdateProductResponse{
+		Product: toProductDetailsResponse(&res.Product),
+		EventID: res.Event.EventID.String(),
+	}
+}
+
+func toListProductsResponse(res *usecase.ListProductsRes) *ListProductsResponse {
+	items := make([]ProductDetailsResponse, 0, len(res.Products))
+	for _, product := range res.Products {
+		items = append(items, toProductDetailsResponse(&product))
+	}
+
+	return &ListProductsResponse{
+		Items:      items,
+		NextCursor: res.NextCursor,
+	}
+}
+
+func toProductTransitionResponse(transition *domain.ProductTransition) ProductTransitionResponse {
+	return ProductTransitionResponse{
+		ID:         transition.ID,
+		FromStatus: string(transition.FromStatus),
+		ToStatus:   string(transition.ToStatus),
+		Reviewer:   transition.Reviewer,
+		Comment:    transition.Comment,
+		CreatedAt:  transition.CreatedAt,
+	}
+}
+
+func toListProductTransitionsResponse(transitions []*domain.ProductTransition) *ListProductTransitionsResponse {
+	items := make([]ProductTransitionResponse, 0, len(transitions))
+	for _, transition := range transitions {
+		items = append(items, toProductTransitionResponse(transition))
+	}
+
+	return &ListProductTransitionsResponse{Items: items}
+}
+
+func toReviewResultResponse(res *usecase.ProductTransitionRes) *ReviewResultResponse {
+	return &ReviewResultResponse{
+		ProductID:  res.Product.ID,
+		Status:     string(res.Product.Status),
+		Version:    res.Product.Version,
+		Transition: toProductTransitionResponse(res.Transition),
+		EventID:    res.Event.EventID.String(),
+	}
+}
+
+func toDuplicateCandidatesResponse(candidates []usecase.DuplicateCandidate) []DuplicateCandidateResponse {
+	items := make([]DuplicateCandidateResponse, 0, len(candidates))
+	for _, candidate := range candidates {
+		items = append(items, DuplicateCandidateResponse{
+			ProductID: candidate.ProductID,
+			Name:      candidate.Name,
+			Score:     candidate.Score,
+		})
+	}
+
+	return items
+}
+
+func toDuplicateProductsErrorResponse(
+	status int,
+	code string,
+	message string,
+	candidates []usecase.DuplicateCandidate,
+) *DuplicateProductsErrorResponse {
+	return &DuplicateProductsErrorResponse{
+		Code:       status,
+		ErrorCode:  code,
+		Message:    message,
+		Duplicates: toDuplicateCandidatesResponse(candidates),
+	}
+}
+
+func toListDuplicateFlagsResponse(flags []*domain.DuplicateFlag) *ListDuplicateFlagsResponse {
+	items := make([]DuplicateFlagResponse, 0, len(flags))
+	for _, flag := range flags {
+		items = append(items, DuplicateFlagResponse{
+			CandidateID: flag.CandidateID,
+			Score:       flag.Score,
+			CreatedAt:   flag.CreatedAt,
+		})
+	}
+
+	return &ListDuplicateFlagsResponse{Items: items}
+}
+
+func toMergeProductResponse(res *usecase.MergeProductsRes) *MergeProductResponse {
+	embeddingIDs := make([]string, 0, len(res.Embeddings))
+	for _, embedding := range res.Embeddings {
+		embeddingIDs = append(embeddingIDs, embedding.ID)
+	}
+
+	return &MergeProductResponse{
+		SourceID:      res.Source.ID,
+		TargetID:      res.Target.ID,
+		SourceVersion: res.Source.Version,
+		TargetVersion: res.Target.Version,
+		EmbeddingIDs:  embeddingIDs,
+		SourceEventID: res.SourceEvent.EventID.String(),
+		TargetEventID: res.TargetEvent.EventID.String(),
+	}
+}
+
+func toAddProductImagesResponse(res *usecase.AddProductImagesRes) *AddProductImagesResponse {
+	return &AddProductImagesResponse{
+		ImageIDs: res.ImageIDs,
+		EventID:  res.Event.EventID.String(),
+	}
+}
+
+func toProductPriceResponse(price *domain.ProductPrice) ProductPriceResponse {
+	return ProductPriceResponse{
+		ID:            price.ID,
+		ProductID:     price.ProductID,
+		Price:         price.Price.Amount,
+		Currency:      string(price.Price.Currency),
+		PriceDisplay:  price.Price.String(),
+		EffectiveFrom: price.EffectiveFrom,
+		AppliedAt:     price.AppliedAt,
+		IsScheduled:   price.IsScheduled(),
+		CreatedAt:     price.CreatedAt,
+	}
+}
+
+func toPriceHistoryResponse(prices []*domain.ProductPrice) *PriceHistoryResponse {
+	items := make([]ProductPriceResponse, 0, len(prices))
+	for _, price := range prices {
+		items = append(items, toProductPriceResponse(price))
+	}
+
+	return &PriceHistoryResponse{Items: items}
+}
+
+func toWeightedPriceResponse(price *usecase.WeightedPrice) *WeightedPriceResponse {
+	return &WeightedPriceResponse{
+		ProductID:    price.Product.ID,
+		Name:         price.Product.Name,
+		Unit:         string(price.Product.Unit),
+		UnitPrice:    price.Product.Price.Amount,
+		WeightGrams:  price.WeightGrams,
+		Total:        price.Total.Amount,
+		Currency:     string(price.Total.Currency),
+		TotalDisplay: price.Total.String(),
+	}
+}
+
+func toCategoryResponse(category *domain.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:         category.ID,
+		Name:       category.Name,
+		ParentID:   category.ParentID,
+		IsArchived: category.IsArchived,
+		CreatedAt:  category.CreatedAt,
+		UpdatedAt:  category.UpdatedAt,
+	}
+}
+
+func toCategoryDetailsResponse(details *usecase.CategoryDetails) CategoryResponse {
+	res := toCategoryResponse(details.Category)
+	res.Path = details.Path
+	res.ProductCount = &details.ProductCount
+
+	return res
+}
+
+func toListCategoriesResponse(categories []usecase.CategoryDetails) *ListCategoriesResponse {
+	items := make([]CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		items = append(items, toCategoryDetailsResponse(&category))
+	}
+
+	return &ListCategoriesResponse{Items: items}
+}
+
+func toAttributeDefinitions(defs []domain.AttributeDef) []AttributeDefinition {
+	res := make([]AttributeDefinition, 0, len(defs))
+	for _, def := range defs {
+		res = append(res, AttributeDefinition{
+			Code:     def.Code,
+			Name:     def.Name,
+			Type:     string(def.Type),
+			Unit:     def.Unit,
+			Values:   def.Values,
+			Required: def.Required,
+		})
+	}
+
+	return res
+}
+
+func toAttributeDefs(definitions []AttributeDefinition) []domain.AttributeDef {
+	defs := make([]domain.AttributeDef, 0, len(definitions))
+	for _, definition := range definitions {
+		defs = append(defs, domain.AttributeDef{
+			Code:     definition.Code,
+			Name:     definition.Name,
+			Type:     domain.AttributeType(definition.Type),
+			Unit:     definition.Unit,
+			Values:   definition.Values,
+			Required: definition.Required,
+		})
+	}
+
+	return defs
+}
+
+func toAttributeSchemaResponse(details *usecase.AttributeSchemaDetails) *AttributeSchemaResponse {
+	return &AttributeSchemaResponse{
+		CategoryID: details.Schema.CategoryID,
+		Attributes: toAttributeDefinitions(details.Schema.Attributes),
+		Effective:  toAttributeDefinitions(details.Effective),
+		UpdatedAt:  details.Schema.UpdatedAt,
+	}
+}
+
+func toVariantGroupResponse(group *domain.VariantGroup) VariantGroupResponse {
+	return VariantGroupResponse{
+		ID:        group.ID,
+		Name:      group.Name,
+		Axes:      nonNilStrings(group.Axes),
+		CreatedAt: group.CreatedAt,
+		UpdatedAt: group.UpdatedAt,
+	}
+}
+
+func toVariantGroupDetailsResponse(details *usecase.VariantGroupDetails) *VariantGroupResponse {
+	res := toVariantGroupResponse(details.Group)
+	res.Variants = make([]ProductResponse, 0, len(details.Variants))
+	for _, variant := range details.Variants {
+		res.Variants = append(res.Variants, toProductResponse(&variant))
+	}
+
+	return &res
+}
+
+func toListVariantGroupsResponse(groups []*domain.VariantGroup) *ListVariantGroupsResponse {
+	items := make([]VariantGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		items = append(items, toVariantGroupResponse(group))
+	}
+
+	return &ListVariantGroupsResponse{Items: items}
+}
+
+func toRecognizeProductResponse(res *usecase.RecognizeProductRes) *RecognizeProductResponse {
+	candidates := make([]RecognitionCandidateResponse, 0, len(res.Candidates))
+	for _, c := range res.Candidates {
+		candidate := RecognitionCandidateResponse{
+			Product: toProductResponse(&c.Product),
+			Score:   c.Score,
+			Hits:    c.Hits,
+		}
+		if c.Stock != nil {
+			stock := toStockLevelResponse(c.Stock)
+			candidate.Stock = &stock
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	response := &RecognizeProductResponse{
+		Verdict:      string(res.Verdict),
+		Candidates:   candidates,
+		ModelVersion: res.ModelVersion,
+	}
+	if res.VariantGroup != nil {
+		response.VariantGroup = toVariantGroupDetailsResponse(res.VariantGroup)
+	}
+
+	return response
+}
+
+// nonNilStrings заменяет nil на пустой список, чтобы в JSON был [] вместо null.
+func toCheckoutResponse(details *usecase.CheckoutDetails) CheckoutResponse {
+	lines := make([]CheckoutLineResponse, 0, len(details.Lines))
+	for _, line := range details.Lines {
+		res := CheckoutLineResponse{
+			ID:               line.Line.ID,
+			ProductID:        line.Line.ProductID,
+			Quantity:         line.Line.Quantity,
+			WeightGrams:      line.Line.WeightGrams,
+			Method:           string(line.Line.Method),
+			Barcode:          line.Line.Barcode,
+			RecognitionScore: line.Line.RecognitionScore,
+			ModelVersion:     line.Line.ModelVersion,
+			Available:        line.Available,
+			AddedAt:          line.Line.AddedAt,
+		}
+		if line.Product != nil {
+			product := toProductResponse(line.Product)
+			res.Product = &product
+		}
+		if line.Available {
+			res.Total = line.Total.Amount
+			res.Currency = string(line.Total.Currency)
+			res.TotalDisplay = line.Total.String()
+		}
+
+		lines = append(lines, res)
+	}
+
+	return CheckoutResponse{
+		ID:           details.Session.ID,
+		Status:       string(details.Session.Status),
+		Lines:        lines,
+		Total:        details.Total.Amount,
+		Currency:     string(details.Total.Currency),
+		TotalDisplay: details.Total.String(),
+		CreatedAt:    details.Session.CreatedAt,
+		UpdatedAt:    details.Session.UpdatedAt,
+		CompletedAt:  details.Session.CompletedAt,
+	}
+}
+
+func toAddRecognizedItemResponse(res *usecase.AddRecognizedItemRes) *AddRecognizedItemResponse {
+	return &AddRecognizedItemResponse{
+		Checkout:    toCheckoutResponse(res.Checkout),
+		LineID:      res.LineID,
+		Recognition: *toRecognizeProductResponse(res.Recognition),
+	}
+}
+
+func toCompleteCheckoutResponse(res *usecase.CompleteCheckoutRes) *CompleteCheckoutResponse {
+	return &CompleteCheckoutResponse{
+		Checkout: toCheckoutResponse(res.Checkout),
+		EventID:  res.Event.EventID.String(),
+	}
+}
+
+func toPromotionResponse(promotion *domain.Promotion) PromotionResponse {
+	res := PromotionResponse{
+		ID:          promotion.ID,
+		Name:        promotion.Name,
+		Kind:        string(promotion.Kind),
+		ProductID:   promotion.ProductID,
+		CategoryID:  promotion.CategoryID,
+		Percent:     promotion.Percent,
+		BuyQuantity: promotion.BuyQuantity,
+		PayQuantity: promotion.PayQuantity,
+		Priority:    promotion.Priority,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      promotion.EndsAt,
+		IsActive:    promotion.IsActive,
+		CreatedAt:   promotion.CreatedAt,
+		UpdatedAt:   promotion.UpdatedAt,
+	}
+	if promotion.Amount != nil {
+		res.Amount = &promotion.Amount.Amount
+		res.Currency = string(promotion.Amount.Currency)
+		res.AmountDisplay = promotion.Amount.String()
+	}
+
+	return res
+}
+
+func toListPromotionsResponse(promotions []*domain.Promotion) *ListPromotionsResponse {
+	items := make([]PromotionResponse, 0, len(promotions))
+	for _, promotion := range promotions {
+		items = append(items, toPromotionResponse(promotion))
+	}
+
+	return &ListPromotionsResponse{Items: items}
+}
+
+func toDiscountedCartResponse(cart *usecase.DiscountedCart) *DiscountedCartResponse {
+	lines := make([]DiscountedLineResponse, 0, len(cart.Lines))
+	for _, line := range cart.Lines {
+		res := DiscountedLineResponse{
+			LineID:      line.LineID,
+			ProductID:   line.ProductID,
+			Quantity:    line.Quantity,
+			WeightGrams: line.WeightGrams,
+			Available:   line.Available,
+			Total:       line.Total.Amount,
+			Discount:    line.Discount.Amount,
+			Promotions:  make([]PromotionEvaluationResponse, 0, len(line.Evaluations)),
+		}
+		if line.Product != nil {
+			product := toProductResponse(line.Product)
+			res.Product = &product
+		}
+
+		for _, evaluation := range line.Evaluations {
+			promotion := PromotionEvaluationResponse{
+				PromotionID: evaluation.Promotion.ID,
+				Name:        evaluation.Promotion.Name,
+				Kind:        string(evaluation.Promotion.Kind),
+				Outcome:     string(evaluation.Outcome),
+				Discount:    evaluation.Discount.Amount,
+			}
+			if evaluation.Outcome != domain.PromotionNotApplicable {
+				promotion.DiscountDisplay = evaluation.Discount.String()
+			}
+
+			res.Promotions = append(res.Promotions, promotion)
+		}
+
+		lines = append(lines, res)
+	}
+
+	return &DiscountedCartResponse{
+		Lines:        lines,
+		Subtotal:     cart.Subtotal.Amount,
+		Discount:     cart.Discount.Amount,
+		Total:        cart.Total.Amount,
+		Currency:     string(cart.Total.Currency),
+		TotalDisplay: cart.Total.String(),
+		EvaluatedAt:  cart.EvaluatedAt,
+	}
+}
+
+func toStoreResponse(store *domain.Store) StoreResponse {
+	return StoreResponse{
+		ID:        store.ID,
+		Code:      store.Code,
+		Name:      store.Name,
+		CreatedAt: store.CreatedAt,
+		UpdatedAt: store.UpdatedAt,
+	}
+}
+
+func toListStoresResponse(stores []*domain.Store) *ListStoresResponse {
+	items := make([]StoreResponse, 0, len(stores))
+	for _, store := range stores {
+		items = append(items, toStoreResponse(store))
+	}
+
+	return &ListStoresResponse{Items: items}
+}
+
+func toStoreProductResponse(product *domain.StoreProduct) StoreProductResponse {
+	res := StoreProductResponse{
+		StoreID:     product.StoreID,
+		ProductID:   product.ProductID,
+		IsAvailable: product.IsAvailable,
+		UpdatedAt:   product.UpdatedAt,
+	}
+	if product.Price != nil {
+		res.Price = &product.Price.Amount
+		res.Currency = string(product.Price.Currency)
+		res.PriceDisplay = product.Price.String()
+	}
+
+	return res
+}
+
+func toListStoreProductsResponse(products []*domain.StoreProduct) *ListStoreProductsResponse {
+	items := make([]StoreProductResponse, 0, len(products))
+	for _, product := range products {
+		items = append(items, toStoreProductResponse(product))
+	}
+
+	return &ListStoreProductsResponse{Items: items}
+}
+
+func toSetStoreProductResponse(res *usecase.SetStoreProductRes) StoreProductResponse {
+	product := toStoreProductResponse(res.Product)
+	product.EventID = res.Event.EventID.String()
+
+	return product
+}
+
+func toStockLevelResponse(level *domain.StockLevel) StockLevelResponse {
+	return StockLevelResponse{
+		StoreID:   level.StoreID,
+		ProductID: level.ProductID,
+		OnHand:    level.OnHand,
+		Reserved:  level.Reserved,
+		Available: level.Available(),
+		InStock:   level.InStock(),
+		UpdatedAt: level.UpdatedAt,
+	}
+}
+
+func toListStockResponse(levels []*domain.StockLevel) *ListStockResponse {
+	items := make([]StockLevelResponse, 0, len(levels))
+	for _, level := range levels {
+		items = append(items, toStockLevelResponse(level))
+	}
+
+	return &ListStockResponse{Items: items}
+}
+
+func toStockChangeResponse(res *usecase.StockChangeRes) *StockChangeResponse {
+	change := &StockChangeResponse{
+		Stock:   toStockLevelResponse(res.Level),
+		EventID: res.Event.EventID.String(),
+	}
+	if res.Reservation != nil {
+		change.Reservation = &StockReservationResponse{
+			ID:        res.Reservation.ID.String(),
+			ProductID: res.Reservation.ProductID,
+			Quantity:  res.Reservation.Quantity,
+			Status:    string(res.Reservation.Status),
+			ExpiresAt: res.Reservation.ExpiresAt,
+			CreatedAt: res.Reservation.CreatedAt,
+			UpdatedAt: res.Reservation.UpdatedAt,
+		}
+	}
+
+	return change
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+// nonNilAttributes заменяет nil на пустой объект, чтобы в JSON был {} вместо null.
+func nonNilAttributes(attrs domain.Attributes) domain.Attributes {
+	if attrs == nil {
+		return domain.Attributes{}
+	}
+
+	return attrs
+}
+
+// nonNilLocalizedNames заменяет nil на пустой объект, чтобы в JSON был {} вместо null.
+func nonNilLocalizedNames(names domain.LocalizedNames) map[string]string {
+	if names == nil {
+		return map[string]string{}
+	}
+
+	return names
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/go-chi/chi/v5"
+)
+
+// getPriceHistory
+//
+//	@Summary		История цен товара
+//	@Description	Возвращает применённые и запланированные цены товара, начиная с самой поздней
+//	@Tags			prices
+//	@Produce		json
+//	@Param			id	path		int						true	"ID товара"
+//	@Success		200	{object}	PriceHistoryResponse	"Цены товара"
+//	@Failure		400	{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse			"Товар не найден"
+//	@Router			/products/{id}/prices [get]
+func (p *ProductHandler) getPriceHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	prices, err := p.productUsecase.GetPriceHistory(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toPriceHistoryResponse(prices))
+}
+
+// getEffectivePrice
+//
+//	@Summary		Цена товара на момент времени
+//	@Description	Возвращает цену товара, действующую в момент at. Для будущего момента учитываются запланированные цены.
+//	@Tags			prices
+//	@Produce		json
+//	@Param			id	path		int						true	"ID товара"
+//	@Param			at	query		string					false	"Момент времени в формате RFC 3339, по умолчанию текущий"
+//	@Success		200	{object}	ProductPriceResponse	"Действующая цена"
+//	@Failure		400	{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse			"Товар не найден или не имел цены в этот момент"
+//	@Router			/products/{id}/prices/effective [get]
+func (p *ProductHandler) getEffectivePrice(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	at, err := parseOptionalTime(r.URL.Query().Get("at"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if at == nil {
+		now := time.Now().UTC()
+		at = &now
+	}
+
+	price, err := p.productUsecase.GetEffectivePrice(r.Context(), id, *at)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toProductPriceResponse(price))
+}
+
+// getPriceByWeight
+//
+//	@Summary		Стоимость весового товара
+//	@Description	Рассчитывает стоимость товара, продаваемого на вес, по цене за килограмм и показанию весов в граммах.
+//	@Description	Сумма округляется до минимальной единицы валюты (половина — вверх), но не меньше одной минимальной единицы.
+//	@Tags			prices
+//	@Produce		json
+//	@Param			id				path		int						true	"ID товара"
+//	@Param			weight_grams	query		int						true	"Показание весов в граммах"
+//	@Param			X-Store-ID		header		int						false	"ID магазина"
+//	@Success		200				{object}	WeightedPriceResponse	"Стоимость товара"
+//	@Failure		400				{object}	ErrorResponse			"Ошибка валидации или товар продаётся не на вес"
+//	@Failure		404				{object}	ErrorResponse			"Товар не найден"
+//	@Router			/products/{id}/prices/by-weight [get]
+func (p *ProductHandler) getPriceByWeight(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	grams, err := parseWeightGrams(r.URL.Query().Get("weight_grams"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	price, err := p.productUsecase.PriceByWeight(r.Context(), usecase.NewPriceByWeightReq(id, grams))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toWeightedPriceResponse(price))
+}
+
+// schedulePrice
+//
+//	@Summary		Планирование цены товара
+//	@Description	Сохраняет цену, которая будет применена к товару в момент effective_from
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара"
+//	@Param			request	body		SchedulePriceRequest	true	"Цена и время начала её действия"
+//	@Success		201		{object}	ProductPriceResponse	"Запланированная цена"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар не найден"
+//	@Router			/products/{id}/prices [post]
+func (p *ProductHandler) schedulePrice(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	req, price, err := parseSchedulePriceRequest(r)
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	scheduled, err := p.productUsecase.SchedulePrice(r.Context(), usecase.NewSchedulePriceReq(id, price, req.EffectiveFrom))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toProductPriceResponse(scheduled))
+}
+
+// cancelScheduledPrice
+//
+//	@Summary		Отмена запланированной цены
+//	@Description	Удаляет ещё не применённую цену товара
+//	@Tags			prices
+//	@Produce		json
+//	@Param			id		path		int				true	"ID товара"
+//	@Param			priceId	path		int				true	"ID цены"
+//	@Success		204		"Цена отменена"
+//	@Failure		400		{object}	ErrorResponse	"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse	"Запланированная цена не найдена"
+//	@Router			/products/{id}/prices/{priceId} [delete]
+func (p *ProductHandler) cancelScheduledPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	priceID, err := parseID(chi.URLParam(r, "priceId"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if err := p.productUsecase.CancelScheduledPrice(r.Context(), id, priceID); err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
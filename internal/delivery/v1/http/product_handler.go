@@ -7,6 +7,7 @@ import (
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/e"
 	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
 )
 
 type ProductHandler struct {
@@ -22,16 +23,32 @@ func NewProductHandler(productUsecase usecase.ProductUC, logger logger.Logger) *
 // registerNewProduct
 //
 //	@Summary		Регистрация нового товара
-//	@Description	Создает новый товар в каталоге с изображениями
+//	@Description	Создает новый товар в каталоге с изображениями. Новый товар ожидает проверки (pending_review)
+//	@Description	и не участвует в распознавании, пока его не одобрят.
+//	@Description	Товар с существующим названием изменяется, только если If-Match содержит его текущую версию.
+//	@Description	Изображения нового товара сравниваются с опубликованными товарами. При похожести не ниже порога
+//	@Description	регистрация по умолчанию отклоняется с 409 и списком вероятных дубликатов; при on_duplicate=review товар создаётся,
+//	@Description	а вероятные дубликаты возвращаются в поле Duplicates и сохраняются для проверяющего.
 //	@Tags			products
 //	@Accept			multipart/form-data
 //	@Produce		json
-//	@Param			name			formData	string					true	"Название товара"
-//	@Param			category_name	formData	string					true	"Категория"
-//	@Param			price			formData	number					true	"Цена"
-//	@Param			images			formData	file					true	"Изображения товара"
-//	@Success		201				{object}	map[string]interface{}	"Успешное создание"
-//	@Failure		400				{object}	ErrorResponse	"Ошибка валидации"
+//	@Param			name				formData	string							true	"Название товара"
+//	@Param			category_name		formData	string							true	"Категория"
+//	@Param			price				formData	number							true	"Цена в основных единицах валюты"
+//	@Param			localized_names		formData	string							false	"Переводы названия JSON-объектом по кодам языков en и ru"
+//	@Param			currency			formData	string							false	"Код валюты ISO 4217, по умолчанию RUB"
+//	@Param			sku					formData	string							false	"Артикул; пустое значение удаляет артикул существующего товара"
+//	@Param			barcode				formData	[]string						false	"Штрихкоды EAN-8, UPC-A, EAN-13 или GTIN-14"	collectionFormat(multi)
+//	@Param			unit				formData	string							false	"Единица измерения, по умолчанию piece"	Enums(piece, kg, l)
+//	@Param			attributes			formData	string							false	"Атрибуты товара JSON-объектом по схеме категории"
+//	@Param			variant_group_id	formData	int								false	"ID группы вариантов; 0 исключает существующий товар из группы"
+//	@Param			images				formData	file							true	"Изображения товара"
+//	@Param			on_duplicate		formData	string							false	"Действие при вероятном дубликате: reject – отказ, review – отметка для проверяющего"	Enums(reject, review)
+//	@Param			If-Match			header		string							false	"ETag существующего товара"
+//	@Success		201					{object}	map[string]interface{}			"Успешное создание"
+//	@Failure		400					{object}	ErrorResponse					"Ошибка валидации"
+//	@Failure		404					{object}	ErrorResponse					"Группа вариантов не найдена"
+//	@Failure		409					{object}	DuplicateProductsErrorResponse	"Товар уже существует, артикул или штрихкод занят, вариант уже есть в группе, версия не совпадает с If-Match или товар похож на опубликованные (possible_duplicate, заполняется duplicates)"
 //	@Router			/products [post]
 func (p *ProductHandler) registerNewProduct(w http.ResponseWriter, r *http.Request) {
 	const (
@@ -54,6 +71,13 @@ func (p *ProductHandler) registerNewProduct(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	version, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
 	images, err := parseImages(r.MultipartForm.File["images"])
 	if err != nil {
 		if !errors.Is(err, e.ErrNoImages) {
@@ -63,20 +87,498 @@ func (p *ProductHandler) registerNewProduct(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	event, err := p.productUsecase.RegisterNewProduct(r.Context(), usecase.NewAddNewProductReq(prMeta.Name, prMeta.CategoryName, prMeta.Price, images))
+	res, err := p.productUsecase.RegisterNewProduct(r.Context(), usecase.NewAddNewProductReq(
+		prMeta.Name, prMeta.CategoryName, prMeta.Price, prMeta.LocalizedNames, prMeta.Identifiers, prMeta.Variant, images, version,
+		prMeta.OnDuplicate,
+	))
 	if err != nil {
 		p.logger.Warnf("%s", err.Error())
+
+		var duplicatesErr *usecase.DuplicateProductsError
+		if errors.As(err, &duplicatesErr) {
+			WriteDuplicatesError(w, duplicatesErr)
+			return
+		}
+
 		WriteError(w, err)
 		return
 	}
 
-	if event != nil {
-		WriteSuccess(w, http.StatusCreated, map[string]interface{}{
-			"EventID": event.EventID,
-		})
+	if res.Event != nil {
+		resp := map[string]interface{}{
+			"EventID": res.Event.EventID,
+		}
+		// Вероятные дубликаты возвращаются только при on_duplicate=review
+		if len(res.Duplicates) > 0 {
+			resp["Duplicates"] = toDuplicateCandidatesResponse(res.Duplicates)
+		}
+
+		WriteSuccess(w, http.StatusCreated, resp)
 	} else {
 		WriteSuccess(w, http.StatusOK, map[string]interface{}{
 			"Changed": true,
 		})
 	}
 }
+
+// recognizeProduct
+//
+//	@Summary		Распознавание товара по фото
+//	@Description	Векторизует один или несколько кадров одного товара и возвращает наиболее похожие товары каталога.
+//	@Description	Результаты кадров объединяются стратегией fusion: rrf – поиск по каждому кадру и Reciprocal Rank Fusion, centroid – поиск по усреднённому вектору.
+//	@Description	verdict: accepted – первый кандидат распознан уверенно, variant – уверенно распознана группа вариантов (variant_group), но не вариант,
+//	@Description	ambiguous – требуется выбор кассира, unknown – товар не найден.
+//	@Description	Вариант группы уточняется повторным распознаванием с фильтром attributes по осям группы.
+//	@Tags			recognition
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			images			formData	file						true	"Кадры товара"
+//	@Param			limit			formData	int							false	"Макс. кол-во кандидатов"
+//	@Param			fusion			formData	string						false	"Стратегия объединения кадров"	Enums(rrf, centroid)
+//	@Param			category_id		formData	int							false	"Поиск только среди товаров категории и её подкатегорий"
+//	@Param			include_stock	formData	bool						false	"Добавить к кандидатам остатки магазина; требует X-Store-ID"
+//	@Param			attributes		formData	string						false	"Поиск только среди товаров с указанными значениями атрибутов, JSON-объект"
+//	@Param			X-Store-ID		header		int							false	"ID магазина"
+//	@Success		200				{object}	RecognizeProductResponse	"Кандидаты распознавания"
+//	@Failure		400				{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404				{object}	ErrorResponse				"Магазин не найден"
+//	@Router			/recognize [post]
+func (p *ProductHandler) recognizeProduct(w http.ResponseWriter, r *http.Request) {
+	const (
+		maxTotalRequestSize = 100 << 20
+		maxMemory           = 32 << 20
+	)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTotalRequestSize)
+
+	if err := ensureMultipartForm(r, maxMemory); err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), r.Header.Get("Content-Type"))
+		WriteError(w, err)
+		return
+	}
+
+	limit, err := parseLimit(r.FormValue("limit"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	categoryID, err := parseOptionalID(r.FormValue("category_id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	includeStock, err := parseBool(r.FormValue("include_stock"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	attrs, err := parseAttributes(r.FormValue("attributes"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	// Одиночное поле image поддерживается для обратной совместимости
+	files := append(r.MultipartForm.File["images"], r.MultipartForm.File["image"]...)
+	frames, err := parseFrames(files)
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := p.productUsecase.RecognizeProduct(r.Context(), usecase.NewRecognizeProductReq(
+		frames, limit, r.FormValue("fusion"), categoryID, includeStock, attrs,
+	))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toRecognizeProductResponse(res))
+}
+
+// deleteProduct
+//
+//	@Summary		Удаление товара
+//	@Description	По умолчанию товар архивируется: он перестаёт распознаваться и отдаваться в информации о товарах.
+//	@Description	Архивировать можно только опубликованный товар (active).
+//	@Description	При hard=true товар удаляется безвозвратно вместе с векторами и изображениями.
+//	@Tags			products
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара"
+//	@Param			hard	query		bool					false	"Безвозвратное удаление"
+//	@Success		200		{object}	map[string]interface{}	"ID события изменения товара"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар не найден"
+//	@Failure		409		{object}	ErrorResponse			"Недопустимый переход статуса"
+//	@Router			/products/{id} [delete]
+func (p *ProductHandler) deleteProduct(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	hard, err := parseBool(r.URL.Query().Get("hard"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	var event *usecase.OutboxEvent
+	if hard {
+		event, err = p.productUsecase.DeleteProduct(r.Context(), id)
+	} else {
+		event, err = p.productUsecase.ArchiveProduct(r.Context(), id)
+	}
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, map[string]interface{}{
+		"EventID": event.EventID,
+	})
+}
+
+// unarchiveProduct
+//
+//	@Summary		Восстановление товара из архива
+//	@Description	Возвращает архивный товар в статус active.
+//	@Tags			products
+//	@Produce		json
+//	@Param			id	path		int						true	"ID товара"
+//	@Success		200	{object}	map[string]interface{}	"ID события изменения товара"
+//	@Failure		400	{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse			"Товар не найден"
+//	@Failure		409	{object}	ErrorResponse			"Недопустимый переход статуса, товар слит или категория в архиве"
+//	@Router			/products/{id}/unarchive [post]
+func (p *ProductHandler) unarchiveProduct(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	event, err := p.productUsecase.UnarchiveProduct(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, map[string]interface{}{
+		"EventID": event.EventID,
+	})
+}
+
+// mergeProduct
+//
+//	@Summary		Слияние товара-дубликата
+//	@Description	Переносит изображения и векторы товара из пути запроса товару target_id и архивирует исходный товар.
+//	@Description	По ID исходного товара информация о товарах возвращает товар target_id. Восстановить слитый товар нельзя.
+//	@Description	Слияние отклоняется, если у товара target_id вместе с перенесёнными станет больше 10 изображений.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара-дубликата"
+//	@Param			request	body		MergeProductRequest		true	"ID товара, в который сливается дубликат"
+//	@Success		200		{object}	MergeProductResponse	"Результат слияния"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации или у товара target_id станет больше 10 изображений"
+//	@Failure		404		{object}	ErrorResponse			"Товар не найден"
+//	@Failure		409		{object}	ErrorResponse			"Товар уже слит или товар target_id в архиве"
+//	@Router			/products/{id}/merge [post]
+func (p *ProductHandler) mergeProduct(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 10
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req MergeProductRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if req.TargetID <= 0 {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), e.ErrInvalidID.Error())
+		WriteError(w, e.Wrap("target_id", e.ErrInvalidID))
+		return
+	}
+
+	res, err := p.productUsecase.MergeProducts(r.Context(), usecase.NewMergeProductsReq(id, req.TargetID))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toMergeProductResponse(res))
+}
+
+// getProduct
+//
+//	@Summary		Получение товара
+//	@Description	Возвращает товар, включая архивный. Заголовок ETag содержит версию товара для If-Match при изменении.
+//	@Tags			products
+//	@Produce		json
+//	@Param			id	path		int						true	"ID товара"
+//	@Success		200	{object}	ProductDetailsResponse	"Товар"
+//	@Header			200	{string}	ETag					"Версия товара"
+//	@Failure		400	{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse			"Товар не найден"
+//	@Router			/products/{id} [get]
+func (p *ProductHandler) getProduct(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	details, err := p.productUsecase.GetProduct(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(details.Product.Version))
+	WriteSuccess(w, http.StatusOK, toProductDetailsResponse(details))
+}
+
+// getProductByBarcode
+//
+//	@Summary		Поиск товара по штрихкоду
+//	@Description	Возвращает неархивный товар по штрихкоду EAN-8, UPC-A, EAN-13 или GTIN-14.
+//	@Description	Используется кассой, если товар не удалось распознать по фото.
+//	@Tags			products
+//	@Produce		json
+//	@Param			barcode		path		string			true	"Штрихкод"
+//	@Param			X-Store-ID	header		int				false	"ID магазина"
+//	@Success		200			{object}	ProductResponse	"Товар"
+//	@Failure		400			{object}	ErrorResponse	"Некорректный штрихкод"
+//	@Failure		404			{object}	ErrorResponse	"Товар не найден"
+//	@Router			/products/barcodes/{barcode} [get]
+func (p *ProductHandler) getProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	product, err := p.productUsecase.GetProductByBarcode(r.Context(), chi.URLParam(r, "barcode"))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toProductResponse(product))
+}
+
+// updateProduct
+//
+//	@Summary		Изменение товара
+//	@Description	Частично изменяет название, переводы названия, цену, категорию, артикул, штрихкоды, единицу измерения, атрибуты и группу вариантов товара
+//	@Description	без изменения изображений. Атрибуты проверяются по схеме категории товара.
+//	@Description	Товар изменяется, только если его версия совпадает с переданным в If-Match значением ETag.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int						true	"ID товара"
+//	@Param			If-Match	header		string					true	"ETag товара"
+//	@Param			request		body		UpdateProductRequest	true	"Изменяемые поля"
+//	@Success		200			{object}	UpdateProductResponse	"Товар после изменения"
+//	@Header			200			{string}	ETag					"Новая версия товара"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Товар или группа вариантов не найдены"
+//	@Failure		409			{object}	ErrorResponse			"Название, артикул или штрихкод заняты другим товаром, вариант уже есть в группе или версия не совпадает"
+//	@Failure		428			{object}	ErrorResponse			"Не передан If-Match"
+//	@Router			/products/{id} [patch]
+func (p *ProductHandler) updateProduct(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	version, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	req, price, ids, err := parseUpdateProductRequest(r)
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	variant := usecase.ProductVariant{Attributes: req.Attributes, VariantGroupID: req.VariantGroupID}
+	res, err := p.productUsecase.UpdateProduct(r.Context(), usecase.NewUpdateProductReq(
+		id, req.Name, req.CategoryName, price, req.LocalizedNames, ids, variant, version,
+	))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(res.Product.Product.Version))
+	WriteSuccess(w, http.StatusOK, toUpdateProductResponse(res))
+}
+
+// addProductImages
+//
+//	@Summary		Добавление изображений товара
+//	@Description	Загружает изображения существующего товара и добавляет их векторы для распознавания
+//	@Tags			products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int							true	"ID товара"
+//	@Param			images	formData	file						true	"Изображения товара"
+//	@Success		201		{object}	AddProductImagesResponse	"ID добавленных изображений"
+//	@Failure		400		{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse				"Товар не найден"
+//	@Router			/products/{id}/images [post]
+func (p *ProductHandler) addProductImages(w http.ResponseWriter, r *http.Request) {
+	const (
+		maxTotalRequestSize = 150 << 20
+		maxMemory           = 32 << 20
+	)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTotalRequestSize)
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if err := ensureMultipartForm(r, maxMemory); err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), r.Header.Get("Content-Type"))
+		WriteError(w, err)
+		return
+	}
+
+	images, err := parseImages(r.MultipartForm.File["images"])
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := p.productUsecase.AddProductImages(r.Context(), usecase.NewAddProductImagesReq(id, images))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toAddProductImagesResponse(res))
+}
+
+// deleteProductImage
+//
+//	@Summary		Удаление изображения товара
+//	@Description	Удаляет изображение товара и его вектор. ID изображения совпадает с ID вектора.
+//	@Tags			products
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара"
+//	@Param			imageId	path		string					true	"ID изображения"
+//	@Success		200		{object}	map[string]interface{}	"ID события изменения товара"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар или изображение не найдены"
+//	@Router			/products/{id}/images/{imageId} [delete]
+func (p *ProductHandler) deleteProductImage(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	imageID, err := parseImageID(chi.URLParam(r, "imageId"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	event, err := p.productUsecase.DeleteProductImage(r.Context(), id, imageID)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, map[string]interface{}{
+		"EventID": event.EventID,
+	})
+}
+
+// listProducts
+//
+//	@Summary		Список товаров
+//	@Description	Возвращает страницу товаров с фильтрацией и сортировкой. Следующая страница запрашивается
+//	@Description	с параметром cursor из next_cursor предыдущего ответа при тех же sort и order.
+//	@Tags			products
+//	@Produce		json
+//	@Param			category_id				query		int						false	"ID категории"
+//	@Param			include_subcategories	query		bool					false	"Учитывать товары подкатегорий"
+//	@Param			currency				query		string					false	"Код валюты ISO 4217; min_price и max_price задаются в ней, по умолчанию в RUB"
+//	@Param			min_price				query		number					false	"Минимальная цена"
+//	@Param			max_price				query		number					false	"Максимальная цена"
+//	@Param			archived				query		bool					false	"Признак архивации, по умолчанию все товары"
+//	@Param			status					query		string					false	"Статус товара, по умолчанию все"	Enums(draft, pending_review, active, archived)
+//	@Param			created_from			query		string					false	"Создан не раньше (RFC 3339)"
+//	@Param			created_to				query		string					false	"Создан раньше (RFC 3339)"
+//	@Param			updated_from			query		string					false	"Изменён не раньше (RFC 3339)"
+//	@Param			updated_to				query		string					false	"Изменён раньше (RFC 3339)"
+//	@Param			sort					query		string					false	"Поле сортировки"			Enums(id, name, price, created_at, updated_at)
+//	@Param			order					query		string					false	"Направление сортировки"	Enums(asc, desc)
+//	@Param			limit					query		int						false	"Размер страницы (по умолчанию 50, макс. 200)"
+//	@Param			cursor					query		string					false	"Курсор следующей страницы"
+//	@Success		200						{object}	ListProductsResponse	"Страница товаров"
+//	@Failure		400						{object}	ErrorResponse			"Ошибка валидации"
+//	@Router			/products [get]
+func (p *ProductHandler) listProducts(w http.ResponseWriter, r *http.Request) {
+	req, err := parseListProductsQuery(r)
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := p.productUsecase.ListProducts(r.Context(), req)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListProductsResponse(res))
+}
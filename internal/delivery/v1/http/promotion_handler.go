@@ -0,0 +1,202 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+type PromotionHandler struct {
+	promotionUsecase usecase.PromotionUC
+	logger           logger.Logger
+}
+
+func NewPromotionHandler(promotionUsecase usecase.PromotionUC, logger logger.Logger) *PromotionHandler {
+	return &PromotionHandler{promotionUsecase: promotionUsecase, logger: logger}
+}
+
+// createPromotion
+//
+//	@Summary		Создание акции
+//	@Description	Создаёт процентную или фиксированную скидку либо акцию "N за M" на товар или категорию вместе с подкатегориями.
+//	@Description	Период действия задаётся необязательными starts_at и ends_at.
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreatePromotionRequest	true	"Параметры акции"
+//	@Success		201		{object}	PromotionResponse		"Акция"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар или категория не найдены"
+//	@Router			/promotions [post]
+func (p *PromotionHandler) createPromotion(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	promotion, err := parseCreatePromotionRequest(r)
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	created, err := p.promotionUsecase.CreatePromotion(r.Context(), promotion)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toPromotionResponse(created))
+}
+
+// listPromotions
+//
+//	@Summary		Список акций
+//	@Description	Возвращает все акции по возрастанию ID или только действующие в момент active_at
+//	@Tags			promotions
+//	@Produce		json
+//	@Param			active_at	query		string					false	"Момент времени в формате RFC 3339"
+//	@Success		200			{object}	ListPromotionsResponse	"Акции"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Router			/promotions [get]
+func (p *PromotionHandler) listPromotions(w http.ResponseWriter, r *http.Request) {
+	activeAt, err := parseOptionalTime(r.URL.Query().Get("active_at"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	promotions, err := p.promotionUsecase.ListPromotions(r.Context(), activeAt)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListPromotionsResponse(promotions))
+}
+
+// getPromotion
+//
+//	@Summary		Получение акции
+//	@Tags			promotions
+//	@Produce		json
+//	@Param			id	path		int					true	"ID акции"
+//	@Success		200	{object}	PromotionResponse	"Акция"
+//	@Failure		400	{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse		"Акция не найдена"
+//	@Router			/promotions/{id} [get]
+func (p *PromotionHandler) getPromotion(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	promotion, err := p.promotionUsecase.GetPromotion(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toPromotionResponse(promotion))
+}
+
+// deletePromotion
+//
+//	@Summary		Удаление акции
+//	@Tags			promotions
+//	@Produce		json
+//	@Param			id	path	int	true	"ID акции"
+//	@Success		204	"Акция удалена"
+//	@Failure		400	{object}	ErrorResponse	"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse	"Акция не найдена"
+//	@Router			/promotions/{id} [delete]
+func (p *PromotionHandler) deletePromotion(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if err := p.promotionUsecase.DeletePromotion(r.Context(), id); err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// evaluatePromotions
+//
+//	@Summary		Расчёт скидок
+//	@Description	Применяет действующие акции к корзине или к списку товаров с кол-вом или весом.
+//	@Description	К позиции применяется одна акция с наибольшей скидкой; при равной скидке — с большим приоритетом,
+//	@Description	затем акция на товар раньше акции на категорию, затем акция с меньшим ID.
+//	@Description	Для каждой позиции возвращаются все подходящие акции с результатом проверки.
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			request		body		EvaluatePromotionsRequest	true	"Корзина или список товаров"
+//	@Param			X-Store-ID	header		int							false	"ID магазина"
+//	@Success		200			{object}	DiscountedCartResponse		"Позиции со скидками и итоги"
+//	@Failure		400			{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse				"Корзина не найдена"
+//	@Failure		409			{object}	ErrorResponse				"Позиции в разных валютах"
+//	@Router			/promotions/evaluate [post]
+func (p *PromotionHandler) evaluatePromotions(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req EvaluatePromotionsRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if (req.CheckoutID == nil) == (len(req.Items) == 0) || (req.CheckoutID != nil && req.At != nil) {
+		err := e.Wrap("exactly one of checkout_id and items is required, at is allowed only with items", e.ErrMissingFields)
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	var (
+		cart *usecase.DiscountedCart
+		err  error
+	)
+	if req.CheckoutID != nil {
+		cart, err = p.promotionUsecase.EvaluateCheckout(r.Context(), *req.CheckoutID)
+	} else {
+		at := time.Now().UTC()
+		if req.At != nil {
+			at = req.At.UTC()
+		}
+
+		items := make([]usecase.PromotionItem, 0, len(req.Items))
+		for _, item := range req.Items {
+			items = append(items, usecase.NewPromotionItem(item.ProductID, item.Quantity, item.WeightGrams))
+		}
+
+		cart, err = p.promotionUsecase.EvaluatePromotions(r.Context(), usecase.NewEvaluatePromotionsReq(items, at))
+	}
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toDiscountedCartResponse(cart))
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/go-chi/chi/v5"
+)
+
+// submitProduct
+//
+//	@Summary		Отправка товара на проверку
+//	@Description	Переводит отклонённый товар (draft) обратно в очередь проверки. Новые товары попадают в очередь сразу при регистрации.
+//	@Tags			review
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара"
+//	@Param			request	body		ReviewRequest			false	"Комментарий к доработке"
+//	@Success		200		{object}	ReviewResultResponse	"Новый статус товара"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар не найден"
+//	@Failure		409		{object}	ErrorResponse			"Недопустимый переход статуса"
+//	@Router			/products/{id}/submit [post]
+func (p *ProductHandler) submitProduct(w http.ResponseWriter, r *http.Request) {
+	p.reviewProduct(w, r, p.productUsecase.SubmitProduct)
+}
+
+// approveProduct
+//
+//	@Summary		Одобрение товара
+//	@Description	Публикует товар из очереди проверки: он и его векторы начинают участвовать в распознавании.
+//	@Tags			review
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара"
+//	@Param			request	body		ReviewRequest			true	"Проверяющий и комментарий"
+//	@Success		200		{object}	ReviewResultResponse	"Новый статус товара"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар не найден"
+//	@Failure		409		{object}	ErrorResponse			"Недопустимый переход статуса"
+//	@Router			/products/{id}/approve [post]
+func (p *ProductHandler) approveProduct(w http.ResponseWriter, r *http.Request) {
+	p.reviewProduct(w, r, p.productUsecase.ApproveProduct)
+}
+
+// rejectProduct
+//
+//	@Summary		Отклонение товара
+//	@Description	Возвращает товар из очереди проверки на доработку (draft). Комментарий обязателен.
+//	@Tags			review
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"ID товара"
+//	@Param			request	body		ReviewRequest			true	"Проверяющий и причина отклонения"
+//	@Success		200		{object}	ReviewResultResponse	"Новый статус товара"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404		{object}	ErrorResponse			"Товар не найден"
+//	@Failure		409		{object}	ErrorResponse			"Недопустимый переход статуса"
+//	@Router			/products/{id}/reject [post]
+func (p *ProductHandler) rejectProduct(w http.ResponseWriter, r *http.Request) {
+	p.reviewProduct(w, r, p.productUsecase.RejectProduct)
+}
+
+// listReviewQueue
+//
+//	@Summary		Очередь проверки
+//	@Description	Возвращает страницу товаров, ожидающих проверки, начиная с давно не изменявшихся.
+//	@Description	Следующая страница запрашивается с параметром cursor из next_cursor предыдущего ответа.
+//	@Tags			review
+//	@Produce		json
+//	@Param			limit	query		int						false	"Размер страницы (по умолчанию 50, макс. 200)"
+//	@Param			cursor	query		string					false	"Курсор следующей страницы"
+//	@Success		200		{object}	ListProductsResponse	"Страница очереди"
+//	@Failure		400		{object}	ErrorResponse			"Ошибка валидации"
+//	@Router			/products/review-queue [get]
+func (p *ProductHandler) listReviewQueue(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := p.productUsecase.ListReviewQueue(r.Context(), limit, r.URL.Query().Get("cursor"))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListProductsResponse(res))
+}
+
+// getProductTransitions
+//
+//	@Summary		История статусов товара
+//	@Description	Возвращает переходы статусов товара с проверяющими и комментариями, начиная с самого раннего.
+//	@Tags			review
+//	@Produce		json
+//	@Param			id	path		int								true	"ID товара"
+//	@Success		200	{object}	ListProductTransitionsResponse	"История статусов"
+//	@Failure		400	{object}	ErrorResponse					"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse					"Товар не найден"
+//	@Router			/products/{id}/transitions [get]
+func (p *ProductHandler) getProductTransitions(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	transitions, err := p.productUsecase.GetProductTransitions(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListProductTransitionsResponse(transitions))
+}
+
+// getDuplicateFlags
+//
+//	@Summary		Вероятные дубликаты товара
+//	@Description	Возвращает опубликованные товары, на которые похож товар, зарегистрированный с on_duplicate=review,
+//	@Description	начиная с самого похожего. Дубликат объединяется с существующим товаром через слияние.
+//	@Tags			review
+//	@Produce		json
+//	@Param			id	path		int							true	"ID товара"
+//	@Success		200	{object}	ListDuplicateFlagsResponse	"Вероятные дубликаты"
+//	@Failure		400	{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse				"Товар не найден"
+//	@Router			/products/{id}/duplicates [get]
+func (p *ProductHandler) getDuplicateFlags(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	flags, err := p.productUsecase.GetDuplicateFlags(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListDuplicateFlagsResponse(flags))
+}
+
+// reviewProduct разбирает ID товара и решение проверяющего и выполняет переход статуса.
+// Пустое тело допускается: обязательность полей проверяет usecase.
+func (p *ProductHandler) reviewProduct(
+	w http.ResponseWriter,
+	r *http.Request,
+	transition func(context.Context, *usecase.ProductTransitionReq) (*usecase.ProductTransitionRes, error),
+) {
+	const maxRequestSize = 1 << 16
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req ReviewRequest
+	if r.ContentLength != 0 {
+		if err := parseJSONBody(r, &req); err != nil {
+			p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+			WriteError(w, err)
+			return
+		}
+	}
+
+	res, err := transition(r.Context(), usecase.NewProductTransitionReq(id, req.Reviewer, req.Comment))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toReviewResultResponse(res))
+}
@@ -18,22 +18,132 @@ func NewRouter(router *chi.Mux, logger logger.Logger) *Router {
 	return &Router{router: router, logger: logger}
 }
 
-func (r *Router) Init(prUC usecase.ProductUC) {
+func (r *Router) Init(
+	prUC usecase.ProductUC,
+	catUC usecase.CategoryUC,
+	checkoutUC usecase.CheckoutUC,
+	promoUC usecase.PromotionUC,
+	storeUC usecase.StoreUC,
+	stockUC usecase.StockUC,
+) {
 	r.router.Use(middleware.Logger)    // Пишет логи запросов в консоль
 	r.router.Use(middleware.Recoverer) // Не дает серверу упасть при панике
-	
+	r.router.Use(localeScope)          // Выбирает язык ответа по Accept-Language
+
 	r.router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"), // ссылка на JSON
 	))
-	
+
 	r.router.Route("/api/v1", func(v1 chi.Router) {
+		storeHandler := NewStoreHandler(storeUC, r.logger)
+		v1.Use(storeHandler.storeScope) // Ограничивает запрос ассортиментом магазина из X-Store-ID
+		stockHandler := NewStockHandler(stockUC, r.logger)
+		registerStoreRoutes(v1, storeHandler, stockHandler)
+
 		prHandler := NewProductHandler(prUC, r.logger)
 		registerProductRoutes(v1, prHandler)
+		registerRecognitionRoutes(v1, prHandler)
+		registerVariantGroupRoutes(v1, prHandler)
+
+		catHandler := NewCategoryHandler(catUC, r.logger)
+		registerCategoryRoutes(v1, catHandler)
+
+		checkoutHandler := NewCheckoutHandler(checkoutUC, r.logger)
+		registerCheckoutRoutes(v1, checkoutHandler)
+
+		promoHandler := NewPromotionHandler(promoUC, r.logger)
+		registerPromotionRoutes(v1, promoHandler)
 	})
 }
 
 func registerProductRoutes(router chi.Router, prHandler *ProductHandler) {
 	router.Route("/products", func(pr chi.Router) {
 		pr.Post("/", prHandler.registerNewProduct)
+		pr.Get("/", prHandler.listProducts)
+		pr.Get("/review-queue", prHandler.listReviewQueue)
+		pr.Get("/barcodes/{barcode}", prHandler.getProductByBarcode)
+		pr.Get("/{id}", prHandler.getProduct)
+		pr.Patch("/{id}", prHandler.updateProduct)
+		pr.Delete("/{id}", prHandler.deleteProduct)
+		pr.Post("/{id}/unarchive", prHandler.unarchiveProduct)
+		pr.Post("/{id}/merge", prHandler.mergeProduct)
+		pr.Post("/{id}/submit", prHandler.submitProduct)
+		pr.Post("/{id}/approve", prHandler.approveProduct)
+		pr.Post("/{id}/reject", prHandler.rejectProduct)
+		pr.Get("/{id}/transitions", prHandler.getProductTransitions)
+		pr.Get("/{id}/duplicates", prHandler.getDuplicateFlags)
+		pr.Post("/{id}/images", prHandler.addProductImages)
+		pr.Delete("/{id}/images/{imageId}", prHandler.deleteProductImage)
+		pr.Get("/{id}/prices", prHandler.getPriceHistory)
+		pr.Post("/{id}/prices", prHandler.schedulePrice)
+		pr.Get("/{id}/prices/effective", prHandler.getEffectivePrice)
+		pr.Get("/{id}/prices/by-weight", prHandler.getPriceByWeight)
+		pr.Delete("/{id}/prices/{priceId}", prHandler.cancelScheduledPrice)
+	})
+}
+
+func registerCategoryRoutes(router chi.Router, catHandler *CategoryHandler) {
+	router.Route("/categories", func(cat chi.Router) {
+		cat.Post("/", catHandler.createCategory)
+		cat.Get("/", catHandler.listCategories)
+		cat.Get("/{id}", catHandler.getCategory)
+		cat.Patch("/{id}", catHandler.renameCategory)
+		cat.Delete("/{id}", catHandler.deleteCategory)
+		cat.Post("/{id}/move", catHandler.moveCategory)
+		cat.Post("/{id}/unarchive", catHandler.unarchiveCategory)
+		cat.Get("/{id}/attributes", catHandler.getAttributeSchema)
+		cat.Put("/{id}/attributes", catHandler.setAttributeSchema)
+	})
+}
+
+func registerVariantGroupRoutes(router chi.Router, prHandler *ProductHandler) {
+	router.Route("/variant-groups", func(vg chi.Router) {
+		vg.Post("/", prHandler.createVariantGroup)
+		vg.Get("/", prHandler.listVariantGroups)
+		vg.Get("/{id}", prHandler.getVariantGroup)
 	})
 }
+
+func registerCheckoutRoutes(router chi.Router, checkoutHandler *CheckoutHandler) {
+	router.Route("/checkout", func(ch chi.Router) {
+		ch.Post("/", checkoutHandler.createCheckout)
+		ch.Get("/{id}", checkoutHandler.getCheckout)
+		ch.Post("/{id}/items", checkoutHandler.addCheckoutItem)
+		ch.Post("/{id}/items/recognize", checkoutHandler.addRecognizedItem)
+		ch.Patch("/{id}/items/{lineId}", checkoutHandler.updateCheckoutLine)
+		ch.Delete("/{id}/items/{lineId}", checkoutHandler.removeCheckoutLine)
+		ch.Post("/{id}/complete", checkoutHandler.completeCheckout)
+	})
+}
+
+func registerPromotionRoutes(router chi.Router, promoHandler *PromotionHandler) {
+	router.Route("/promotions", func(promo chi.Router) {
+		promo.Post("/", promoHandler.createPromotion)
+		promo.Get("/", promoHandler.listPromotions)
+		promo.Post("/evaluate", promoHandler.evaluatePromotions)
+		promo.Get("/{id}", promoHandler.getPromotion)
+		promo.Delete("/{id}", promoHandler.deletePromotion)
+	})
+}
+
+func registerStoreRoutes(router chi.Router, storeHandler *StoreHandler, stockHandler *StockHandler) {
+	router.Route("/stores", func(store chi.Router) {
+		store.Post("/", storeHandler.createStore)
+		store.Get("/", storeHandler.listStores)
+		store.Get("/{id}", storeHandler.getStore)
+		store.Get("/{id}/products", storeHandler.listStoreProducts)
+		store.Put("/{id}/products/{productId}", storeHandler.setStoreProduct)
+		store.Delete("/{id}/products/{productId}", storeHandler.removeStoreProduct)
+		store.Get("/{id}/stock", stockHandler.listStock)
+		store.Get("/{id}/stock/{productId}", stockHandler.getStock)
+		store.Put("/{id}/stock/{productId}", stockHandler.setStock)
+		store.Post("/{id}/stock/{productId}/decrement", stockHandler.decrementStock)
+		store.Post("/{id}/stock/{productId}/reservations", stockHandler.reserveStock)
+		store.Post("/{id}/reservations/{reservationId}/commit", stockHandler.commitReservation)
+		store.Delete("/{id}/reservations/{reservationId}", stockHandler.releaseReservation)
+	})
+}
+
+func registerRecognitionRoutes(router chi.Router, prHandler *ProductHandler) {
+	router.Post("/recognize", prHandler.recognizeProduct)
+}
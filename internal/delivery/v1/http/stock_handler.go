@@ -0,0 +1,319 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+type StockHandler struct {
+	stockUsecase usecase.StockUC
+	logger       logger.Logger
+}
+
+func NewStockHandler(stockUsecase usecase.StockUC, logger logger.Logger) *StockHandler {
+	return &StockHandler{stockUsecase: stockUsecase, logger: logger}
+}
+
+// listStock
+//
+//	@Summary		Остатки магазина
+//	@Description	Возвращает остатки товаров магазина по возрастанию ID товара. Остатки указаны в штуках, граммах или миллилитрах.
+//	@Tags			stock
+//	@Produce		json
+//	@Param			id	path		int					true	"ID магазина"
+//	@Success		200	{object}	ListStockResponse	"Остатки"
+//	@Failure		400	{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse		"Магазин не найден"
+//	@Router			/stores/{id}/stock [get]
+func (s *StockHandler) listStock(w http.ResponseWriter, r *http.Request) {
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	levels, err := s.stockUsecase.ListStock(r.Context(), storeID)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListStockResponse(levels))
+}
+
+// getStock
+//
+//	@Summary		Остаток товара в магазине
+//	@Tags			stock
+//	@Produce		json
+//	@Param			id			path		int					true	"ID магазина"
+//	@Param			productId	path		int					true	"ID товара"
+//	@Success		200			{object}	StockLevelResponse	"Остаток"
+//	@Failure		400			{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse		"Остаток товара в магазине не учитывается"
+//	@Router			/stores/{id}/stock/{productId} [get]
+func (s *StockHandler) getStock(w http.ResponseWriter, r *http.Request) {
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	productID, err := parseID(chi.URLParam(r, "productId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	level, err := s.stockUsecase.GetStock(r.Context(), storeID, productID)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toStockLevelResponse(level))
+}
+
+// setStock
+//
+//	@Summary		Установка остатка
+//	@Description	Задаёт фактический остаток товара в магазине по результатам инвентаризации или приёмки.
+//	@Description	Для товара, остаток которого не учитывался, учёт начинается. Активные резервы сохраняются.
+//	@Tags			stock
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"ID магазина"
+//	@Param			productId	path		int					true	"ID товара"
+//	@Param			request		body		SetStockRequest		true	"Фактический остаток"
+//	@Success		200			{object}	StockChangeResponse	"Остаток и ID события"
+//	@Failure		400			{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse		"Магазин или товар не найдены"
+//	@Router			/stores/{id}/stock/{productId} [put]
+func (s *StockHandler) setStock(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	productID, err := parseID(chi.URLParam(r, "productId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	var req SetStockRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	if req.OnHand == nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), e.ErrMissingFields.Error())
+		WriteError(w, e.ErrMissingFields)
+		return
+	}
+
+	res, err := s.stockUsecase.SetStock(r.Context(), storeID, productID, *req.OnHand)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toStockChangeResponse(res))
+}
+
+// decrementStock
+//
+//	@Summary		Списание остатка
+//	@Description	Списывает доступный остаток без резерва, например при продаже.
+//	@Tags			stock
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int						true	"ID магазина"
+//	@Param			productId	path		int						true	"ID товара"
+//	@Param			request		body		StockQuantityRequest	true	"Кол-во для списания"
+//	@Success		200			{object}	StockChangeResponse		"Остаток и ID события"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Остаток товара в магазине не учитывается"
+//	@Failure		409			{object}	ErrorResponse			"Недостаточно остатка"
+//	@Router			/stores/{id}/stock/{productId}/decrement [post]
+func (s *StockHandler) decrementStock(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	productID, err := parseID(chi.URLParam(r, "productId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	quantity, err := parseStockQuantityRequest(r)
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := s.stockUsecase.DecrementStock(r.Context(), storeID, productID, quantity)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toStockChangeResponse(res))
+}
+
+// reserveStock
+//
+//	@Summary		Резервирование остатка
+//	@Description	Резервирует доступный остаток. Резерв, не подтверждённый и не отменённый до expires_at, перестаёт учитываться.
+//	@Tags			stock
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int						true	"ID магазина"
+//	@Param			productId	path		int						true	"ID товара"
+//	@Param			request		body		StockQuantityRequest	true	"Кол-во для резервирования"
+//	@Success		201			{object}	StockChangeResponse		"Остаток, резерв и ID события"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Остаток товара в магазине не учитывается"
+//	@Failure		409			{object}	ErrorResponse			"Недостаточно остатка"
+//	@Router			/stores/{id}/stock/{productId}/reservations [post]
+func (s *StockHandler) reserveStock(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	productID, err := parseID(chi.URLParam(r, "productId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	quantity, err := parseStockQuantityRequest(r)
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := s.stockUsecase.ReserveStock(r.Context(), storeID, productID, quantity)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toStockChangeResponse(res))
+}
+
+// commitReservation
+//
+//	@Summary		Подтверждение резерва
+//	@Description	Списывает зарезервированное кол-во с остатка. Просроченный резерв подтвердить нельзя.
+//	@Tags			stock
+//	@Produce		json
+//	@Param			id				path		int					true	"ID магазина"
+//	@Param			reservationId	path		string				true	"ID резерва"
+//	@Success		200				{object}	StockChangeResponse	"Остаток, резерв и ID события"
+//	@Failure		400				{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404				{object}	ErrorResponse		"Резерв не найден"
+//	@Failure		409				{object}	ErrorResponse		"Резерв подтверждён, отменён или просрочен"
+//	@Router			/stores/{id}/reservations/{reservationId}/commit [post]
+func (s *StockHandler) commitReservation(w http.ResponseWriter, r *http.Request) {
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	reservationID, err := parseReservationID(chi.URLParam(r, "reservationId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := s.stockUsecase.CommitReservation(r.Context(), storeID, reservationID)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toStockChangeResponse(res))
+}
+
+// releaseReservation
+//
+//	@Summary		Отмена резерва
+//	@Description	Отменяет активный резерв, в том числе просроченный
+//	@Tags			stock
+//	@Produce		json
+//	@Param			id				path		int					true	"ID магазина"
+//	@Param			reservationId	path		string				true	"ID резерва"
+//	@Success		200				{object}	StockChangeResponse	"Остаток, резерв и ID события"
+//	@Failure		400				{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		404				{object}	ErrorResponse		"Резерв не найден"
+//	@Failure		409				{object}	ErrorResponse		"Резерв уже подтверждён или отменён"
+//	@Router			/stores/{id}/reservations/{reservationId} [delete]
+func (s *StockHandler) releaseReservation(w http.ResponseWriter, r *http.Request) {
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	reservationID, err := parseReservationID(chi.URLParam(r, "reservationId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := s.stockUsecase.ReleaseReservation(r.Context(), storeID, reservationID)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toStockChangeResponse(res))
+}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/DRSN-tech/go-backend/pkg/storectx"
+	"github.com/go-chi/chi/v5"
+)
+
+// storeIDHeader — заголовок с ID магазина, ассортиментом которого ограничивается запрос
+const storeIDHeader = "X-Store-ID"
+
+type StoreHandler struct {
+	storeUsecase usecase.StoreUC
+	logger       logger.Logger
+}
+
+func NewStoreHandler(storeUsecase usecase.StoreUC, logger logger.Logger) *StoreHandler {
+	return &StoreHandler{storeUsecase: storeUsecase, logger: logger}
+}
+
+// storeScope проверяет магазин из заголовка X-Store-ID и передаёт его в контексте запроса.
+// Запрос без заголовка не ограничивается ассортиментом магазина.
+func (s *StoreHandler) storeScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(storeIDHeader)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		storeID, err := parseID(header)
+		if err != nil {
+			s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+			WriteError(w, err)
+			return
+		}
+
+		if _, err := s.storeUsecase.GetStore(r.Context(), storeID); err != nil {
+			s.logger.Warnf("%s", err.Error())
+			WriteError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(storectx.WithStoreID(r.Context(), storeID)))
+	})
+}
+
+// createStore
+//
+//	@Summary		Создание магазина
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateStoreRequest	true	"Код и название магазина"
+//	@Success		201		{object}	StoreResponse		"Магазин"
+//	@Failure		400		{object}	ErrorResponse		"Ошибка валидации"
+//	@Failure		409		{object}	ErrorResponse		"Код магазина занят"
+//	@Router			/stores [post]
+func (s *StoreHandler) createStore(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req CreateStoreRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	created, err := s.storeUsecase.CreateStore(r.Context(), domain.NewStore(req.Code, req.Name))
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toStoreResponse(created))
+}
+
+// listStores
+//
+//	@Summary		Список магазинов
+//	@Tags			stores
+//	@Produce		json
+//	@Success		200	{object}	ListStoresResponse	"Магазины"
+//	@Router			/stores [get]
+func (s *StoreHandler) listStores(w http.ResponseWriter, r *http.Request) {
+	stores, err := s.storeUsecase.ListStores(r.Context())
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListStoresResponse(stores))
+}
+
+// getStore
+//
+//	@Summary		Получение магазина
+//	@Tags			stores
+//	@Produce		json
+//	@Param			id	path		int				true	"ID магазина"
+//	@Success		200	{object}	StoreResponse	"Магазин"
+//	@Failure		400	{object}	ErrorResponse	"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse	"Магазин не найден"
+//	@Router			/stores/{id} [get]
+func (s *StoreHandler) getStore(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	store, err := s.storeUsecase.GetStore(r.Context(), id)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toStoreResponse(store))
+}
+
+// listStoreProducts
+//
+//	@Summary		Ассортимент магазина
+//	@Description	Возвращает товары ассортимента магазина по возрастанию ID товара, включая недоступные для продажи
+//	@Tags			stores
+//	@Produce		json
+//	@Param			id	path		int							true	"ID магазина"
+//	@Success		200	{object}	ListStoreProductsResponse	"Ассортимент"
+//	@Failure		400	{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		404	{object}	ErrorResponse				"Магазин не найден"
+//	@Router			/stores/{id}/products [get]
+func (s *StoreHandler) listStoreProducts(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	products, err := s.storeUsecase.ListStoreProducts(r.Context(), id)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListStoreProductsResponse(products))
+}
+
+// setStoreProduct
+//
+//	@Summary		Добавление товара в ассортимент магазина
+//	@Description	Добавляет товар в ассортимент магазина или изменяет его доступность и цену в магазине.
+//	@Description	Товар, доступный в магазине, распознаётся в нём и возвращается с ценой магазина.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int						true	"ID магазина"
+//	@Param			productId	path		int						true	"ID товара"
+//	@Param			request		body		SetStoreProductRequest	true	"Доступность и цена в магазине"
+//	@Success		200			{object}	StoreProductResponse	"Товар в ассортименте и ID события"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Магазин или товар не найдены"
+//	@Router			/stores/{id}/products/{productId} [put]
+func (s *StoreHandler) setStoreProduct(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	productID, err := parseID(chi.URLParam(r, "productId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	product, err := parseSetStoreProductRequest(r, storeID, productID)
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	res, err := s.storeUsecase.SetStoreProduct(r.Context(), product)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toSetStoreProductResponse(res))
+}
+
+// removeStoreProduct
+//
+//	@Summary		Исключение товара из ассортимента магазина
+//	@Tags			stores
+//	@Produce		json
+//	@Param			id			path		int						true	"ID магазина"
+//	@Param			productId	path		int						true	"ID товара"
+//	@Success		200			{object}	map[string]interface{}	"ID события"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Магазин или товар в ассортименте не найдены"
+//	@Router			/stores/{id}/products/{productId} [delete]
+func (s *StoreHandler) removeStoreProduct(w http.ResponseWriter, r *http.Request) {
+	storeID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	productID, err := parseID(chi.URLParam(r, "productId"))
+	if err != nil {
+		s.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	event, err := s.storeUsecase.RemoveStoreProduct(r.Context(), storeID, productID)
+	if err != nil {
+		s.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, map[string]interface{}{
+		"EventID": event.EventID,
+	})
+}
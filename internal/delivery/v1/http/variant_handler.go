@@ -0,0 +1,92 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/go-chi/chi/v5"
+)
+
+// createVariantGroup
+//
+//	@Summary		Создание группы вариантов
+//	@Description	Создаёт группу вариантов одного товара, например разных объёмов. Варианты группы различаются значениями атрибутов-осей.
+//	@Description	Товары добавляются в группу полем variant_group_id при регистрации или изменении товара.
+//	@Tags			variant-groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateVariantGroupRequest	true	"Название и оси группы"
+//	@Success		201		{object}	VariantGroupResponse		"Группа вариантов"
+//	@Failure		400		{object}	ErrorResponse				"Ошибка валидации"
+//	@Failure		409		{object}	ErrorResponse				"Название занято"
+//	@Router			/variant-groups [post]
+func (p *ProductHandler) createVariantGroup(w http.ResponseWriter, r *http.Request) {
+	const maxRequestSize = 1 << 20
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	var req CreateVariantGroupRequest
+	if err := parseJSONBody(r, &req); err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	group, err := p.productUsecase.CreateVariantGroup(r.Context(), domain.NewVariantGroup(req.Name, req.Axes))
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusCreated, toVariantGroupResponse(group))
+}
+
+// listVariantGroups
+//
+//	@Summary		Список групп вариантов
+//	@Tags			variant-groups
+//	@Produce		json
+//	@Success		200	{object}	ListVariantGroupsResponse	"Группы вариантов"
+//	@Router			/variant-groups [get]
+func (p *ProductHandler) listVariantGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := p.productUsecase.ListVariantGroups(r.Context())
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toListVariantGroupsResponse(groups))
+}
+
+// getVariantGroup
+//
+//	@Summary		Получение группы вариантов
+//	@Description	Возвращает группу с её неархивными товарами. С X-Store-ID возвращаются только варианты из ассортимента магазина.
+//	@Tags			variant-groups
+//	@Produce		json
+//	@Param			id			path		int						true	"ID группы"
+//	@Param			X-Store-ID	header		int						false	"ID магазина"
+//	@Success		200			{object}	VariantGroupResponse	"Группа вариантов"
+//	@Failure		400			{object}	ErrorResponse			"Ошибка валидации"
+//	@Failure		404			{object}	ErrorResponse			"Группа не найдена"
+//	@Router			/variant-groups/{id} [get]
+func (p *ProductHandler) getVariantGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		p.logger.Warnf("%d %s: %s", http.StatusBadRequest, e.ErrStatusBadRequest.Error(), err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	details, err := p.productUsecase.GetVariantGroup(r.Context(), id)
+	if err != nil {
+		p.logger.Warnf("%s", err.Error())
+		WriteError(w, err)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, toVariantGroupDetailsResponse(details))
+}
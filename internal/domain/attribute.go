@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// AttributeType — тип значения атрибута продукта
+type AttributeType string
+
+const (
+	AttributeString AttributeType = "string"
+	AttributeNumber AttributeType = "number"
+	AttributeBool   AttributeType = "bool"
+	AttributeEnum   AttributeType = "enum" // строка из списка допустимых значений
+)
+
+const (
+	maxAttributes           = 64  // макс. кол-во атрибутов в схеме категории
+	maxAttributeCodeLength  = 64  // макс. длина кода атрибута
+	maxAttributeNameLength  = 128 // макс. длина названия атрибута
+	maxAttributeUnitLength  = 16  // макс. длина единицы измерения числового атрибута
+	maxAttributeValueLength = 255 // макс. длина строкового значения в символах
+)
+
+// AttributeDef описывает атрибут в схеме категории
+type AttributeDef struct {
+	Code     string // код атрибута, например "volume"; ключ в Product.Attributes
+	Name     string // название для отображения, например "Объём"
+	Type     AttributeType
+	Unit     string   // единица измерения числового атрибута, например "l"
+	Values   []string // допустимые значения перечисления
+	Required bool
+}
+
+// AttributeSchema — схема атрибутов категории. Продукт проверяется по объединению схем
+// своей категории и её предков, см. MergeAttributeSchemas.
+type AttributeSchema struct {
+	CategoryID int64
+	Attributes []AttributeDef
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+}
+
+// Attributes — значения атрибутов продукта по их кодам: string для строк и перечислений, float64 для чисел, bool
+type Attributes map[string]any
+
+func NewAttributeSchema(categoryID int64, attributes []AttributeDef) *AttributeSchema {
+	return &AttributeSchema{
+		CategoryID: categoryID,
+		Attributes: attributes,
+	}
+}
+
+// Validate обрезает пробелы в названиях и значениях перечислений и проверяет определения атрибутов.
+// Коды уникальны в схеме, единица измерения допустима только у числового атрибута, а список значений — только у перечисления.
+func (s *AttributeSchema) Validate() error {
+	if s.Attributes == nil {
+		s.Attributes = []AttributeDef{}
+	}
+
+	if len(s.Attributes) > maxAttributes {
+		return e.Wrap("too many attributes", e.ErrInvalidAttributeSchema)
+	}
+
+	codes := make(map[string]struct{}, len(s.Attributes))
+	for i := range s.Attributes {
+		def := &s.Attributes[i]
+		if !validAttributeCode(def.Code) {
+			return e.Wrap("code", e.ErrInvalidAttributeSchema)
+		}
+
+		if _, ok := codes[def.Code]; ok {
+			return e.Wrap(def.Code+": duplicate code", e.ErrInvalidAttributeSchema)
+		}
+		codes[def.Code] = struct{}{}
+
+		def.Name = strings.TrimSpace(def.Name)
+		if def.Name == "" || utf8.RuneCountInString(def.Name) > maxAttributeNameLength {
+			return e.Wrap(def.Code+": name", e.ErrInvalidAttributeSchema)
+		}
+
+		switch def.Type {
+		case AttributeString, AttributeNumber, AttributeBool, AttributeEnum:
+		default:
+			return e.Wrap(def.Code+": type", e.ErrInvalidAttributeSchema)
+		}
+
+		def.Unit = strings.TrimSpace(def.Unit)
+		if def.Unit != "" && (def.Type != AttributeNumber || len(def.Unit) > maxAttributeUnitLength) {
+			return e.Wrap(def.Code+": unit", e.ErrInvalidAttributeSchema)
+		}
+
+		if err := def.validateValues(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateValues проверяет список значений: непустой и без повторов у перечисления, пустой у остальных типов.
+func (d *AttributeDef) validateValues() error {
+	if d.Type != AttributeEnum {
+		if len(d.Values) > 0 {
+			return e.Wrap(d.Code+": values", e.ErrInvalidAttributeSchema)
+		}
+		return nil
+	}
+
+	if len(d.Values) == 0 {
+		return e.Wrap(d.Code+": values", e.ErrInvalidAttributeSchema)
+	}
+
+	seen := make(map[string]struct{}, len(d.Values))
+	for i, value := range d.Values {
+		value = strings.TrimSpace(value)
+		if value == "" || utf8.RuneCountInString(value) > maxAttributeValueLength {
+			return e.Wrap(d.Code+": values", e.ErrInvalidAttributeSchema)
+		}
+
+		if _, ok := seen[value]; ok {
+			return e.Wrap(d.Code+": duplicate value", e.ErrInvalidAttributeSchema)
+		}
+		seen[value] = struct{}{}
+		d.Values[i] = value
+	}
+
+	return nil
+}
+
+// normalize проверяет значение атрибута по его типу и приводит его к хранимому виду.
+func (d *AttributeDef) normalize(value any) (any, error) {
+	switch d.Type {
+	case AttributeNumber:
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case int64:
+			number = float64(v)
+		case int:
+			number = float64(v)
+		default:
+			return nil, e.Wrap(d.Code, e.ErrInvalidAttribute)
+		}
+
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, e.Wrap(d.Code, e.ErrInvalidAttribute)
+		}
+		return number, nil
+	case AttributeBool:
+		if _, ok := value.(bool); !ok {
+			return nil, e.Wrap(d.Code, e.ErrInvalidAttribute)
+		}
+		return value, nil
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return nil, e.Wrap(d.Code, e.ErrInvalidAttribute)
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" || utf8.RuneCountInString(s) > maxAttributeValueLength {
+			return nil, e.Wrap(d.Code, e.ErrInvalidAttribute)
+		}
+
+		if d.Type == AttributeEnum && !slices.Contains(d.Values, s) {
+			return nil, e.Wrap(d.Code, e.ErrInvalidAttribute)
+		}
+		return s, nil
+	}
+}
+
+// MergeAttributeSchemas объединяет схемы категорий от корня дерева до категории продукта.
+// Определение подкатегории заменяет одноимённое определение предка на его месте, новые коды добавляются в конец.
+func MergeAttributeSchemas(schemas []*AttributeSchema) []AttributeDef {
+	result := make([]AttributeDef, 0)
+	index := make(map[string]int)
+	for _, schema := range schemas {
+		for _, def := range schema.Attributes {
+			if i, ok := index[def.Code]; ok {
+				result[i] = def
+				continue
+			}
+
+			index[def.Code] = len(result)
+			result = append(result, def)
+		}
+	}
+
+	return result
+}
+
+// ValidateAttributes проверяет атрибуты продукта по определениям категории и возвращает их в хранимом виде.
+// Атрибуты вне схемы не допускаются, обязательные атрибуты должны быть заданы.
+func ValidateAttributes(defs []AttributeDef, attrs Attributes) (Attributes, error) {
+	result, err := normalizeAttributes(defs, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, def := range defs {
+		if _, ok := result[def.Code]; def.Required && !ok {
+			return nil, e.Wrap(def.Code, e.ErrAttributeRequired)
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateAttributeFilter проверяет фильтр по атрибутам и возвращает его в хранимом виде. Фильтр применяется
+// к продуктам разных категорий, поэтому тип значения определяется самим значением, а не схемой категории.
+func ValidateAttributeFilter(filter Attributes) (Attributes, error) {
+	result := make(Attributes, len(filter))
+	for code, value := range filter {
+		if !validAttributeCode(code) {
+			return nil, e.Wrap(code, e.ErrUnknownAttribute)
+		}
+
+		def := AttributeDef{Code: code}
+		switch value.(type) {
+		case float64, int64, int:
+			def.Type = AttributeNumber
+		case bool:
+			def.Type = AttributeBool
+		default:
+			def.Type = AttributeString
+		}
+
+		v, err := def.normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		result[code] = v
+	}
+
+	return result, nil
+}
+
+// normalizeAttributes проверяет значения атрибутов по определениям без учёта обязательности.
+func normalizeAttributes(defs []AttributeDef, attrs Attributes) (Attributes, error) {
+	byCode := make(map[string]*AttributeDef, len(defs))
+	for i := range defs {
+		byCode[defs[i].Code] = &defs[i]
+	}
+
+	result := make(Attributes, len(attrs))
+	for code, value := range attrs {
+		def, ok := byCode[code]
+		if !ok {
+			return nil, e.Wrap(code, e.ErrUnknownAttribute)
+		}
+
+		v, err := def.normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		result[code] = v
+	}
+
+	return result, nil
+}
+
+// Equal сравнивает атрибуты; nil и пустой набор равны.
+func (a Attributes) Equal(b Attributes) bool {
+	return maps.Equal(a, b)
+}
+
+// validAttributeCode проверяет код атрибута: от 1 до 64 строчных латинских букв, цифр и "_", начиная с буквы.
+func validAttributeCode(code string) bool {
+	if code == "" || len(code) > maxAttributeCodeLength || code[0] < 'a' || code[0] > 'z' {
+		return false
+	}
+
+	for _, r := range code {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+func TestAttributeSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs []AttributeDef
+		err   error
+	}{
+		{"nil attributes", nil, nil},
+		{
+			name: "all types",
+			attrs: []AttributeDef{
+				{Code: "brand", Name: " Бренд ", Type: AttributeString},
+				{Code: "volume", Name: "Объём", Type: AttributeNumber, Unit: "l", Required: true},
+				{Code: "sugar_free", Name: "Без сахара", Type: AttributeBool},
+				{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{" can ", "bottle"}},
+			},
+		},
+		{"invalid code", []AttributeDef{{Code: "Volume", Name: "Объём", Type: AttributeNumber}}, e.ErrInvalidAttributeSchema},
+		{"code starts with digit", []AttributeDef{{Code: "1l", Name: "Объём", Type: AttributeNumber}}, e.ErrInvalidAttributeSchema},
+		{
+			name: "duplicate code",
+			attrs: []AttributeDef{
+				{Code: "volume", Name: "Объём", Type: AttributeNumber},
+				{Code: "volume", Name: "Объём, л", Type: AttributeNumber},
+			},
+			err: e.ErrInvalidAttributeSchema,
+		},
+		{"blank name", []AttributeDef{{Code: "brand", Name: "  ", Type: AttributeString}}, e.ErrInvalidAttributeSchema},
+		{"unknown type", []AttributeDef{{Code: "brand", Name: "Бренд", Type: AttributeType("date")}}, e.ErrInvalidAttributeSchema},
+		{"unit of non-number", []AttributeDef{{Code: "brand", Name: "Бренд", Type: AttributeString, Unit: "l"}}, e.ErrInvalidAttributeSchema},
+		{"values of non-enum", []AttributeDef{{Code: "brand", Name: "Бренд", Type: AttributeString, Values: []string{"a"}}}, e.ErrInvalidAttributeSchema},
+		{"enum without values", []AttributeDef{{Code: "pack", Name: "Упаковка", Type: AttributeEnum}}, e.ErrInvalidAttributeSchema},
+		{"enum with blank value", []AttributeDef{{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{"can", " "}}}, e.ErrInvalidAttributeSchema},
+		{
+			name:  "enum with duplicate value after trim",
+			attrs: []AttributeDef{{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{"can", " can"}}},
+			err:   e.ErrInvalidAttributeSchema,
+		},
+		{"too many attributes", make([]AttributeDef, maxAttributes+1), e.ErrInvalidAttributeSchema},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := NewAttributeSchema(1, tt.attrs)
+			err := schema.Validate()
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.err)
+			}
+			if schema.Attributes == nil {
+				t.Errorf("Validate() left nil attributes")
+			}
+		})
+	}
+}
+
+func TestAttributeSchemaValidateTrims(t *testing.T) {
+	schema := NewAttributeSchema(1, []AttributeDef{
+		{Code: "volume", Name: " Объём ", Type: AttributeNumber, Unit: " l "},
+		{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{" can ", "bottle "}},
+	})
+	if err := schema.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	want := []AttributeDef{
+		{Code: "volume", Name: "Объём", Type: AttributeNumber, Unit: "l"},
+		{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{"can", "bottle"}},
+	}
+	if !reflect.DeepEqual(schema.Attributes, want) {
+		t.Errorf("Validate() attributes = %v, want %v", schema.Attributes, want)
+	}
+}
+
+func TestMergeAttributeSchemas(t *testing.T) {
+	brand := AttributeDef{Code: "brand", Name: "Бренд", Type: AttributeString}
+	volume := AttributeDef{Code: "volume", Name: "Объём", Type: AttributeNumber, Unit: "l"}
+	requiredVolume := AttributeDef{Code: "volume", Name: "Объём", Type: AttributeNumber, Unit: "ml", Required: true}
+	pack := AttributeDef{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{"can", "bottle"}}
+
+	tests := []struct {
+		name    string
+		schemas []*AttributeSchema
+		want    []AttributeDef
+	}{
+		{"no schemas", nil, []AttributeDef{}},
+		{"single schema", []*AttributeSchema{NewAttributeSchema(1, []AttributeDef{brand, volume})}, []AttributeDef{brand, volume}},
+		{
+			name: "child adds attributes after parent",
+			schemas: []*AttributeSchema{
+				NewAttributeSchema(1, []AttributeDef{brand}),
+				NewAttributeSchema(2, []AttributeDef{pack}),
+			},
+			want: []AttributeDef{brand, pack},
+		},
+		{
+			name: "child overrides parent definition in place",
+			schemas: []*AttributeSchema{
+				NewAttributeSchema(1, []AttributeDef{volume, brand}),
+				NewAttributeSchema(2, []AttributeDef{pack, requiredVolume}),
+			},
+			want: []AttributeDef{requiredVolume, brand, pack},
+		},
+		{
+			name: "deepest category wins",
+			schemas: []*AttributeSchema{
+				NewAttributeSchema(1, []AttributeDef{requiredVolume}),
+				NewAttributeSchema(2, []AttributeDef{volume}),
+				NewAttributeSchema(3, []AttributeDef{brand}),
+			},
+			want: []AttributeDef{volume, brand},
+		},
+		{
+			name: "empty schemas in the path",
+			schemas: []*AttributeSchema{
+				NewAttributeSchema(1, nil),
+				NewAttributeSchema(2, []AttributeDef{brand}),
+				NewAttributeSchema(3, []AttributeDef{}),
+			},
+			want: []AttributeDef{brand},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeAttributeSchemas(tt.schemas); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeAttributeSchemas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAttributes(t *testing.T) {
+	defs := []AttributeDef{
+		{Code: "brand", Name: "Бренд", Type: AttributeString},
+		{Code: "volume", Name: "Объём", Type: AttributeNumber, Unit: "l", Required: true},
+		{Code: "sugar_free", Name: "Без сахара", Type: AttributeBool},
+		{Code: "pack", Name: "Упаковка", Type: AttributeEnum, Values: []string{"can", "bottle"}},
+	}
+
+	tests := []struct {
+		name  string
+		attrs Attributes
+		want  Attributes
+		err   error
+	}{
+		{
+			name:  "all types",
+			attrs: Attributes{"brand": " Добрый ", "volume": 0.5, "sugar_free": true, "pack": "can"},
+			want:  Attributes{"brand": "Добрый", "volume": 0.5, "sugar_free": true, "pack": "can"},
+		},
+		{"integer number", Attributes{"volume": 1}, Attributes{"volume": 1.0}, nil},
+		{"int64 number", Attributes{"volume": int64(2)}, Attributes{"volume": 2.0}, nil},
+		{"only required", Attributes{"volume": 1.5}, Attributes{"volume": 1.5}, nil},
+		{"missing required", Attributes{"brand": "Добрый"}, nil, e.ErrAttributeRequired},
+		{"nil attributes with required", nil, nil, e.ErrAttributeRequired},
+		{"unknown attribute", Attributes{"volume": 1.0, "color": "red"}, nil, e.ErrUnknownAttribute},
+		{"number as string", Attributes{"volume": "1"}, nil, e.ErrInvalidAttribute},
+		{"NaN number", Attributes{"volume": math.NaN()}, nil, e.ErrInvalidAttribute},
+		{"infinite number", Attributes{"volume": math.Inf(1)}, nil, e.ErrInvalidAttribute},
+		{"bool as string", Attributes{"volume": 1.0, "sugar_free": "true"}, nil, e.ErrInvalidAttribute},
+		{"string as number", Attributes{"volume": 1.0, "brand": 5.0}, nil, e.ErrInvalidAttribute},
+		{"blank string", Attributes{"volume": 1.0, "brand": "  "}, nil, e.ErrInvalidAttribute},
+		{"too long string", Attributes{"volume": 1.0, "brand": strings.Repeat("я", maxAttributeValueLength+1)}, nil, e.ErrInvalidAttribute},
+		{"enum value outside list", Attributes{"volume": 1.0, "pack": "box"}, nil, e.ErrInvalidAttribute},
+		{"enum value is trimmed", Attributes{"volume": 1.0, "pack": " bottle "}, Attributes{"volume": 1.0, "pack": "bottle"}, nil},
+		{"enum value is case sensitive", Attributes{"volume": 1.0, "pack": "Can"}, nil, e.ErrInvalidAttribute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateAttributes(defs, tt.attrs)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("ValidateAttributes() error = %v, want %v", err, tt.err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ValidateAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAttributeFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Attributes
+		want   Attributes
+		err    error
+	}{
+		{"empty filter", Attributes{}, Attributes{}, nil},
+		{
+			name:   "type is taken from value",
+			filter: Attributes{"brand": " Добрый ", "volume": 1, "sugar_free": false},
+			want:   Attributes{"brand": "Добрый", "volume": 1.0, "sugar_free": false},
+		},
+		{"code outside any schema is allowed", Attributes{"color": "red"}, Attributes{"color": "red"}, nil},
+		{"invalid code", Attributes{"Color": "red"}, nil, e.ErrUnknownAttribute},
+		{"blank string", Attributes{"brand": " "}, nil, e.ErrInvalidAttribute},
+		{"NaN number", Attributes{"volume": math.NaN()}, nil, e.ErrInvalidAttribute},
+		{"unsupported value type", Attributes{"volume": []any{1.0}}, nil, e.ErrInvalidAttribute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateAttributeFilter(tt.filter)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("ValidateAttributeFilter() error = %v, want %v", err, tt.err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ValidateAttributeFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariantKey(t *testing.T) {
+	group := NewVariantGroup("Добрый Кола", []string{"volume", "pack"})
+	base := Attributes{"brand": "Добрый", "volume": 0.5, "pack": "can"}
+
+	baseKey, err := group.VariantKey(base)
+	if err != nil {
+		t.Fatalf("VariantKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		attrs    Attributes
+		conflict bool // ключ совпадает с ключом base
+		err      error
+	}{
+		{"same axes conflict", Attributes{"volume": 0.5, "pack": "can"}, true, nil},
+		{"non-axis attributes are ignored", Attributes{"brand": "Другой", "volume": 0.5, "pack": "can"}, true, nil},
+		{"other volume", Attributes{"volume": 1.0, "pack": "can"}, false, nil},
+		{"other pack", Attributes{"volume": 0.5, "pack": "bottle"}, false, nil},
+		{"swapped axis values", Attributes{"volume": "can", "pack": 0.5}, false, nil},
+		{"missing axis", Attributes{"volume": 0.5}, false, e.ErrVariantAxisMissing},
+		{"nil attributes", nil, false, e.ErrVariantAxisMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := group.VariantKey(tt.attrs)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("VariantKey() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (got == baseKey) != tt.conflict {
+				t.Errorf("VariantKey() = %q, base key %q, want conflict %t", got, baseKey, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestVariantKeyOfNormalizedAttributes(t *testing.T) {
+	defs := []AttributeDef{{Code: "volume", Name: "Объём", Type: AttributeNumber}}
+	group := NewVariantGroup("Вода", []string{"volume"})
+
+	// Значения, равные после приведения к хранимому виду, дают одинаковый ключ
+	keys := make(map[string]struct{})
+	for _, value := range []any{1, int64(1), 1.0} {
+		attrs, err := ValidateAttributes(defs, Attributes{"volume": value})
+		if err != nil {
+			t.Fatalf("ValidateAttributes() error = %v", err)
+		}
+
+		key, err := group.VariantKey(attrs)
+		if err != nil {
+			t.Fatalf("VariantKey() error = %v", err)
+		}
+		keys[key] = struct{}{}
+	}
+
+	if len(keys) != 1 {
+		t.Errorf("VariantKey() returned %d distinct keys for equal values, want 1", len(keys))
+	}
+}
@@ -6,13 +6,15 @@ import "time"
 type Category struct {
 	ID         int64
 	Name       string
+	ParentID   *int64 // nil — корневая категория
 	CreatedAt  time.Time
 	UpdatedAt  *time.Time
 	IsArchived bool
 }
 
-func NewCategory(name string) *Category {
+func NewCategory(name string, parentID *int64) *Category {
 	return &Category{
-		Name: name,
+		Name:     name,
+		ParentID: parentID,
 	}
 }
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// CheckoutStatus — состояние сессии оформления покупки
+type CheckoutStatus string
+
+const (
+	CheckoutOpen      CheckoutStatus = "open"      // позиции можно добавлять и изменять
+	CheckoutCompleted CheckoutStatus = "completed" // покупка оформлена, сессия доступна только для чтения
+)
+
+// IdentificationMethod — способ, которым товар позиции был определён на кассе
+type IdentificationMethod string
+
+const (
+	IdentifiedByRecognition IdentificationMethod = "recognition"
+	IdentifiedByID          IdentificationMethod = "id"
+	IdentifiedByBarcode     IdentificationMethod = "barcode"
+)
+
+const (
+	// MaxCheckoutLines ограничивает кол-во позиций в одной сессии
+	MaxCheckoutLines = 200
+	// MaxLineQuantity ограничивает кол-во штук в одной позиции
+	MaxLineQuantity = 999
+)
+
+// CheckoutSession описывает корзину покупателя на кассе
+type CheckoutSession struct {
+	ID          string
+	Status      CheckoutStatus
+	StoreID     *int64 // магазин, в ассортименте и по ценам которого оценивается корзина; nil — общие цены
+	Lines       []CheckoutLine
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+	Version     int64 // Увеличивается при каждом изменении сессии
+}
+
+// CheckoutLine описывает позицию корзины. Цена позиции не хранится и рассчитывается по текущей цене продукта.
+type CheckoutLine struct {
+	ID               string
+	ProductID        int64
+	Quantity         int64 // кол-во штук; для весового продукта всегда 1
+	WeightGrams      int64 // показание весов для весового продукта, иначе 0
+	Method           IdentificationMethod
+	Barcode          *string  // штрихкод, по которому добавлен продукт
+	RecognitionScore *float32 // score распознавания продукта
+	ModelVersion     *string  // версия модели, распознавшей продукт
+	AddedAt          time.Time
+}
+
+func NewCheckoutSession(id string, storeID *int64, now time.Time) *CheckoutSession {
+	return &CheckoutSession{
+		ID:        id,
+		Status:    CheckoutOpen,
+		StoreID:   storeID,
+		Lines:     []CheckoutLine{},
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   1,
+	}
+}
+
+func NewCheckoutLine(id string, productID int64, quantity int64, weightGrams int64, method IdentificationMethod, now time.Time) CheckoutLine {
+	return CheckoutLine{
+		ID:          id,
+		ProductID:   productID,
+		Quantity:    quantity,
+		WeightGrams: weightGrams,
+		Method:      method,
+		AddedAt:     now,
+	}
+}
+
+// FindLine возвращает индекс позиции с заданным ID или -1
+func (s *CheckoutSession) FindLine(id string) int {
+	for i := range s.Lines {
+		if s.Lines[i].ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ProductIDs возвращает ID продуктов позиций без повторов в порядке первого появления
+func (s *CheckoutSession) ProductIDs() []int64 {
+	seen := make(map[int64]struct{}, len(s.Lines))
+	ids := make([]int64, 0, len(s.Lines))
+	for _, line := range s.Lines {
+		if _, ok := seen[line.ProductID]; !ok {
+			seen[line.ProductID] = struct{}{}
+			ids = append(ids, line.ProductID)
+		}
+	}
+
+	return ids
+}
+
+// Total рассчитывает стоимость позиции по текущей цене продукта за единицу измерения.
+func (l CheckoutLine) Total(price Money, unit Unit) (Money, error) {
+	return LineTotal(price, unit, l.Quantity, l.WeightGrams)
+}
+
+// LineTotal рассчитывает стоимость кол-ва продукта по цене за единицу измерения.
+// Весовой продукт оценивается по показанию весов, штучный — по кол-ву.
+func LineTotal(price Money, unit Unit, quantity int64, weightGrams int64) (Money, error) {
+	if unit.IsWeighted() {
+		return PriceByWeight(price, weightGrams)
+	}
+
+	if weightGrams != 0 {
+		return Money{}, e.ErrProductNotWeighted
+	}
+
+	if quantity <= 0 || quantity > MaxLineQuantity {
+		return Money{}, e.ErrInvalidQuantity
+	}
+
+	if err := price.Validate(); err != nil {
+		return Money{}, err
+	}
+
+	// Переполнения нет: цена не больше 10^12 минимальных единиц, кол-во не больше MaxLineQuantity
+	return NewMoney(price.Amount*quantity, price.Currency), nil
+}
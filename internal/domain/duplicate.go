@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// DuplicatePolicy — действие при регистрации продукта, изображения которого похожи на изображения существующих продуктов.
+type DuplicatePolicy string
+
+const (
+	DuplicateReject DuplicatePolicy = "reject" // регистрация отклоняется со списком вероятных дубликатов
+	DuplicateReview DuplicatePolicy = "review" // продукт создаётся, вероятные дубликаты сохраняются для проверяющего
+)
+
+// ParseDuplicatePolicy проверяет действие при вероятном дубликате без учёта регистра. Пустое значение означает DuplicateReject.
+func ParseDuplicatePolicy(s string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return DuplicateReject, nil
+	case DuplicateReject, DuplicateReview:
+		return policy, nil
+	default:
+		return "", e.ErrInvalidDuplicatePolicy
+	}
+}
+
+// DuplicateFlag — отметка проверяющему: продукт похож на опубликованный продукт CandidateID с близостью Score.
+type DuplicateFlag struct {
+	ProductID   int64
+	CandidateID int64
+	Score       float32
+	CreatedAt   time.Time
+}
+
+func NewDuplicateFlag(productID int64, candidateID int64, score float32) *DuplicateFlag {
+	return &DuplicateFlag{
+		ProductID:   productID,
+		CandidateID: candidateID,
+		Score:       score,
+	}
+}
@@ -12,6 +12,13 @@ type Embedding struct {
 	Payload Payload
 }
 
+// SearchHit описывает эмбеддинг, найденный поиском ближайших соседей, и его близость к запросу
+type SearchHit struct {
+	ID      string
+	Score   float32
+	Payload Payload
+}
+
 func NewEmbedding(id string, vector []float32, payload Payload) *Embedding {
 	return &Embedding{
 		ID:      id,
@@ -28,3 +35,60 @@ func NewPayload(productID int64, imagePath string, modelVersion string) Payload
 		"model_version": modelVersion,
 	}
 }
+
+func NewSearchHit(id string, score float32, payload Payload) *SearchHit {
+	return &SearchHit{
+		ID:      id,
+		Score:   score,
+		Payload: payload,
+	}
+}
+
+// ProductID возвращает идентификатор продукта, к которому относится вектор
+func (p Payload) ProductID() (int64, bool) {
+	productID, ok := p["product_id"].(int64)
+	return productID, ok
+}
+
+// SetProductID переназначает вектор другому продукту
+func (p Payload) SetProductID(productID int64) {
+	p["product_id"] = productID
+}
+
+// SetStoreIDs задаёт магазины, в ассортименте которых распознаётся продукт вектора
+func (p Payload) SetStoreIDs(storeIDs []int64) {
+	ids := make([]any, 0, len(storeIDs))
+	for _, id := range storeIDs {
+		ids = append(ids, id)
+	}
+	p["store_ids"] = ids
+}
+
+// SetVariant задаёт атрибуты продукта вектора и его группу вариантов для фильтрации при поиске.
+// Атрибуты хранятся вложенным объектом attributes, продукт вне группы получает variant_group_id = null.
+func (p Payload) SetVariant(attrs Attributes, variantGroupID *int64) {
+	values := make(map[string]any, len(attrs))
+	for code, value := range attrs {
+		values[code] = value
+	}
+	p["attributes"] = values
+
+	if variantGroupID != nil {
+		p["variant_group_id"] = *variantGroupID
+	} else {
+		p["variant_group_id"] = nil
+	}
+}
+
+// SetStatus задаёт статус продукта вектора. Векторы продуктов вне статуса ProductActive исключаются из поиска;
+// is_archived сохраняется для векторов, записанных до появления статусов.
+func (p Payload) SetStatus(status ProductStatus) {
+	p["status"] = string(status)
+	p["is_archived"] = status == ProductArchived
+}
+
+// ImagePath возвращает ключ изображения в объектном хранилище, по которому построен вектор
+func (p Payload) ImagePath() (string, bool) {
+	imagePath, ok := p["image_path"].(string)
+	return imagePath, ok
+}
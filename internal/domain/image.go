@@ -1,5 +1,8 @@
 package domain
 
+// MaxProductImages — макс. кол-во изображений (и векторов) одного продукта, на которое рассчитано распознавание
+const MaxProductImages = 10
+
 // Image описывает изображение, которое хранится в S3
 type Image struct {
 	ID        string // uuid
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// ImageMeta описывает сохранённое изображение продукта и построенный по нему вектор
+type ImageMeta struct {
+	ID           string // uuid
+	ProductID    int64
+	ObjectKey    string
+	MimeType     string
+	Size         int64
+	SHA256       string
+	EmbeddingID  *string // ID точки в Qdrant
+	ModelVersion *string
+	CreatedAt    time.Time
+}
+
+func NewImageMeta(id string, productID int64, objectKey string, mimeType string, size int64, sha256 string,
+	embeddingID *string, modelVersion *string) *ImageMeta {
+	return &ImageMeta{
+		ID:           id,
+		ProductID:    productID,
+		ObjectKey:    objectKey,
+		MimeType:     mimeType,
+		Size:         size,
+		SHA256:       sha256,
+		EmbeddingID:  embeddingID,
+		ModelVersion: modelVersion,
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"maps"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/locale"
+)
+
+// maxProductNameLength — макс. длина названия продукта в символах
+const maxProductNameLength = 128
+
+// LocalizedNames — названия продукта для отображения по языкам, например {"en": "Milk"}.
+// Ключ — код языка из locale.Supported; каноническое название хранится в Product.Name.
+type LocalizedNames map[string]string
+
+// ParseLocalizedNames проверяет языки и обрезает пробелы в названиях. Пустое название не допускается:
+// чтобы удалить перевод, его нужно не передавать.
+func ParseLocalizedNames(names map[string]string) (LocalizedNames, error) {
+	parsed := make(LocalizedNames, len(names))
+	for lang, name := range names {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if !locale.IsSupported(lang) {
+			return nil, e.ErrUnsupportedLocale
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" || utf8.RuneCountInString(name) > maxProductNameLength {
+			return nil, e.ErrProductNameRequired
+		}
+		parsed[lang] = name
+	}
+
+	return parsed, nil
+}
+
+// Name возвращает название на языке lang; false означает, что перевода нет.
+func (n LocalizedNames) Name(lang string) (string, bool) {
+	name, ok := n[lang]
+	return name, ok
+}
+
+// Equal сообщает, совпадают ли наборы названий.
+func (n LocalizedNames) Equal(other LocalizedNames) bool {
+	return maps.Equal(n, other)
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/shopspring/decimal"
+)
+
+// Currency — код валюты по ISO 4217
+type Currency string
+
+const (
+	CurrencyRUB Currency = "RUB"
+	CurrencyBYN Currency = "BYN"
+	CurrencyKZT Currency = "KZT"
+	CurrencyUZS Currency = "UZS"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+	CurrencyJPY Currency = "JPY"
+	CurrencyKWD Currency = "KWD"
+)
+
+// DefaultCurrency используется, если валюта цены не указана
+const DefaultCurrency = CurrencyRUB
+
+// maxMajorAmount ограничивает цену в основных единицах валюты
+const maxMajorAmount = 1_000_000_000
+
+// currencyExponents хранит кол-во знаков дробной части для поддерживаемых валют
+var currencyExponents = map[Currency]int32{
+	CurrencyRUB: 2,
+	CurrencyBYN: 2,
+	CurrencyKZT: 2,
+	CurrencyUZS: 2,
+	CurrencyUSD: 2,
+	CurrencyEUR: 2,
+	CurrencyJPY: 0,
+	CurrencyKWD: 3,
+}
+
+// ParseCurrency проверяет код валюты без учёта регистра. Пустой код означает DefaultCurrency.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+
+	currency := Currency(code)
+	if _, ok := currencyExponents[currency]; !ok {
+		return "", e.ErrUnsupportedCurrency
+	}
+
+	return currency, nil
+}
+
+// Exponent возвращает кол-во знаков дробной части валюты
+func (c Currency) Exponent() int32 {
+	return currencyExponents[c]
+}
+
+// Money описывает сумму в минимальных единицах валюты (копейках, центах и т.п.)
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// ParseMoney преобразует строку вида "599.99" в сумму в минимальных единицах валюты.
+// Возвращает ошибку, если:
+// - формат некорректен или сумма отрицательна
+// - знаков дробной части больше, чем у валюты
+// - сумма превышает 10^9 основных единиц валюты
+func ParseMoney(s string, currency Currency) (Money, error) {
+	exp, ok := currencyExponents[currency]
+	if !ok {
+		return Money{}, e.ErrUnsupportedCurrency
+	}
+
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return Money{}, e.ErrInvalidPrice
+	}
+
+	if d.IsNegative() || d.GreaterThan(decimal.NewFromInt(maxMajorAmount)) {
+		return Money{}, e.ErrInvalidPrice
+	}
+
+	if d.Exponent() < -exp {
+		return Money{}, e.ErrPricePrecision
+	}
+
+	return NewMoney(d.Shift(exp).IntPart(), currency), nil
+}
+
+// Validate проверяет, что валюта поддерживается, а сумма положительна
+func (m Money) Validate() error {
+	if _, ok := currencyExponents[m.Currency]; !ok {
+		return e.ErrUnsupportedCurrency
+	}
+
+	if m.Amount <= 0 {
+		return e.ErrPriceMustBePositive
+	}
+
+	return nil
+}
+
+// Decimal возвращает сумму в основных единицах валюты, например "599.99"
+func (m Money) Decimal() string {
+	exp := m.Currency.Exponent()
+	return decimal.New(m.Amount, -exp).StringFixed(exp)
+}
+
+// String возвращает сумму с кодом валюты, например "599.99 RUB"
+func (m Money) String() string {
+	return m.Decimal() + " " + string(m.Currency)
+}
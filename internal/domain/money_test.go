@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		currency Currency
+		want     Money
+		err      error
+	}{
+		{"two decimals", "599.99", CurrencyRUB, NewMoney(59999, CurrencyRUB), nil},
+		{"integer", "600", CurrencyRUB, NewMoney(60000, CurrencyRUB), nil},
+		{"one decimal", "0.5", CurrencyUSD, NewMoney(50, CurrencyUSD), nil},
+		{"surrounding spaces", " 12.30 ", CurrencyEUR, NewMoney(1230, CurrencyEUR), nil},
+		{"zero exponent currency", "1500", CurrencyJPY, NewMoney(1500, CurrencyJPY), nil},
+		{"three decimal currency", "1.234", CurrencyKWD, NewMoney(1234, CurrencyKWD), nil},
+		{"zero", "0", CurrencyRUB, NewMoney(0, CurrencyRUB), nil},
+		{"max amount", "1000000000", CurrencyRUB, NewMoney(100_000_000_000, CurrencyRUB), nil},
+		{"too precise", "1.999", CurrencyRUB, Money{}, e.ErrPricePrecision},
+		{"fraction for zero exponent currency", "10.5", CurrencyJPY, Money{}, e.ErrPricePrecision},
+		{"negative", "-1", CurrencyRUB, Money{}, e.ErrInvalidPrice},
+		{"above max amount", "1000000000.01", CurrencyRUB, Money{}, e.ErrInvalidPrice},
+		{"not a number", "abc", CurrencyRUB, Money{}, e.ErrInvalidPrice},
+		{"empty", "", CurrencyRUB, Money{}, e.ErrInvalidPrice},
+		{"unsupported currency", "1", Currency("XXX"), Money{}, e.ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.s, tt.currency)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("ParseMoney(%q) error = %v, want %v", tt.s, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,19 +4,32 @@ import "time"
 
 // Product описывает продукт
 type Product struct {
-	ID         int64
-	Name       string
-	Price      int64 // Цена хранится в копейках
-	CategoryID int64
-	CreatedAt  time.Time
-	UpdatedAt  *time.Time
-	IsArchived bool
+	ID             int64
+	Name           string
+	Price          Money    // за единицу измерения: за штуку, килограмм или литр
+	SKU            *string  // артикул, уникален среди продуктов
+	Barcodes       []string // штрихкоды EAN/UPC в порядке возрастания
+	Unit           Unit
+	CategoryID     int64
+	LocalizedNames LocalizedNames // названия для отображения на других языках
+	Attributes     Attributes     // значения атрибутов по схеме категории
+	VariantGroupID *int64         // группа вариантов продукта
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	IsArchived     bool          // совпадает с Status == ProductArchived
+	Status         ProductStatus // продукт до одобрения не участвует в распознавании
+	Version        int64         // Увеличивается при каждом изменении продукта
 }
 
-func NewProduct(name string, price int64, categoryID int64) *Product {
+// NewProduct создаёт продукт, ожидающий проверки.
+func NewProduct(name string, price Money, categoryID int64) *Product {
 	return &Product{
-		Name:       name,
-		Price:      price,
-		CategoryID: categoryID,
+		Name:           name,
+		Price:          price,
+		Unit:           UnitPiece,
+		CategoryID:     categoryID,
+		Status:         ProductPendingReview,
+		LocalizedNames: LocalizedNames{},
+		Attributes:     Attributes{},
 	}
 }
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// Unit — единица измерения продукта
+type Unit string
+
+const (
+	UnitPiece    Unit = "piece"
+	UnitKilogram Unit = "kg"
+	UnitLiter    Unit = "l"
+)
+
+// maxSKULength ограничивает длину артикула
+const maxSKULength = 64
+
+// ParseUnit проверяет единицу измерения без учёта регистра. Пустая строка означает UnitPiece.
+func ParseUnit(s string) (Unit, error) {
+	switch unit := Unit(strings.ToLower(strings.TrimSpace(s))); unit {
+	case "":
+		return UnitPiece, nil
+	case UnitPiece, UnitKilogram, UnitLiter:
+		return unit, nil
+	default:
+		return "", e.ErrInvalidUnit
+	}
+}
+
+// ParseSKU проверяет артикул: от 1 до 64 латинских букв, цифр и символов "-", "_", ".".
+func ParseSKU(s string) (string, error) {
+	sku := strings.TrimSpace(s)
+	if sku == "" || len(sku) > maxSKULength {
+		return "", e.ErrInvalidSKU
+	}
+
+	for _, r := range sku {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return "", e.ErrInvalidSKU
+		}
+	}
+
+	return sku, nil
+}
+
+// ParseBarcode проверяет штрихкод EAN-8, UPC-A, EAN-13 или GTIN-14 по контрольной цифре GS1.
+// UPC-A приводится к EAN-13 добавлением ведущего нуля, чтобы один товар не имел двух записей.
+func ParseBarcode(s string) (string, error) {
+	code := strings.TrimSpace(s)
+	switch len(code) {
+	case 8, 13, 14:
+	case 12:
+		code = "0" + code
+	default:
+		return "", e.ErrInvalidBarcode
+	}
+
+	sum := 0
+	for i := len(code) - 1; i >= 0; i-- {
+		d := code[i]
+		if d < '0' || d > '9' {
+			return "", e.ErrInvalidBarcode
+		}
+
+		// Веса 3 и 1 чередуются справа налево, начиная с цифры перед контрольной
+		digit := int(d - '0')
+		if (len(code)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	if sum%10 != 0 {
+		return "", e.ErrInvalidBarcode
+	}
+
+	return code, nil
+}
+
+// ParseBarcodes проверяет штрихкоды и возвращает их без повторов в порядке возрастания.
+func ParseBarcodes(codes []string) ([]string, error) {
+	res := make([]string, 0, len(codes))
+	for _, s := range codes {
+		code, err := ParseBarcode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, code)
+	}
+
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
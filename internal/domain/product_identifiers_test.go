@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+func TestParseBarcode(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+		err  error
+	}{
+		{"EAN-13", "4006381333931", "4006381333931", nil},
+		{"EAN-8", "73513537", "73513537", nil},
+		{"UPC-A is normalized to EAN-13", "036000291452", "0036000291452", nil},
+		{"GTIN-14", "10012345678902", "10012345678902", nil},
+		{"surrounding spaces", " 4006381333931 ", "4006381333931", nil},
+		{"wrong check digit", "4006381333932", "", e.ErrInvalidBarcode},
+		{"wrong UPC-A check digit", "036000291453", "", e.ErrInvalidBarcode},
+		{"non-digit", "40063813339X1", "", e.ErrInvalidBarcode},
+		{"unsupported length", "123456789", "", e.ErrInvalidBarcode},
+		{"empty", "", "", e.ErrInvalidBarcode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBarcode(tt.s)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("ParseBarcode(%q) error = %v, want %v", tt.s, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBarcode(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBarcodes(t *testing.T) {
+	got, err := ParseBarcodes([]string{"4006381333931", "036000291452", "0036000291452", "73513537"})
+	if err != nil {
+		t.Fatalf("ParseBarcodes() error = %v", err)
+	}
+
+	want := []string{"0036000291452", "4006381333931", "73513537"}
+	if len(got) != len(want) {
+		t.Fatalf("ParseBarcodes() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ParseBarcodes() = %v, want %v", got, want)
+		}
+	}
+
+	if _, err := ParseBarcodes([]string{"4006381333931", "4006381333932"}); !errors.Is(err, e.ErrInvalidBarcode) {
+		t.Errorf("ParseBarcodes() error = %v, want %v", err, e.ErrInvalidBarcode)
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// ProductPrice описывает цену продукта, действующую с EffectiveFrom.
+// Запланированная цена не применена к продукту, пока AppliedAt равен nil.
+type ProductPrice struct {
+	ID            int64
+	ProductID     int64
+	Price         Money
+	EffectiveFrom time.Time
+	AppliedAt     *time.Time
+	CreatedAt     time.Time
+}
+
+func NewProductPrice(productID int64, price Money, effectiveFrom time.Time) *ProductPrice {
+	return &ProductPrice{
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+	}
+}
+
+// IsScheduled сообщает, что цена ещё не применена к продукту.
+func (p *ProductPrice) IsScheduled() bool {
+	return p.AppliedAt == nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// ProductRedirect — перенаправление со слитого продукта-дубликата на продукт, в который он слит.
+// По ID источника GetProductsInfo возвращает продукт-получатель.
+type ProductRedirect struct {
+	SourceID  int64
+	TargetID  int64
+	CreatedAt time.Time
+}
+
+func NewProductRedirect(sourceID int64, targetID int64) *ProductRedirect {
+	return &ProductRedirect{
+		SourceID: sourceID,
+		TargetID: targetID,
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// ProductStatus — этап жизненного цикла продукта. В распознавании и выдаче GetProductsInfo
+// участвуют только продукты в статусе ProductActive.
+type ProductStatus string
+
+const (
+	ProductDraft         ProductStatus = "draft"          // отклонён проверкой, ожидает доработки
+	ProductPendingReview ProductStatus = "pending_review" // ожидает проверки
+	ProductActive        ProductStatus = "active"
+	ProductArchived      ProductStatus = "archived"
+)
+
+const (
+	maxReviewerLength      = 128  // макс. длина имени проверяющего в символах
+	maxReviewCommentLength = 2000 // макс. длина комментария проверки в символах
+)
+
+// productTransitions — допустимые переходы между статусами продукта.
+// Архивировать можно только опубликованный продукт, восстановленный продукт снова публикуется.
+var productTransitions = map[ProductStatus][]ProductStatus{
+	ProductDraft:         {ProductPendingReview},
+	ProductPendingReview: {ProductActive, ProductDraft},
+	ProductActive:        {ProductArchived},
+	ProductArchived:      {ProductActive},
+}
+
+// ParseProductStatus проверяет статус продукта без учёта регистра.
+func ParseProductStatus(s string) (ProductStatus, error) {
+	switch status := ProductStatus(strings.ToLower(strings.TrimSpace(s))); status {
+	case ProductDraft, ProductPendingReview, ProductActive, ProductArchived:
+		return status, nil
+	default:
+		return "", e.ErrInvalidStatus
+	}
+}
+
+// CanTransition сообщает, допускается ли переход из статуса s в статус to.
+func (s ProductStatus) CanTransition(to ProductStatus) bool {
+	for _, next := range productTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVisible сообщает, участвует ли продукт в распознавании и выдаче покупателям.
+func (s ProductStatus) IsVisible() bool {
+	return s == ProductActive
+}
+
+// ProductTransition — запись истории статусов продукта: переход, проверяющий и его комментарий.
+type ProductTransition struct {
+	ID         int64
+	ProductID  int64
+	FromStatus ProductStatus
+	ToStatus   ProductStatus
+	Reviewer   *string // nil для переходов без проверки, например архивации
+	Comment    *string
+	CreatedAt  time.Time
+}
+
+func NewProductTransition(productID int64, from ProductStatus, to ProductStatus, reviewer *string, comment *string) *ProductTransition {
+	return &ProductTransition{
+		ProductID:  productID,
+		FromStatus: from,
+		ToStatus:   to,
+		Reviewer:   reviewer,
+		Comment:    comment,
+	}
+}
+
+// ParseReviewer проверяет имя проверяющего: от 1 до 128 символов без учёта пробелов по краям.
+func ParseReviewer(s string) (string, error) {
+	reviewer := strings.TrimSpace(s)
+	if reviewer == "" || utf8.RuneCountInString(reviewer) > maxReviewerLength {
+		return "", e.ErrInvalidReviewer
+	}
+
+	return reviewer, nil
+}
+
+// ParseReviewComment обрезает пробелы в комментарии проверки. Пустой комментарий означает nil.
+func ParseReviewComment(s string) (*string, error) {
+	comment := strings.TrimSpace(s)
+	if comment == "" {
+		return nil, nil
+	}
+
+	if utf8.RuneCountInString(comment) > maxReviewCommentLength {
+		return nil, e.ErrInvalidReviewComment
+	}
+
+	return &comment, nil
+}
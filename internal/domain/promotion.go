@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// PromotionKind — вид скидки акции
+type PromotionKind string
+
+const (
+	PromotionPercent PromotionKind = "percent" // процент от стоимости позиции
+	PromotionFixed   PromotionKind = "fixed"   // фиксированная сумма за единицу измерения
+	PromotionBundle  PromotionKind = "bundle"  // "N за M": из каждых BuyQuantity штук оплачиваются PayQuantity
+)
+
+// PromotionOutcome — результат проверки акции для позиции
+type PromotionOutcome string
+
+const (
+	PromotionApplied       PromotionOutcome = "applied"        // скидка акции применена к позиции
+	PromotionOutranked     PromotionOutcome = "outranked"      // акция подходит, но применена другая
+	PromotionNotApplicable PromotionOutcome = "not_applicable" // условия акции не выполнены
+)
+
+// Promotion описывает акцию на продукт или на категорию вместе с её подкатегориями.
+// Задаётся ровно одно из ProductID и CategoryID.
+type Promotion struct {
+	ID          int64
+	Name        string
+	Kind        PromotionKind
+	ProductID   *int64
+	CategoryID  *int64
+	Percent     int64  // для PromotionPercent: от 1 до 100
+	Amount      *Money // для PromotionFixed: скидка за штуку, килограмм или литр
+	BuyQuantity int64  // для PromotionBundle: N
+	PayQuantity int64  // для PromotionBundle: M, меньше N
+	Priority    int64  // при равной скидке применяется акция с большим приоритетом
+	StartsAt    *time.Time
+	EndsAt      *time.Time // не входит в период действия
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+// PromotionLine — позиция, для которой рассчитывается скидка
+type PromotionLine struct {
+	ProductID   int64
+	Price       Money // цена продукта за единицу измерения
+	Unit        Unit
+	Quantity    int64
+	WeightGrams int64
+	Total       Money // стоимость позиции без скидки
+}
+
+// PromotionEvaluation — результат проверки одной акции для позиции
+type PromotionEvaluation struct {
+	Promotion *Promotion
+	Discount  Money
+	Outcome   PromotionOutcome
+}
+
+// Validate обрезает пробелы в названии и проверяет название, цель, параметры вида скидки и период действия акции.
+func (p *Promotion) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return e.Wrap("name", e.ErrInvalidPromotion)
+	}
+
+	if (p.ProductID == nil) == (p.CategoryID == nil) {
+		return e.Wrap("exactly one of product_id and category_id is required", e.ErrInvalidPromotion)
+	}
+
+	if (p.ProductID != nil && *p.ProductID <= 0) || (p.CategoryID != nil && *p.CategoryID <= 0) {
+		return e.ErrInvalidID
+	}
+
+	switch p.Kind {
+	case PromotionPercent:
+		if p.Percent < 1 || p.Percent > 100 || p.Amount != nil || p.BuyQuantity != 0 || p.PayQuantity != 0 {
+			return e.Wrap("percent must be from 1 to 100", e.ErrInvalidPromotion)
+		}
+	case PromotionFixed:
+		if p.Amount == nil || p.Percent != 0 || p.BuyQuantity != 0 || p.PayQuantity != 0 {
+			return e.Wrap("amount is required", e.ErrInvalidPromotion)
+		}
+		if err := p.Amount.Validate(); err != nil {
+			return err
+		}
+	case PromotionBundle:
+		if p.BuyQuantity < 2 || p.BuyQuantity > MaxLineQuantity || p.PayQuantity < 1 || p.PayQuantity >= p.BuyQuantity ||
+			p.Percent != 0 || p.Amount != nil {
+			return e.Wrap("pay_quantity must be positive and less than buy_quantity", e.ErrInvalidPromotion)
+		}
+	default:
+		return e.Wrap("kind", e.ErrInvalidPromotion)
+	}
+
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return e.Wrap("ends_at must be after starts_at", e.ErrInvalidPromotion)
+	}
+
+	return nil
+}
+
+// ActiveAt сообщает, действует ли акция в момент t.
+func (p *Promotion) ActiveAt(t time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+
+	return p.EndsAt == nil || t.Before(*p.EndsAt)
+}
+
+// Discount рассчитывает скидку акции для позиции. Скидка не превышает стоимость позиции
+// и округляется до минимальной единицы валюты половиной вверх.
+// Возвращает false, если условия акции для позиции не выполнены.
+func (p *Promotion) Discount(line PromotionLine) (Money, bool) {
+	var amount int64
+	switch p.Kind {
+	case PromotionPercent:
+		amount = (line.Total.Amount*p.Percent + 50) / 100
+	case PromotionFixed:
+		if p.Amount == nil || p.Amount.Currency != line.Total.Currency {
+			return Money{}, false
+		}
+
+		if line.Unit.IsWeighted() {
+			amount = (p.Amount.Amount*line.WeightGrams + gramsPerKilogram/2) / gramsPerKilogram
+		} else {
+			amount = p.Amount.Amount * line.Quantity
+		}
+	case PromotionBundle:
+		// "N за M" применяется только к штучным продуктам
+		if line.Unit.IsWeighted() || p.BuyQuantity <= 0 || line.Quantity < p.BuyQuantity {
+			return Money{}, false
+		}
+
+		free := line.Quantity / p.BuyQuantity * (p.BuyQuantity - p.PayQuantity)
+		amount = free * line.Price.Amount
+	}
+
+	amount = min(amount, line.Total.Amount)
+	if amount <= 0 {
+		return Money{}, false
+	}
+
+	return NewMoney(amount, line.Total.Currency), true
+}
+
+// EvaluatePromotions проверяет действующие акции для позиции и применяет одну из них: скидки не суммируются.
+// Применяется акция с наибольшей скидкой; при равной скидке — с большим приоритетом,
+// затем акция на продукт раньше акции на категорию, затем акция с меньшим ID.
+// Результаты возвращаются в том же порядке, неподходящие акции — в конце по возрастанию ID.
+func EvaluatePromotions(line PromotionLine, promotions []*Promotion) []PromotionEvaluation {
+	res := make([]PromotionEvaluation, 0, len(promotions))
+	for _, promotion := range promotions {
+		discount, ok := promotion.Discount(line)
+		outcome := PromotionOutranked
+		if !ok {
+			outcome = PromotionNotApplicable
+		}
+
+		res = append(res, PromotionEvaluation{Promotion: promotion, Discount: discount, Outcome: outcome})
+	}
+
+	slices.SortFunc(res, comparePromotionEvaluations)
+	if len(res) > 0 && res[0].Outcome == PromotionOutranked {
+		res[0].Outcome = PromotionApplied
+	}
+
+	return res
+}
+
+func comparePromotionEvaluations(a, b PromotionEvaluation) int {
+	aApplicable, bApplicable := a.Outcome != PromotionNotApplicable, b.Outcome != PromotionNotApplicable
+	switch {
+	case aApplicable != bApplicable:
+		if aApplicable {
+			return -1
+		}
+		return 1
+	case !aApplicable:
+		return cmp.Compare(a.Promotion.ID, b.Promotion.ID)
+	case a.Discount.Amount != b.Discount.Amount:
+		return cmp.Compare(b.Discount.Amount, a.Discount.Amount)
+	case a.Promotion.Priority != b.Promotion.Priority:
+		return cmp.Compare(b.Promotion.Priority, a.Promotion.Priority)
+	case (a.Promotion.ProductID != nil) != (b.Promotion.ProductID != nil):
+		if a.Promotion.ProductID != nil {
+			return -1
+		}
+		return 1
+	default:
+		return cmp.Compare(a.Promotion.ID, b.Promotion.ID)
+	}
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"testing"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func rub(amount int64) Money {
+	return NewMoney(amount, CurrencyRUB)
+}
+
+func moneyPtr(m Money) *Money {
+	return &m
+}
+
+func TestPromotionDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		promotion Promotion
+		line      PromotionLine
+		want      Money
+		ok        bool
+	}{
+		{
+			name:      "percent rounds half up",
+			promotion: Promotion{Kind: PromotionPercent, Percent: 15},
+			line:      PromotionLine{Price: rub(999), Unit: UnitPiece, Quantity: 1, Total: rub(999)},
+			want:      rub(150),
+			ok:        true,
+		},
+		{
+			name:      "percent rounds below half down",
+			promotion: Promotion{Kind: PromotionPercent, Percent: 5},
+			line:      PromotionLine{Price: rub(1001), Unit: UnitPiece, Quantity: 1, Total: rub(1001)},
+			want:      rub(50),
+			ok:        true,
+		},
+		{
+			name:      "percent of tiny total rounds to zero",
+			promotion: Promotion{Kind: PromotionPercent, Percent: 10},
+			line:      PromotionLine{Price: rub(4), Unit: UnitPiece, Quantity: 1, Total: rub(4)},
+			ok:        false,
+		},
+		{
+			name:      "fixed per piece",
+			promotion: Promotion{Kind: PromotionFixed, Amount: moneyPtr(rub(30))},
+			line:      PromotionLine{Price: rub(100), Unit: UnitPiece, Quantity: 3, Total: rub(300)},
+			want:      rub(90),
+			ok:        true,
+		},
+		{
+			name:      "fixed per kg scales by weight",
+			promotion: Promotion{Kind: PromotionFixed, Amount: moneyPtr(rub(100))},
+			line:      PromotionLine{Price: rub(50000), Unit: UnitKilogram, WeightGrams: 1234, Total: rub(61700)},
+			want:      rub(123),
+			ok:        true,
+		},
+		{
+			name:      "fixed per kg rounds half up",
+			promotion: Promotion{Kind: PromotionFixed, Amount: moneyPtr(rub(100))},
+			line:      PromotionLine{Price: rub(50000), Unit: UnitKilogram, WeightGrams: 1235, Total: rub(61750)},
+			want:      rub(124),
+			ok:        true,
+		},
+		{
+			name:      "fixed does not exceed line total",
+			promotion: Promotion{Kind: PromotionFixed, Amount: moneyPtr(rub(500))},
+			line:      PromotionLine{Price: rub(300), Unit: UnitPiece, Quantity: 1, Total: rub(300)},
+			want:      rub(300),
+			ok:        true,
+		},
+		{
+			name:      "fixed in other currency is not applicable",
+			promotion: Promotion{Kind: PromotionFixed, Amount: moneyPtr(NewMoney(1, CurrencyUSD))},
+			line:      PromotionLine{Price: rub(100), Unit: UnitPiece, Quantity: 1, Total: rub(100)},
+			ok:        false,
+		},
+		{
+			name:      "bundle with partial bundle left over",
+			promotion: Promotion{Kind: PromotionBundle, BuyQuantity: 3, PayQuantity: 2},
+			line:      PromotionLine{Price: rub(100), Unit: UnitPiece, Quantity: 7, Total: rub(700)},
+			want:      rub(200),
+			ok:        true,
+		},
+		{
+			name:      "bundle below buy quantity is not applicable",
+			promotion: Promotion{Kind: PromotionBundle, BuyQuantity: 3, PayQuantity: 2},
+			line:      PromotionLine{Price: rub(100), Unit: UnitPiece, Quantity: 2, Total: rub(200)},
+			ok:        false,
+		},
+		{
+			name:      "bundle is not applicable to weighted products",
+			promotion: Promotion{Kind: PromotionBundle, BuyQuantity: 2, PayQuantity: 1},
+			line:      PromotionLine{Price: rub(100), Unit: UnitKilogram, WeightGrams: 5000, Total: rub(500)},
+			ok:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.promotion.Discount(tt.line)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Discount() = %v, %t, want %v, %t", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestEvaluatePromotions(t *testing.T) {
+	line := PromotionLine{ProductID: 1, Price: rub(100), Unit: UnitPiece, Quantity: 3, Total: rub(300)}
+
+	type result struct {
+		id      int64
+		outcome PromotionOutcome
+	}
+
+	tests := []struct {
+		name       string
+		promotions []*Promotion
+		want       []result
+	}{
+		{
+			name:       "no promotions",
+			promotions: nil,
+			want:       []result{},
+		},
+		{
+			name: "largest discount wins",
+			promotions: []*Promotion{
+				{ID: 1, Kind: PromotionPercent, Percent: 10, ProductID: int64Ptr(1)},
+				{ID: 2, Kind: PromotionPercent, Percent: 20, ProductID: int64Ptr(1)},
+			},
+			want: []result{{2, PromotionApplied}, {1, PromotionOutranked}},
+		},
+		{
+			name: "equal discount ties by priority",
+			promotions: []*Promotion{
+				{ID: 1, Kind: PromotionPercent, Percent: 10, ProductID: int64Ptr(1), Priority: 1},
+				{ID: 2, Kind: PromotionFixed, Amount: moneyPtr(rub(10)), ProductID: int64Ptr(1), Priority: 5},
+			},
+			want: []result{{2, PromotionApplied}, {1, PromotionOutranked}},
+		},
+		{
+			name: "equal discount and priority prefers product over category",
+			promotions: []*Promotion{
+				{ID: 1, Kind: PromotionPercent, Percent: 10, CategoryID: int64Ptr(7)},
+				{ID: 2, Kind: PromotionPercent, Percent: 10, ProductID: int64Ptr(1)},
+			},
+			want: []result{{2, PromotionApplied}, {1, PromotionOutranked}},
+		},
+		{
+			name: "full tie prefers lower ID",
+			promotions: []*Promotion{
+				{ID: 5, Kind: PromotionPercent, Percent: 10, CategoryID: int64Ptr(7)},
+				{ID: 3, Kind: PromotionPercent, Percent: 10, CategoryID: int64Ptr(8)},
+			},
+			want: []result{{3, PromotionApplied}, {5, PromotionOutranked}},
+		},
+		{
+			name: "not applicable promotions go last by ID",
+			promotions: []*Promotion{
+				{ID: 9, Kind: PromotionBundle, BuyQuantity: 5, PayQuantity: 4, ProductID: int64Ptr(1)},
+				{ID: 4, Kind: PromotionFixed, Amount: moneyPtr(NewMoney(1, CurrencyUSD)), ProductID: int64Ptr(1)},
+				{ID: 6, Kind: PromotionPercent, Percent: 1, ProductID: int64Ptr(1)},
+			},
+			want: []result{{6, PromotionApplied}, {4, PromotionNotApplicable}, {9, PromotionNotApplicable}},
+		},
+		{
+			name: "nothing applies",
+			promotions: []*Promotion{
+				{ID: 2, Kind: PromotionBundle, BuyQuantity: 5, PayQuantity: 4, ProductID: int64Ptr(1)},
+			},
+			want: []result{{2, PromotionNotApplicable}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluatePromotions(line, tt.promotions)
+			if len(got) != len(tt.want) {
+				t.Fatalf("EvaluatePromotions() returned %d evaluations, want %d", len(got), len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				if got[i].Promotion.ID != want.id || got[i].Outcome != want.outcome {
+					t.Errorf("EvaluatePromotions()[%d] = %d %s, want %d %s",
+						i, got[i].Promotion.ID, got[i].Outcome, want.id, want.outcome)
+				}
+			}
+		})
+	}
+}
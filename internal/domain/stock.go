@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/google/uuid"
+)
+
+// ReservationStatus — состояние резерва остатка
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"    // резерв уменьшает доступный остаток до истечения срока
+	ReservationCommitted ReservationStatus = "committed" // зарезервированное кол-во списано с остатка
+	ReservationReleased  ReservationStatus = "released"  // резерв отменён
+)
+
+// StockChangeReason — причина изменения остатка
+type StockChangeReason string
+
+const (
+	StockSet       StockChangeReason = "set"       // остаток задан по результатам инвентаризации или приёмки
+	StockDecrement StockChangeReason = "decrement" // остаток списан без резерва
+	StockReserve   StockChangeReason = "reserve"
+	StockCommit    StockChangeReason = "commit"
+	StockRelease   StockChangeReason = "release"
+)
+
+// MaxStockQuantity ограничивает остаток и кол-во в одной операции
+const MaxStockQuantity = 1_000_000_000
+
+// StockLevel описывает остаток товара в магазине в минимальных единицах:
+// штуках, граммах для весового товара или миллилитрах для товара в литрах.
+type StockLevel struct {
+	StoreID   int64
+	ProductID int64
+	OnHand    int64 // фактический остаток
+	Reserved  int64 // сумма активных непросроченных резервов
+	UpdatedAt *time.Time
+}
+
+// StockReservation описывает резерв остатка, например на время оформления покупки
+type StockReservation struct {
+	ID        uuid.UUID
+	StoreID   int64
+	ProductID int64
+	Quantity  int64
+	Status    ReservationStatus
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// StockChange описывает изменение остатка для публикации события
+type StockChange struct {
+	Level         StockLevel
+	Delta         int64 // изменение фактического остатка
+	Reason        StockChangeReason
+	ReservationID *uuid.UUID
+}
+
+func NewStockReservation(storeID int64, productID int64, quantity int64, expiresAt time.Time) *StockReservation {
+	return &StockReservation{
+		ID:        uuid.New(),
+		StoreID:   storeID,
+		ProductID: productID,
+		Quantity:  quantity,
+		Status:    ReservationActive,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func NewStockChange(level StockLevel, delta int64, reason StockChangeReason, reservationID *uuid.UUID) *StockChange {
+	return &StockChange{
+		Level:         level,
+		Delta:         delta,
+		Reason:        reason,
+		ReservationID: reservationID,
+	}
+}
+
+// ValidateStockQuantity проверяет кол-во операции с остатком: от 1 до MaxStockQuantity.
+func ValidateStockQuantity(quantity int64) error {
+	if quantity <= 0 || quantity > MaxStockQuantity {
+		return e.ErrInvalidQuantity
+	}
+
+	return nil
+}
+
+// Available возвращает остаток, доступный для продажи и резервирования.
+// Если после инвентаризации резервов больше фактического остатка, доступно 0.
+func (l *StockLevel) Available() int64 {
+	return max(l.OnHand-l.Reserved, 0)
+}
+
+// InStock сообщает, есть ли доступный остаток.
+func (l *StockLevel) InStock() bool {
+	return l.Available() > 0
+}
+
+// CheckActive проверяет, что резерв можно подтвердить в момент now.
+func (r *StockReservation) CheckActive(now time.Time) error {
+	if r.Status != ReservationActive {
+		return e.ErrReservationClosed
+	}
+
+	if !now.Before(r.ExpiresAt) {
+		return e.ErrReservationExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// maxStoreCodeLength ограничивает длину кода магазина
+const maxStoreCodeLength = 32
+
+// Store описывает магазин сети. Продукты общие для всех магазинов,
+// а ассортимент и цены магазина задаются записями StoreProduct.
+type Store struct {
+	ID        int64
+	Code      string // уникальный код магазина, например "msk-001"
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// StoreProduct описывает продукт в ассортименте магазина.
+// Продукт без записи в магазине не продаётся и не распознаётся.
+type StoreProduct struct {
+	StoreID     int64
+	ProductID   int64
+	IsAvailable bool
+	Price       *Money // цена в магазине, nil — общая цена продукта
+	UpdatedAt   *time.Time
+}
+
+func NewStore(code string, name string) *Store {
+	return &Store{
+		Code: code,
+		Name: name,
+	}
+}
+
+func NewStoreProduct(storeID int64, productID int64, isAvailable bool, price *Money) *StoreProduct {
+	return &StoreProduct{
+		StoreID:     storeID,
+		ProductID:   productID,
+		IsAvailable: isAvailable,
+		Price:       price,
+	}
+}
+
+// Validate приводит код магазина к нижнему регистру, обрезает пробелы в названии и проверяет их.
+// Код состоит из 1–32 латинских букв, цифр и символа "-".
+func (s *Store) Validate() error {
+	s.Code = strings.ToLower(strings.TrimSpace(s.Code))
+	if s.Code == "" || len(s.Code) > maxStoreCodeLength {
+		return e.Wrap("code", e.ErrInvalidStore)
+	}
+
+	for _, r := range s.Code {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+		default:
+			return e.Wrap("code", e.ErrInvalidStore)
+		}
+	}
+
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return e.Wrap("name", e.ErrInvalidStore)
+	}
+
+	return nil
+}
+
+// Sells сообщает, продаётся ли продукт в магазине.
+func (p *StoreProduct) Sells() bool {
+	return p != nil && p.IsAvailable
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+const (
+	maxVariantGroupNameLength = 128 // макс. длина названия группы вариантов
+	maxVariantAxes            = 4   // макс. кол-во атрибутов, различающих варианты
+)
+
+// VariantGroup объединяет варианты одного товара, например напиток объёмом 0,5 л и 1 л.
+// Варианты различаются значениями атрибутов-осей; в группе нет двух продуктов с одинаковыми значениями осей.
+type VariantGroup struct {
+	ID        int64
+	Name      string
+	Axes      []string // коды атрибутов, различающих варианты
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewVariantGroup(name string, axes []string) *VariantGroup {
+	return &VariantGroup{
+		Name: name,
+		Axes: axes,
+	}
+}
+
+// Validate обрезает пробелы в названии и проверяет группу: от 1 до 4 осей с корректными кодами без повторов.
+func (g *VariantGroup) Validate() error {
+	g.Name = strings.TrimSpace(g.Name)
+	if g.Name == "" || utf8.RuneCountInString(g.Name) > maxVariantGroupNameLength {
+		return e.Wrap("name", e.ErrInvalidVariantGroup)
+	}
+
+	if len(g.Axes) == 0 || len(g.Axes) > maxVariantAxes {
+		return e.Wrap("axes", e.ErrInvalidVariantGroup)
+	}
+
+	seen := make(map[string]struct{}, len(g.Axes))
+	for _, axis := range g.Axes {
+		if !validAttributeCode(axis) {
+			return e.Wrap("axes", e.ErrInvalidVariantGroup)
+		}
+
+		if _, ok := seen[axis]; ok {
+			return e.Wrap(axis+": duplicate axis", e.ErrInvalidVariantGroup)
+		}
+		seen[axis] = struct{}{}
+	}
+
+	return nil
+}
+
+// VariantKey возвращает ключ варианта по значениям осей группы.
+// Продукт без значения какой-либо оси не может входить в группу.
+func (g *VariantGroup) VariantKey(attrs Attributes) (string, error) {
+	values := make([]string, 0, len(g.Axes))
+	for _, axis := range g.Axes {
+		value, ok := attrs[axis]
+		if !ok {
+			return "", e.Wrap(axis, e.ErrVariantAxisMissing)
+		}
+		values = append(values, fmt.Sprint(value))
+	}
+
+	return strings.Join(values, "\x00"), nil
+}
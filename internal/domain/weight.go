@@ -0,0 +1,36 @@
+package domain
+
+import "github.com/DRSN-tech/go-backend/pkg/e"
+
+// MaxScaleGrams ограничивает показание весов, переданное для расчёта стоимости
+const MaxScaleGrams = 100_000
+
+// gramsPerKilogram — кол-во граммов в килограмме
+const gramsPerKilogram = 1000
+
+// IsWeighted сообщает, продаётся ли продукт на вес. Цена весового продукта указывается за килограмм.
+func (u Unit) IsWeighted() bool {
+	return u == UnitKilogram
+}
+
+// PriceByWeight рассчитывает стоимость весового продукта по цене за килограмм и показанию весов в граммах.
+// Сумма округляется до минимальной единицы валюты по арифметическим правилам (половина — вверх),
+// при этом положительный вес стоит не меньше одной минимальной единицы.
+// Возвращает ошибку, если вес не положителен или превышает MaxScaleGrams.
+func PriceByWeight(pricePerKg Money, grams int64) (Money, error) {
+	if grams <= 0 || grams > MaxScaleGrams {
+		return Money{}, e.ErrInvalidWeight
+	}
+
+	if err := pricePerKg.Validate(); err != nil {
+		return Money{}, err
+	}
+
+	// Переполнения нет: цена не больше 10^12 минимальных единиц, вес не больше 10^5 граммов
+	amount := (pricePerKg.Amount*grams + gramsPerKilogram/2) / gramsPerKilogram
+	if amount == 0 {
+		amount = 1
+	}
+
+	return NewMoney(amount, pricePerKg.Currency), nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+func TestPriceByWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		pricePerKg Money
+		grams      int64
+		want       Money
+		err        error
+	}{
+		{"whole kilogram", NewMoney(50000, CurrencyRUB), 1000, NewMoney(50000, CurrencyRUB), nil},
+		{"rounds half up", NewMoney(12345, CurrencyRUB), 500, NewMoney(6173, CurrencyRUB), nil},
+		{"rounds above half up", NewMoney(12345, CurrencyRUB), 333, NewMoney(4111, CurrencyRUB), nil},
+		{"rounds below half down", NewMoney(12345, CurrencyRUB), 334, NewMoney(4123, CurrencyRUB), nil},
+		{"positive weight costs at least one unit", NewMoney(100, CurrencyRUB), 1, NewMoney(1, CurrencyRUB), nil},
+		{"zero exponent currency", NewMoney(1999, CurrencyJPY), 250, NewMoney(500, CurrencyJPY), nil},
+		{"max scale reading", NewMoney(100_000_000_000, CurrencyRUB), MaxScaleGrams, NewMoney(10_000_000_000_000, CurrencyRUB), nil},
+		{"zero weight", NewMoney(50000, CurrencyRUB), 0, Money{}, e.ErrInvalidWeight},
+		{"negative weight", NewMoney(50000, CurrencyRUB), -5, Money{}, e.ErrInvalidWeight},
+		{"above max scale reading", NewMoney(50000, CurrencyRUB), MaxScaleGrams + 1, Money{}, e.ErrInvalidWeight},
+		{"zero price", NewMoney(0, CurrencyRUB), 500, Money{}, e.ErrPriceMustBePositive},
+		{"unsupported currency", NewMoney(100, Currency("XXX")), 500, Money{}, e.ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PriceByWeight(tt.pricePerKg, tt.grams)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("PriceByWeight() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("PriceByWeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -166,7 +167,7 @@ func (w *OutboxWorker) processBatch(ctx context.Context) (bool, error) {
 }
 
 func (w *OutboxWorker) processEvent(ctx context.Context, event *usecase.OutboxEvent) error {
-	if err := w.SendBytes(ctx, event.ProductID, event.Payload); err != nil {
+	if err := w.SendBytes(ctx, event); err != nil {
 		// Добавляем retry логику для временных ошибок
 		if isRetryableError(err) {
 			return e.Wrap("Temporary Kafka failure, will retry", err)
@@ -176,8 +177,15 @@ func (w *OutboxWorker) processEvent(ctx context.Context, event *usecase.OutboxEv
 	return nil
 }
 
-func (w *OutboxWorker) SendBytes(ctx context.Context, productID int64, payload []byte) error {
-	return w.producer.WriteRawMessage(ctx, usecase.NewWriteRawMessageReq(productID, payload))
+// SendBytes отправляет событие outbox. События продукта и изменения остатков отправляются с ключом ID продукта,
+// чтобы сохранить их порядок, остальные события — с ключом ID события.
+func (w *OutboxWorker) SendBytes(ctx context.Context, event *usecase.OutboxEvent) error {
+	key := event.EventID.String()
+	if event.EventType == usecase.ProductEvent || event.EventType == usecase.StockEvent {
+		key = strconv.FormatInt(event.ProductID, 10)
+	}
+
+	return w.producer.WriteRawMessage(ctx, usecase.NewWriteRawMessageReq(event.EventType, key, event.Payload))
 }
 
 func isRetryableError(err error) bool {
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -19,15 +20,27 @@ import (
 )
 
 type Producer struct {
-	writer *kafka.Writer
-	logger logger.Logger
-	cfg    *cfg.KafkaCfg
+	writer          *kafka.Writer
+	analyticsWriter *kafka.Writer // пишет аналитические события, которые не читают потребители изменений продуктов
+	stockWriter     *kafka.Writer // пишет изменения остатков товаров в магазинах
+	logger          logger.Logger
+	cfg             *cfg.KafkaCfg
 }
 
 func NewProducer(logger logger.Logger, cfg *cfg.KafkaCfg) (*Producer, error) {
-	writer := &kafka.Writer{
+	return &Producer{
+		writer:          newWriter(logger, cfg, cfg.Topic),
+		analyticsWriter: newWriter(logger, cfg, cfg.AnalyticsTopic),
+		stockWriter:     newWriter(logger, cfg, cfg.StockTopic),
+		logger:          logger,
+		cfg:             cfg,
+	}, nil
+}
+
+func newWriter(logger logger.Logger, cfg *cfg.KafkaCfg, topic string) *kafka.Writer {
+	return &kafka.Writer{
 		Addr:         kafka.TCP(cfg.Brokers...),
-		Topic:        cfg.Topic,
+		Topic:        topic,
 		Balancer:     &kafka.Hash{},
 		RequiredAcks: kafka.RequireOne,
 		BatchSize:    10,
@@ -39,12 +52,6 @@ func NewProducer(logger logger.Logger, cfg *cfg.KafkaCfg) (*Producer, error) {
 			}
 		},
 	}
-
-	return &Producer{
-		writer: writer,
-		logger: logger,
-		cfg:    cfg,
-	}, nil
 }
 
 func (p *Producer) WriteMessage(ctx context.Context, req *usecase.WriteMessageReq) error {
@@ -59,21 +66,43 @@ func (p *Producer) WriteMessage(ctx context.Context, req *usecase.WriteMessageRe
 	})
 }
 
+// WriteRawMessage отправляет сохранённое в outbox событие в топик, соответствующий его типу.
 func (p *Producer) WriteRawMessage(ctx context.Context, req *usecase.WriteRawMessageReq) error {
-	return p.writer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(strconv.FormatInt(req.ProductID, 10)),
+	var writer *kafka.Writer
+	switch req.EventType {
+	case usecase.ProductEvent:
+		writer = p.writer
+	case usecase.StockEvent:
+		writer = p.stockWriter
+	default:
+		writer = p.analyticsWriter
+	}
+
+	return writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(req.Key),
 		Value: req.Payload,
 	})
 }
 
+// EnsureTopic создаёт топики изменений продуктов, аналитических событий и изменений остатков, если они отсутствуют.
 func (p *Producer) EnsureTopic(timeout time.Duration) error {
+	for _, topic := range []string{p.cfg.Topic, p.cfg.AnalyticsTopic, p.cfg.StockTopic} {
+		if err := p.ensureTopic(topic, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Producer) ensureTopic(topic string, timeout time.Duration) error {
 	conn, err := kafka.Dial(p.cfg.NetworkMode, p.cfg.Brokers[0])
 	if err != nil {
 		return e.Wrap(whereami.WhereAmI(), err)
 	}
 	defer conn.Close()
 
-	partitions, err := conn.ReadPartitions(p.cfg.Topic)
+	partitions, err := conn.ReadPartitions(topic)
 	if err == nil && len(partitions) > 0 {
 		return nil
 	}
@@ -81,7 +110,7 @@ func (p *Producer) EnsureTopic(timeout time.Duration) error {
 	done := make(chan error, 1)
 	go func() {
 		err := conn.CreateTopics(kafka.TopicConfig{
-			Topic:             p.cfg.Topic,
+			Topic:             topic,
 			NumPartitions:     p.cfg.Partitions,
 			ReplicationFactor: p.cfg.ReplicationFactor,
 		})
@@ -91,34 +120,181 @@ func (p *Producer) EnsureTopic(timeout time.Duration) error {
 	select {
 	case err := <-done:
 		if err != nil {
-			return e.Wrap(whereami.WhereAmI(), fmt.Errorf("failed to create topic %s: %w", p.cfg.Topic, err))
+			return e.Wrap(whereami.WhereAmI(), fmt.Errorf("failed to create topic %s: %w", topic, err))
 		}
 		return nil
 	case <-time.After(timeout):
 		_ = conn.Close()
-		return e.Wrap(whereami.WhereAmI(), fmt.Errorf("timeout: %v, topic: %s", timeout, p.cfg.Topic))
+		return e.Wrap(whereami.WhereAmI(), fmt.Errorf("timeout: %v, topic: %s", timeout, topic))
 	}
 }
 
 func (p *Producer) Close() error {
-	return p.writer.Close()
+	return errors.Join(p.writer.Close(), p.analyticsWriter.Close(), p.stockWriter.Close())
 }
 
+// GetPayloadBytes сериализует ProductChangeEvent с операцией, соответствующей req.Operation.
+// Версия продукта позволяет потребителям отбрасывать события, пришедшие не по порядку.
 func (p *Producer) GetPayloadBytes(req *usecase.WriteMessageReq) ([]byte, error) {
-	protoEmbeddings, err := toArrProtoEmbeddings(req.Embeddings)
-	if err != nil {
-		return nil, e.Wrap(whereami.WhereAmI(), err)
-	}
-
 	event := &drsnProto.ProductChangeEvent{
 		EventId:        uuid.NewString(),
 		EventTimestamp: time.Now().UnixNano(),
-		Operation: &drsnProto.ProductChangeEvent_Upsert{
+		Version:        req.Version,
+	}
+
+	switch req.Operation {
+	case usecase.OperationUpsert:
+		protoEmbeddings, err := toArrProtoEmbeddings(req.Embeddings)
+		if err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+
+		event.Operation = &drsnProto.ProductChangeEvent_Upsert{
 			Upsert: &drsnProto.UpsertEvent{
 				ProductId:  req.ProductID,
 				Embeddings: protoEmbeddings,
 			},
-		},
+		}
+	case usecase.OperationUpdate:
+		event.Operation = &drsnProto.ProductChangeEvent_Update{
+			Update: &drsnProto.UpdateEvent{
+				ProductId:    req.ProductID,
+				Name:         req.Product.Product.Name,
+				Price:        req.Product.Product.Price.Amount,
+				Currency:     string(req.Product.Product.Price.Currency),
+				CategoryId:   req.Product.Product.CategoryID,
+				CategoryName: req.Product.CategoryName,
+			},
+		}
+	case usecase.OperationArchive, usecase.OperationUnarchive:
+		event.Operation = &drsnProto.ProductChangeEvent_Archive{
+			Archive: &drsnProto.ArchiveEvent{
+				ProductId: req.ProductID,
+				Archived:  req.Operation == usecase.OperationArchive,
+			},
+		}
+	case usecase.OperationDelete:
+		event.Operation = &drsnProto.ProductChangeEvent_Delete{
+			Delete: &drsnProto.DeleteEvent{
+				ProductId:    req.ProductID,
+				EmbeddingIds: toEmbeddingIDs(req.Embeddings),
+			},
+		}
+	case usecase.OperationDeleteImages:
+		event.Operation = &drsnProto.ProductChangeEvent_DeleteEmbeddings{
+			DeleteEmbeddings: &drsnProto.DeleteEmbeddingsEvent{
+				ProductId:    req.ProductID,
+				EmbeddingIds: toEmbeddingIDs(req.Embeddings),
+			},
+		}
+	case usecase.OperationStoreAssortment:
+		assortment := &drsnProto.StoreAssortmentEvent{
+			ProductId:    req.ProductID,
+			StoreId:      req.Assortment.Product.StoreID,
+			InAssortment: !req.Assortment.Removed,
+			Available:    req.Assortment.Product.IsAvailable,
+		}
+		// Нулевая цена означает, что в магазине действует общая цена продукта
+		if price := req.Assortment.Product.Price; price != nil {
+			assortment.Price = price.Amount
+			assortment.Currency = string(price.Currency)
+		}
+
+		event.Operation = &drsnProto.ProductChangeEvent_StoreAssortment{StoreAssortment: assortment}
+	case usecase.OperationStatusChange:
+		// Пустые reviewer и comment означают переход без проверяющего или без комментария
+		statusChange := &drsnProto.StatusChangeEvent{
+			ProductId:  req.ProductID,
+			FromStatus: string(req.Transition.FromStatus),
+			ToStatus:   string(req.Transition.ToStatus),
+		}
+		if req.Transition.Reviewer != nil {
+			statusChange.Reviewer = *req.Transition.Reviewer
+		}
+		if req.Transition.Comment != nil {
+			statusChange.Comment = *req.Transition.Comment
+		}
+
+		event.Operation = &drsnProto.ProductChangeEvent_StatusChange{StatusChange: statusChange}
+	case usecase.OperationMerge:
+		event.Operation = &drsnProto.ProductChangeEvent_Merge{
+			Merge: &drsnProto.MergeEvent{
+				ProductId:       req.ProductID,
+				TargetProductId: req.TargetID,
+				EmbeddingIds:    toEmbeddingIDs(req.Embeddings),
+			},
+		}
+	default:
+		return nil, e.Wrap(whereami.WhereAmI(), fmt.Errorf("unknown product operation: %q", req.Operation))
+	}
+
+	return proto.Marshal(event)
+}
+
+// GetCheckoutPayloadBytes сериализует CheckoutCompletedEvent оформленной корзины для аналитики.
+// Событие содержит способ определения каждого товара, чтобы оценивать качество распознавания.
+func (p *Producer) GetCheckoutPayloadBytes(req *usecase.CheckoutCompletedMessageReq) ([]byte, error) {
+	session := req.Checkout.Session
+	event := &drsnProto.CheckoutCompletedEvent{
+		EventId:        req.EventID.String(),
+		EventTimestamp: time.Now().UnixNano(),
+		SessionId:      session.ID,
+		Total:          req.Checkout.Total.Amount,
+		Currency:       string(req.Checkout.Total.Currency),
+		Lines:          make([]*drsnProto.CheckoutLineEvent, 0, len(req.Checkout.Lines)),
+	}
+
+	if session.CompletedAt != nil {
+		event.CompletedAt = session.CompletedAt.UnixNano()
+	}
+
+	// Нулевой store_id означает корзину без магазина
+	if session.StoreID != nil {
+		event.StoreId = *session.StoreID
+	}
+
+	for _, line := range req.Checkout.Lines {
+		lineEvent := &drsnProto.CheckoutLineEvent{
+			LineId:      line.Line.ID,
+			ProductId:   line.Line.ProductID,
+			Quantity:    line.Line.Quantity,
+			WeightGrams: line.Line.WeightGrams,
+			Total:       line.Total.Amount,
+			Method:      string(line.Line.Method),
+		}
+		if line.Line.Barcode != nil {
+			lineEvent.Barcode = *line.Line.Barcode
+		}
+		if line.Line.RecognitionScore != nil {
+			lineEvent.RecognitionScore = *line.Line.RecognitionScore
+		}
+		if line.Line.ModelVersion != nil {
+			lineEvent.ModelVersion = *line.Line.ModelVersion
+		}
+
+		event.Lines = append(event.Lines, lineEvent)
+	}
+
+	return proto.Marshal(event)
+}
+
+// GetStockPayloadBytes сериализует StockChangedEvent с остатком товара в магазине после изменения.
+func (p *Producer) GetStockPayloadBytes(req *usecase.StockChangedMessageReq) ([]byte, error) {
+	level := req.Change.Level
+	event := &drsnProto.StockChangedEvent{
+		EventId:        req.EventID.String(),
+		EventTimestamp: time.Now().UnixNano(),
+		StoreId:        level.StoreID,
+		ProductId:      level.ProductID,
+		OnHand:         level.OnHand,
+		Reserved:       level.Reserved,
+		Available:      level.Available(),
+		Delta:          req.Change.Delta,
+		Reason:         string(req.Change.Reason),
+	}
+
+	if req.Change.ReservationID != nil {
+		event.ReservationId = req.Change.ReservationID.String()
 	}
 
 	return proto.Marshal(event)
@@ -172,3 +348,12 @@ func toArrProtoEmbeddings(embeddings []domain.Embedding) ([]*drsnProto.Embedding
 
 	return result, nil
 }
+
+func toEmbeddingIDs(embeddings []domain.Embedding) []string {
+	ids := make([]string, 0, len(embeddings))
+	for _, embedding := range embeddings {
+		ids = append(ids, embedding.ID)
+	}
+
+	return ids
+}
@@ -2,6 +2,8 @@ package minio
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
@@ -16,6 +18,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// uploadedImage — загруженное изображение и его позиция в запросе.
+type uploadedImage struct {
+	index int
+	image usecase.UploadedImage
+}
+
 // MinioInfrastructure управляет загрузкой и очисткой изображений в MinIO.
 type MinioInfrastructure struct {
 	minioRepo usecase.ImageRepository
@@ -41,12 +49,12 @@ func (m *MinioInfrastructure) UploadImages(ctx context.Context, req *usecase.Upl
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	keyCh := make(chan string, len(req.Images))
+	imageCh := make(chan uploadedImage, len(req.Images))
 	errCh := make(chan error, len(req.Images))
 	sem := make(chan struct{}, m.cfg.UploadImagesLimit)
 
 	var uploadWg sync.WaitGroup
-	for _, image := range req.Images {
+	for i, image := range req.Images {
 		uploadWg.Add(1)
 		go func() {
 			defer uploadWg.Done()
@@ -68,30 +76,37 @@ func (m *MinioInfrastructure) UploadImages(ctx context.Context, req *usecase.Upl
 				return
 			}
 
-			keyCh <- key
+			sum := sha256.Sum256(image.Data)
+			imageCh <- uploadedImage{
+				index: i,
+				image: *usecase.NewUploadedImage(imageID, key, image.MimeType, image.Size, hex.EncodeToString(sum[:])),
+			}
 		}()
 	}
 
 	go func() {
 		uploadWg.Wait()
 		close(errCh)
-		close(keyCh)
+		close(imageCh)
 	}()
 
-	keys := make([]string, 0, len(req.Images))
+	// Изображения сохраняются в порядке изображений запроса
+	images := make([]usecase.UploadedImage, len(req.Images))
+	uploaded := make([]string, 0, len(req.Images))
 	ok := false
 	defer func() {
-		if !ok && len(keys) > 0 {
+		if !ok && len(uploaded) > 0 {
 			m.wg.Add(1)
-			go m.cleanupUploadedKeys(keys)
+			go m.cleanupUploadedKeys(uploaded)
 		}
 	}()
 
 	for completed := 0; completed < len(req.Images); {
 		select {
-		case key, ok := <-keyCh:
+		case res, ok := <-imageCh:
 			if ok {
-				keys = append(keys, key)
+				images[res.index] = res.image
+				uploaded = append(uploaded, res.image.Key)
 				completed++
 			}
 		case err, ok := <-errCh:
@@ -106,7 +121,7 @@ func (m *MinioInfrastructure) UploadImages(ctx context.Context, req *usecase.Upl
 	}
 
 	ok = true
-	return usecase.NewUploadImagesRes(keys), nil
+	return usecase.NewUploadImagesRes(images), nil
 }
 
 // CleanupImages запускает фоновую очистку указанных ключей MinIO
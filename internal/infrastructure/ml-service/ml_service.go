@@ -66,16 +66,22 @@ func (m *MLService) VectorizeRequest(ctx context.Context, req *usecase.Vectorize
 	return nil, e.Wrap(whereami.WhereAmI(), fmt.Errorf("unreachable"))
 }
 
+// indexedVector — результат векторизации изображения и его позиция в запросе.
+type indexedVector struct {
+	index int
+	res   usecase.VectorizeRes
+}
+
 // vectorizeBatch отправляет батч изображений на векторизацию параллельно с ограничением конкурентности
 func (m *MLService) vectorizeBatch(ctx context.Context, req *usecase.VectorizeReq) ([]usecase.VectorizeRes, error) {
 	const op = "MLService.vectorizeBatch"
 
-	vectorCh := make(chan usecase.VectorizeRes, len(req.Images))
+	vectorCh := make(chan indexedVector, len(req.Images))
 	errCh := make(chan error, len(req.Images))
 	sem := make(chan struct{}, m.cfg.MaxConcurrent)
 
 	var wg sync.WaitGroup
-	for _, image := range req.Images {
+	for i, image := range req.Images {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -101,7 +107,7 @@ func (m *MLService) vectorizeBatch(ctx context.Context, req *usecase.VectorizeRe
 				return
 			}
 
-			vectorCh <- *usecase.NewVectorizeRes(res.Vector, res.ModelVersion)
+			vectorCh <- indexedVector{index: i, res: *usecase.NewVectorizeRes(res.Vector, res.ModelVersion)}
 		}()
 	}
 
@@ -111,12 +117,13 @@ func (m *MLService) vectorizeBatch(ctx context.Context, req *usecase.VectorizeRe
 		close(vectorCh)
 	}()
 
-	vectors := make([]usecase.VectorizeRes, 0, len(req.Images))
+	// Векторы сохраняются в порядке изображений запроса
+	vectors := make([]usecase.VectorizeRes, len(req.Images))
 	for completed := 0; completed < len(req.Images); {
 		select {
 		case vector, ok := <-vectorCh:
 			if ok {
-				vectors = append(vectors, vector)
+				vectors[vector.index] = vector.res
 				completed++
 			}
 		case err, ok := <-errCh:
@@ -0,0 +1,76 @@
+package pricing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+)
+
+// PriceWorker периодически применяет запланированные цены, время действия которых наступило.
+type PriceWorker struct {
+	prUC   usecase.ProductUC
+	logger logger.Logger
+	cfg    *cfg.PricingCfg
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewPriceWorker(prUC usecase.ProductUC, logger logger.Logger, cfg *cfg.PricingCfg) *PriceWorker {
+	return &PriceWorker{
+		prUC:   prUC,
+		logger: logger,
+		cfg:    cfg,
+		stop:   make(chan struct{}),
+	}
+}
+
+func (w *PriceWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.run(ctx)
+	}()
+}
+
+func (w *PriceWorker) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+func (w *PriceWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.SchedulerInterval)
+	defer ticker.Stop()
+
+	// Цены, наступившие за время простоя, применяются сразу при старте
+	w.applyDuePrices(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.applyDuePrices(ctx)
+		}
+	}
+}
+
+// applyDuePrices применяет наступившие цены пачками, пока они не закончатся.
+func (w *PriceWorker) applyDuePrices(ctx context.Context) {
+	for {
+		applied, err := w.prUC.ApplyDuePrices(ctx, w.cfg.SchedulerBatchSize)
+		if err != nil {
+			w.logger.Warnf("Failed to apply scheduled prices: %v", err)
+			return
+		}
+
+		if applied < w.cfg.SchedulerBatchSize {
+			return
+		}
+	}
+}
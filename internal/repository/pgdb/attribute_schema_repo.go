@@ -0,0 +1,94 @@
+package pgdb
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+const attributeSchemaColumns = `category_id, attributes, created_at, updated_at`
+
+// AttributeSchemaRepo реализует хранение схем атрибутов категорий поверх PostgreSQL.
+// Определения атрибутов схемы хранятся JSONB-массивом.
+type AttributeSchemaRepo struct {
+	pool *pgxpool.Pool
+	conv converter.AttributeSchemaConverter
+}
+
+func NewAttributeSchemaRepo(pool *pgxpool.Pool, conv converter.AttributeSchemaConverter) *AttributeSchemaRepo {
+	return &AttributeSchemaRepo{pool: pool, conv: conv}
+}
+
+// ListForCategory возвращает схемы категории и её предков в порядке от корня дерева к категории.
+// Категории без собственной схемы пропускаются.
+func (a *AttributeSchemaRepo) ListForCategory(ctx context.Context, categoryID int64) ([]*domain.AttributeSchema, error) {
+	query := `
+		WITH RECURSIVE path AS (
+			SELECT id, parent_id, 0 AS depth
+			FROM categories
+			WHERE id = $1
+			UNION ALL
+			SELECT cat.id, cat.parent_id, path.depth + 1
+			FROM categories cat
+			JOIN path ON cat.id = path.parent_id
+		)
+		SELECT s.category_id, s.attributes, s.created_at, s.updated_at
+		FROM path
+		JOIN category_attribute_schemas s ON s.category_id = path.id
+		ORDER BY path.depth DESC
+	`
+
+	rows, err := a.pool.Query(ctx, query, categoryID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.AttributeSchemaModel, 0)
+	for rows.Next() {
+		var model converter.AttributeSchemaModel
+		if err := scanAttributeSchema(rows, &model); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return a.conv.ToArrEntity(models), nil
+}
+
+// Set создаёт или заменяет схему атрибутов категории.
+func (a *AttributeSchemaRepo) Set(ctx context.Context, schema *domain.AttributeSchema) (*domain.AttributeSchema, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO category_attribute_schemas (category_id, attributes)
+		VALUES ($1, $2)
+		ON CONFLICT (category_id) DO UPDATE
+		SET attributes = EXCLUDED.attributes,
+			updated_at = NOW()
+		RETURNING ` + attributeSchemaColumns
+
+	model := a.conv.ToModel(schema)
+	if err := scanAttributeSchema(tx.QueryRow(ctx, query, model.CategoryID, model.Attributes), model); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return a.conv.ToEntity(model), nil
+}
+
+func scanAttributeSchema(row pgx.Row, model *converter.AttributeSchemaModel) error {
+	return row.Scan(&model.CategoryID, &model.Attributes, &model.CreatedAt, &model.UpdatedAt)
+}
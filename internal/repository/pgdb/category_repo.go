@@ -2,15 +2,25 @@ package pgdb
 
 import (
 	"context"
+	"errors"
 
 	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/e"
 	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jimlawless/whereami"
 )
 
+const (
+	categoryParentConstraint = "fk_categories_parent"
+
+	// categoryTreeLockKey — ключ advisory-блокировки структуры дерева категорий
+	categoryTreeLockKey int64 = 0x63617465676f7279
+)
+
 // CategoryRepo реализует репозиторий категорий поверх PostgreSQL.
 type CategoryRepo struct {
 	pool *pgxpool.Pool
@@ -22,6 +32,7 @@ func NewCategoryRepo(pool *pgxpool.Pool, conv converter.CategoryConverter) *Cate
 }
 
 // Create идемпотентно создаёт категорию по имени, игнорируя дубликаты.
+// Для существующей категории возвращается её текущий родитель.
 func (c *CategoryRepo) Create(ctx context.Context, category *domain.Category) (*domain.Category, error) {
 	tx, err := tr.TxFromCtx(ctx)
 	if err != nil {
@@ -30,27 +41,361 @@ func (c *CategoryRepo) Create(ctx context.Context, category *domain.Category) (*
 
 	query := `
 		WITH ins AS (
-			INSERT INTO categories (name)
-			VALUES ($1)
+			INSERT INTO categories (name, parent_id)
+			VALUES ($1, $2)
 			ON CONFLICT (name) DO NOTHING
-			RETURNING id, name, created_at, updated_at, is_archived
+			RETURNING id, name, parent_id, created_at, updated_at, is_archived
 		)
-		SELECT id, name, created_at, updated_at, is_archived
+		SELECT id, name, parent_id, created_at, updated_at, is_archived
 		FROM ins
 		UNION ALL
-		SELECT id, name, created_at, updated_at, is_archived
+		SELECT id, name, parent_id, created_at, updated_at, is_archived
 		FROM categories
 		WHERE name = $1
 		  AND NOT EXISTS (SELECT 1 FROM ins);
 	`
 
 	var model converter.CategoryModel
-	if err := tx.QueryRow(ctx, query, category.Name).
+	if err := tx.QueryRow(ctx, query, category.Name, category.ParentID).
+		Scan(
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+		); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return c.conv.ToEntity(&model), nil
+}
+
+// GetByID возвращает категорию по ID с путём от корня дерева и количеством неархивных продуктов в ней.
+func (c *CategoryRepo) GetByID(ctx context.Context, id int64) (*usecase.CategoryDetails, error) {
+	query := `
+		WITH RECURSIVE path AS (
+			SELECT id, parent_id, ARRAY[name::text] AS names
+			FROM categories
+			WHERE id = $1
+			UNION ALL
+			SELECT cat.id, cat.parent_id, cat.name::text || path.names
+			FROM path
+			JOIN categories cat ON cat.id = path.parent_id
+		)
+		SELECT
+			c.id, c.name, c.parent_id, c.created_at, c.updated_at, c.is_archived,
+			(SELECT names FROM path WHERE parent_id IS NULL),
+			(SELECT COUNT(*) FROM products pr WHERE pr.category_id = c.id AND NOT pr.is_archived)
+		FROM categories c
+		WHERE c.id = $1
+	`
+
+	var (
+		model converter.CategoryModel
+		path  []string
+		count int64
+	)
+	if err := c.pool.QueryRow(ctx, query, id).
+		Scan(
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+			&path, &count,
+		); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return usecase.NewCategoryDetails(c.conv.ToEntity(&model), path, count), nil
+}
+
+// List возвращает категории в порядке обхода дерева с путём от корня и количеством неархивных продуктов.
+// При archived == nil возвращаются все категории.
+func (c *CategoryRepo) List(ctx context.Context, archived *bool) ([]usecase.CategoryDetails, error) {
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id, ARRAY[name::text] AS names
+			FROM categories
+			WHERE parent_id IS NULL
+			UNION ALL
+			SELECT cat.id, tree.names || cat.name::text
+			FROM categories cat
+			JOIN tree ON cat.parent_id = tree.id
+		)
+		SELECT
+			c.id, c.name, c.parent_id, c.created_at, c.updated_at, c.is_archived,
+			tree.names,
+			(SELECT COUNT(*) FROM products pr WHERE pr.category_id = c.id AND NOT pr.is_archived)
+		FROM categories c
+		JOIN tree ON tree.id = c.id
+		WHERE $1::boolean IS NULL OR c.is_archived = $1
+		ORDER BY tree.names, c.id
+	`
+
+	rows, err := c.pool.Query(ctx, query, archived)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	res := make([]usecase.CategoryDetails, 0)
+	for rows.Next() {
+		var (
+			model converter.CategoryModel
+			path  []string
+			count int64
+		)
+		if err := rows.Scan(
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+			&path, &count,
+		); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+
+		res = append(res, *usecase.NewCategoryDetails(c.conv.ToEntity(&model), path, count))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return res, nil
+}
+
+// GetForUpdate возвращает категорию, блокируя её запись до конца текущей транзакции.
+func (c *CategoryRepo) GetForUpdate(ctx context.Context, id int64) (*domain.Category, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		SELECT id, name, parent_id, created_at, updated_at, is_archived
+		FROM categories
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var model converter.CategoryModel
+	if err := tx.QueryRow(ctx, query, id).
+		Scan(
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+		); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return c.conv.ToEntity(&model), nil
+}
+
+// Rename изменяет название категории в рамках текущей транзакции.
+func (c *CategoryRepo) Rename(ctx context.Context, id int64, name string) (*domain.Category, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		UPDATE categories
+		SET name = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, name, parent_id, created_at, updated_at, is_archived
+	`
+
+	var model converter.CategoryModel
+	if err := tx.QueryRow(ctx, query, id, name).
+		Scan(
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+		); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+		}
+		if postgresDuplicate(err) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNameTaken)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return c.conv.ToEntity(&model), nil
+}
+
+// SetArchived архивирует или восстанавливает категорию в рамках текущей транзакции.
+func (c *CategoryRepo) SetArchived(ctx context.Context, id int64, archived bool) (*domain.Category, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		UPDATE categories
+		SET is_archived = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, name, parent_id, created_at, updated_at, is_archived
+	`
+
+	var model converter.CategoryModel
+	if err := tx.QueryRow(ctx, query, id, archived).
+		Scan(
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+		); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return c.conv.ToEntity(&model), nil
+}
+
+// Move переносит категорию к новому родителю в рамках текущей транзакции. parentID == nil делает категорию корневой.
+func (c *CategoryRepo) Move(ctx context.Context, id int64, parentID *int64) (*domain.Category, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		UPDATE categories
+		SET parent_id = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, name, parent_id, created_at, updated_at, is_archived
+	`
+
+	var model converter.CategoryModel
+	if err := tx.QueryRow(ctx, query, id, parentID).
 		Scan(
-			&model.ID, &model.Name, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
+			&model.ID, &model.Name, &model.ParentID, &model.CreatedAt, &model.UpdatedAt, &model.IsArchived,
 		); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+		}
 		return nil, e.Wrap(whereami.WhereAmI(), err)
 	}
 
 	return c.conv.ToEntity(&model), nil
 }
+
+// Delete удаляет категорию в рамках текущей транзакции.
+// Категорию, на которую ссылаются продукты (в т.ч. архивные) или дочерние категории, удалить нельзя.
+func (c *CategoryRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `DELETE FROM categories WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		if constraint, ok := postgresForeignKeyViolation(err); ok {
+			if constraint == categoryParentConstraint {
+				return e.Wrap(whereami.WhereAmI(), e.ErrCategoryHasChildren)
+			}
+			return e.Wrap(whereami.WhereAmI(), e.ErrCategoryHasProducts)
+		}
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+	}
+
+	return nil
+}
+
+// CountActiveProducts возвращает количество неархивных продуктов категории в рамках текущей транзакции.
+func (c *CategoryRepo) CountActiveProducts(ctx context.Context, id int64) (int64, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM products
+		WHERE category_id = $1 AND NOT is_archived
+	`
+
+	var count int64
+	if err := tx.QueryRow(ctx, query, id).Scan(&count); err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return count, nil
+}
+
+// CountActiveChildren возвращает количество неархивных дочерних категорий в рамках текущей транзакции.
+func (c *CategoryRepo) CountActiveChildren(ctx context.Context, id int64) (int64, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM categories
+		WHERE parent_id = $1 AND NOT is_archived
+	`
+
+	var count int64
+	if err := tx.QueryRow(ctx, query, id).Scan(&count); err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return count, nil
+}
+
+// SubtreeIDs возвращает ID категории и всех её потомков в рамках текущей транзакции.
+func (c *CategoryRepo) SubtreeIDs(ctx context.Context, id int64) ([]int64, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	rows, err := tx.Query(ctx, categorySubtreeQuery("$1"), id)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return ids, nil
+}
+
+// SubtreeProductIDs возвращает ID неархивных продуктов категории и всех её потомков.
+func (c *CategoryRepo) SubtreeProductIDs(ctx context.Context, id int64) ([]int64, error) {
+	query := `
+		SELECT id
+		FROM products
+		WHERE category_id IN (` + categorySubtreeQuery("$1") + `)
+		  AND NOT is_archived
+	`
+
+	rows, err := c.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return ids, nil
+}
+
+// LockTree блокирует изменение структуры дерева категорий до конца текущей транзакции.
+// Без блокировки два параллельных переноса могут образовать цикл.
+func (c *CategoryRepo) LockTree(ctx context.Context) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, categoryTreeLockKey); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
@@ -13,10 +13,66 @@ import (
 // goverter:extend ConvertTime
 // goverter:extend ConvertPointerTime
 type ProductConverter interface {
+	// goverter:map Price.Amount Price
+	// goverter:map Price.Currency Currency
 	ToModel(entity *domain.Product) *ProductModel
+	// goverter:map . Price | ProductModelToMoney
 	ToEntity(model *ProductModel) *domain.Product
 }
 
+// ProductPriceConverter преобразует сущности ProductPrice между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type ProductPriceConverter interface {
+	// goverter:map Price.Amount Price
+	// goverter:map Price.Currency Currency
+	ToModel(entity *domain.ProductPrice) *ProductPriceModel
+	// goverter:map . Price | ProductPriceModelToMoney
+	ToEntity(model *ProductPriceModel) *domain.ProductPrice
+	ToArrEntity(models []*ProductPriceModel) []*domain.ProductPrice
+}
+
+// PromotionConverter преобразует сущности Promotion между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type PromotionConverter interface {
+	// goverter:map Amount Amount | MoneyToPointerAmount
+	// goverter:map Amount Currency | MoneyToPointerCurrency
+	ToModel(entity *domain.Promotion) *PromotionModel
+	// goverter:map . Amount | PromotionModelToMoney
+	ToEntity(model *PromotionModel) *domain.Promotion
+	ToArrEntity(models []*PromotionModel) []*domain.Promotion
+}
+
+// StoreConverter преобразует сущности Store и StoreProduct между domain и моделями PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type StoreConverter interface {
+	ToModel(entity *domain.Store) *StoreModel
+	ToEntity(model *StoreModel) *domain.Store
+	ToArrEntity(models []*StoreModel) []*domain.Store
+	// goverter:map Price Price | MoneyToPointerAmount
+	// goverter:map Price Currency | MoneyToPointerCurrency
+	ToProductModel(entity *domain.StoreProduct) *StoreProductModel
+	// goverter:map . Price | StoreProductModelToMoney
+	ToProductEntity(model *StoreProductModel) *domain.StoreProduct
+	ToArrProductEntity(models []*StoreProductModel) []*domain.StoreProduct
+}
+
+// StockConverter преобразует остатки и резервы между domain и моделями PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type StockConverter interface {
+	ToLevelEntity(model *StockLevelModel) *domain.StockLevel
+	ToArrLevelEntity(models []*StockLevelModel) []*domain.StockLevel
+	ToReservationModel(entity *domain.StockReservation) *StockReservationModel
+	ToReservationEntity(model *StockReservationModel) *domain.StockReservation
+}
+
 // CategoryConverter преобразует сущности Category между domain и моделью PostgreSQL.
 // goverter:converter
 // goverter:extend ConvertTime
@@ -26,6 +82,62 @@ type CategoryConverter interface {
 	ToEntity(model *CategoryModel) *domain.Category
 }
 
+// AttributeSchemaConverter преобразует схемы атрибутов категорий между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type AttributeSchemaConverter interface {
+	ToModel(entity *domain.AttributeSchema) *AttributeSchemaModel
+	ToEntity(model *AttributeSchemaModel) *domain.AttributeSchema
+	ToArrEntity(models []*AttributeSchemaModel) []*domain.AttributeSchema
+}
+
+// VariantGroupConverter преобразует группы вариантов между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type VariantGroupConverter interface {
+	ToModel(entity *domain.VariantGroup) *VariantGroupModel
+	ToEntity(model *VariantGroupModel) *domain.VariantGroup
+	ToArrEntity(models []*VariantGroupModel) []*domain.VariantGroup
+}
+
+// ProductRedirectConverter преобразует перенаправления слитых продуктов между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+type ProductRedirectConverter interface {
+	ToModel(entity *domain.ProductRedirect) *ProductRedirectModel
+	ToEntity(model *ProductRedirectModel) *domain.ProductRedirect
+}
+
+// ProductTransitionConverter преобразует записи истории статусов продукта между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+type ProductTransitionConverter interface {
+	ToModel(entity *domain.ProductTransition) *ProductTransitionModel
+	ToEntity(model *ProductTransitionModel) *domain.ProductTransition
+	ToArrEntity(models []*ProductTransitionModel) []*domain.ProductTransition
+}
+
+// DuplicateFlagConverter преобразует отметки вероятных дубликатов между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+type DuplicateFlagConverter interface {
+	ToModel(entity *domain.DuplicateFlag) *DuplicateFlagModel
+	ToEntity(model *DuplicateFlagModel) *domain.DuplicateFlag
+	ToArrEntity(models []*DuplicateFlagModel) []*domain.DuplicateFlag
+}
+
+// ImageMetaConverter преобразует сущности ImageMeta между domain и моделью PostgreSQL.
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type ImageMetaConverter interface {
+	ToModel(entity *domain.ImageMeta) *ImageMetaModel
+	ToEntity(model *ImageMetaModel) *domain.ImageMeta
+	ToArrEntity(models []*ImageMetaModel) []*domain.ImageMeta
+}
+
 // OutboxEventConverter преобразует сущности OutboxEvent между usecase и моделью PostgreSQL.
 // goverter:converter
 // goverter:extend ConvertTime
@@ -46,6 +158,51 @@ func ConvertTime(t time.Time) time.Time {
 	return t
 }
 
+func ProductModelToMoney(model ProductModel) domain.Money {
+	return domain.NewMoney(model.Price, domain.Currency(model.Currency))
+}
+
+func ProductPriceModelToMoney(model ProductPriceModel) domain.Money {
+	return domain.NewMoney(model.Price, domain.Currency(model.Currency))
+}
+
+// PromotionModelToMoney возвращает скидку фиксированной акции или nil для акций других видов.
+func PromotionModelToMoney(model PromotionModel) *domain.Money {
+	if model.Amount == nil || model.Currency == nil {
+		return nil
+	}
+
+	money := domain.NewMoney(*model.Amount, domain.Currency(*model.Currency))
+	return &money
+}
+
+// StoreProductModelToMoney возвращает цену продукта в магазине или nil, если действует общая цена.
+func StoreProductModelToMoney(model StoreProductModel) *domain.Money {
+	if model.Price == nil || model.Currency == nil {
+		return nil
+	}
+
+	money := domain.NewMoney(*model.Price, domain.Currency(*model.Currency))
+	return &money
+}
+
+func MoneyToPointerAmount(m *domain.Money) *int64 {
+	if m == nil {
+		return nil
+	}
+
+	return &m.Amount
+}
+
+func MoneyToPointerCurrency(m *domain.Money) *string {
+	if m == nil {
+		return nil
+	}
+
+	currency := string(m.Currency)
+	return &currency
+}
+
 func ConvertOutBoxStatus(s usecase.OutboxStatus) usecase.OutboxStatus {
 	return s
 }
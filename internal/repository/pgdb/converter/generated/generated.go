@@ -10,6 +10,83 @@ import (
 	uuid "github.com/google/uuid"
 )
 
+type AttributeSchemaConverterImpl struct{}
+
+func (c *AttributeSchemaConverterImpl) ToArrEntity(source []*converter.AttributeSchemaModel) []*domain.AttributeSchema {
+	var pDomainAttributeSchemaList []*domain.AttributeSchema
+	if source != nil {
+		pDomainAttributeSchemaList = make([]*domain.AttributeSchema, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainAttributeSchemaList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainAttributeSchemaList
+}
+func (c *AttributeSchemaConverterImpl) ToEntity(source *converter.AttributeSchemaModel) *domain.AttributeSchema {
+	var pDomainAttributeSchema *domain.AttributeSchema
+	if source != nil {
+		var domainAttributeSchema domain.AttributeSchema
+		domainAttributeSchema.CategoryID = (*source).CategoryID
+		if (*source).Attributes != nil {
+			domainAttributeSchema.Attributes = make([]domain.AttributeDef, len((*source).Attributes))
+			for i := 0; i < len((*source).Attributes); i++ {
+				domainAttributeSchema.Attributes[i] = c.converterAttributeDefModelToDomainAttributeDef((*source).Attributes[i])
+			}
+		}
+		domainAttributeSchema.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		domainAttributeSchema.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainAttributeSchema = &domainAttributeSchema
+	}
+	return pDomainAttributeSchema
+}
+func (c *AttributeSchemaConverterImpl) ToModel(source *domain.AttributeSchema) *converter.AttributeSchemaModel {
+	var pConverterAttributeSchemaModel *converter.AttributeSchemaModel
+	if source != nil {
+		var converterAttributeSchemaModel converter.AttributeSchemaModel
+		converterAttributeSchemaModel.CategoryID = (*source).CategoryID
+		if (*source).Attributes != nil {
+			converterAttributeSchemaModel.Attributes = make([]converter.AttributeDefModel, len((*source).Attributes))
+			for i := 0; i < len((*source).Attributes); i++ {
+				converterAttributeSchemaModel.Attributes[i] = c.domainAttributeDefToConverterAttributeDefModel((*source).Attributes[i])
+			}
+		}
+		converterAttributeSchemaModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		converterAttributeSchemaModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterAttributeSchemaModel = &converterAttributeSchemaModel
+	}
+	return pConverterAttributeSchemaModel
+}
+func (c *AttributeSchemaConverterImpl) converterAttributeDefModelToDomainAttributeDef(source converter.AttributeDefModel) domain.AttributeDef {
+	var domainAttributeDef domain.AttributeDef
+	domainAttributeDef.Code = source.Code
+	domainAttributeDef.Name = source.Name
+	domainAttributeDef.Type = domain.AttributeType(source.Type)
+	domainAttributeDef.Unit = source.Unit
+	if source.Values != nil {
+		domainAttributeDef.Values = make([]string, len(source.Values))
+		for i := 0; i < len(source.Values); i++ {
+			domainAttributeDef.Values[i] = source.Values[i]
+		}
+	}
+	domainAttributeDef.Required = source.Required
+	return domainAttributeDef
+}
+func (c *AttributeSchemaConverterImpl) domainAttributeDefToConverterAttributeDefModel(source domain.AttributeDef) converter.AttributeDefModel {
+	var converterAttributeDefModel converter.AttributeDefModel
+	converterAttributeDefModel.Code = source.Code
+	converterAttributeDefModel.Name = source.Name
+	converterAttributeDefModel.Type = string(source.Type)
+	converterAttributeDefModel.Unit = source.Unit
+	if source.Values != nil {
+		converterAttributeDefModel.Values = make([]string, len(source.Values))
+		for i := 0; i < len(source.Values); i++ {
+			converterAttributeDefModel.Values[i] = source.Values[i]
+		}
+	}
+	converterAttributeDefModel.Required = source.Required
+	return converterAttributeDefModel
+}
+
 type CategoryConverterImpl struct{}
 
 func (c *CategoryConverterImpl) ToEntity(source *converter.CategoryModel) *domain.Category {
@@ -18,6 +95,10 @@ func (c *CategoryConverterImpl) ToEntity(source *converter.CategoryModel) *domai
 		var domainCategory domain.Category
 		domainCategory.ID = (*source).ID
 		domainCategory.Name = (*source).Name
+		if (*source).ParentID != nil {
+			xint64 := *(*source).ParentID
+			domainCategory.ParentID = &xint64
+		}
 		domainCategory.CreatedAt = converter.ConvertTime((*source).CreatedAt)
 		domainCategory.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
 		domainCategory.IsArchived = (*source).IsArchived
@@ -31,6 +112,10 @@ func (c *CategoryConverterImpl) ToModel(source *domain.Category) *converter.Cate
 		var converterCategoryModel converter.CategoryModel
 		converterCategoryModel.ID = (*source).ID
 		converterCategoryModel.Name = (*source).Name
+		if (*source).ParentID != nil {
+			xint64 := *(*source).ParentID
+			converterCategoryModel.ParentID = &xint64
+		}
 		converterCategoryModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
 		converterCategoryModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
 		converterCategoryModel.IsArchived = (*source).IsArchived
@@ -39,6 +124,102 @@ func (c *CategoryConverterImpl) ToModel(source *domain.Category) *converter.Cate
 	return pConverterCategoryModel
 }
 
+type DuplicateFlagConverterImpl struct{}
+
+func (c *DuplicateFlagConverterImpl) ToArrEntity(source []*converter.DuplicateFlagModel) []*domain.DuplicateFlag {
+	var pDomainDuplicateFlagList []*domain.DuplicateFlag
+	if source != nil {
+		pDomainDuplicateFlagList = make([]*domain.DuplicateFlag, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainDuplicateFlagList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainDuplicateFlagList
+}
+func (c *DuplicateFlagConverterImpl) ToEntity(source *converter.DuplicateFlagModel) *domain.DuplicateFlag {
+	var pDomainDuplicateFlag *domain.DuplicateFlag
+	if source != nil {
+		var domainDuplicateFlag domain.DuplicateFlag
+		domainDuplicateFlag.ProductID = (*source).ProductID
+		domainDuplicateFlag.CandidateID = (*source).CandidateID
+		domainDuplicateFlag.Score = (*source).Score
+		domainDuplicateFlag.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pDomainDuplicateFlag = &domainDuplicateFlag
+	}
+	return pDomainDuplicateFlag
+}
+func (c *DuplicateFlagConverterImpl) ToModel(source *domain.DuplicateFlag) *converter.DuplicateFlagModel {
+	var pConverterDuplicateFlagModel *converter.DuplicateFlagModel
+	if source != nil {
+		var converterDuplicateFlagModel converter.DuplicateFlagModel
+		converterDuplicateFlagModel.ProductID = (*source).ProductID
+		converterDuplicateFlagModel.CandidateID = (*source).CandidateID
+		converterDuplicateFlagModel.Score = (*source).Score
+		converterDuplicateFlagModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pConverterDuplicateFlagModel = &converterDuplicateFlagModel
+	}
+	return pConverterDuplicateFlagModel
+}
+
+type ImageMetaConverterImpl struct{}
+
+func (c *ImageMetaConverterImpl) ToArrEntity(source []*converter.ImageMetaModel) []*domain.ImageMeta {
+	var pDomainImageMetaList []*domain.ImageMeta
+	if source != nil {
+		pDomainImageMetaList = make([]*domain.ImageMeta, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainImageMetaList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainImageMetaList
+}
+func (c *ImageMetaConverterImpl) ToEntity(source *converter.ImageMetaModel) *domain.ImageMeta {
+	var pDomainImageMeta *domain.ImageMeta
+	if source != nil {
+		var domainImageMeta domain.ImageMeta
+		domainImageMeta.ID = (*source).ID
+		domainImageMeta.ProductID = (*source).ProductID
+		domainImageMeta.ObjectKey = (*source).ObjectKey
+		domainImageMeta.MimeType = (*source).MimeType
+		domainImageMeta.Size = (*source).Size
+		domainImageMeta.SHA256 = (*source).SHA256
+		if (*source).EmbeddingID != nil {
+			xstring := *(*source).EmbeddingID
+			domainImageMeta.EmbeddingID = &xstring
+		}
+		if (*source).ModelVersion != nil {
+			xstring2 := *(*source).ModelVersion
+			domainImageMeta.ModelVersion = &xstring2
+		}
+		domainImageMeta.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pDomainImageMeta = &domainImageMeta
+	}
+	return pDomainImageMeta
+}
+func (c *ImageMetaConverterImpl) ToModel(source *domain.ImageMeta) *converter.ImageMetaModel {
+	var pConverterImageMetaModel *converter.ImageMetaModel
+	if source != nil {
+		var converterImageMetaModel converter.ImageMetaModel
+		converterImageMetaModel.ID = (*source).ID
+		converterImageMetaModel.ProductID = (*source).ProductID
+		converterImageMetaModel.ObjectKey = (*source).ObjectKey
+		converterImageMetaModel.MimeType = (*source).MimeType
+		converterImageMetaModel.Size = (*source).Size
+		converterImageMetaModel.SHA256 = (*source).SHA256
+		if (*source).EmbeddingID != nil {
+			xstring := *(*source).EmbeddingID
+			converterImageMetaModel.EmbeddingID = &xstring
+		}
+		if (*source).ModelVersion != nil {
+			xstring2 := *(*source).ModelVersion
+			converterImageMetaModel.ModelVersion = &xstring2
+		}
+		converterImageMetaModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pConverterImageMetaModel = &converterImageMetaModel
+	}
+	return pConverterImageMetaModel
+}
+
 type OutboxEventConverterImpl struct{}
 
 func (c *OutboxEventConverterImpl) ToArrEntity(source []*converter.OutboxEventModel) []*usecase.OutboxEvent {
@@ -111,11 +292,40 @@ func (c *ProductConverterImpl) ToEntity(source *converter.ProductModel) *domain.
 		var domainProduct domain.Product
 		domainProduct.ID = (*source).ID
 		domainProduct.Name = (*source).Name
-		domainProduct.Price = (*source).Price
+		domainProduct.Price = converter.ProductModelToMoney((*source))
+		if (*source).SKU != nil {
+			xstring := *(*source).SKU
+			domainProduct.SKU = &xstring
+		}
+		if (*source).Barcodes != nil {
+			domainProduct.Barcodes = make([]string, len((*source).Barcodes))
+			for i := 0; i < len((*source).Barcodes); i++ {
+				domainProduct.Barcodes[i] = (*source).Barcodes[i]
+			}
+		}
+		domainProduct.Unit = domain.Unit((*source).Unit)
 		domainProduct.CategoryID = (*source).CategoryID
+		if (*source).LocalizedNames != nil {
+			domainProduct.LocalizedNames = make(domain.LocalizedNames, len((*source).LocalizedNames))
+			for key, value := range (*source).LocalizedNames {
+				domainProduct.LocalizedNames[key] = value
+			}
+		}
+		if (*source).Attributes != nil {
+			domainProduct.Attributes = make(domain.Attributes, len((*source).Attributes))
+			for key, value := range (*source).Attributes {
+				domainProduct.Attributes[key] = value
+			}
+		}
+		if (*source).VariantGroupID != nil {
+			xint64 := *(*source).VariantGroupID
+			domainProduct.VariantGroupID = &xint64
+		}
 		domainProduct.CreatedAt = converter.ConvertTime((*source).CreatedAt)
 		domainProduct.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
 		domainProduct.IsArchived = (*source).IsArchived
+		domainProduct.Status = domain.ProductStatus((*source).Status)
+		domainProduct.Version = (*source).Version
 		pDomainProduct = &domainProduct
 	}
 	return pDomainProduct
@@ -126,12 +336,426 @@ func (c *ProductConverterImpl) ToModel(source *domain.Product) *converter.Produc
 		var converterProductModel converter.ProductModel
 		converterProductModel.ID = (*source).ID
 		converterProductModel.Name = (*source).Name
-		converterProductModel.Price = (*source).Price
+		converterProductModel.Price = (*source).Price.Amount
+		converterProductModel.Currency = string((*source).Price.Currency)
+		if (*source).SKU != nil {
+			xstring := *(*source).SKU
+			converterProductModel.SKU = &xstring
+		}
+		if (*source).Barcodes != nil {
+			converterProductModel.Barcodes = make([]string, len((*source).Barcodes))
+			for i := 0; i < len((*source).Barcodes); i++ {
+				converterProductModel.Barcodes[i] = (*source).Barcodes[i]
+			}
+		}
+		converterProductModel.Unit = string((*source).Unit)
 		converterProductModel.CategoryID = (*source).CategoryID
+		if (*source).LocalizedNames != nil {
+			converterProductModel.LocalizedNames = make(map[string]string, len((*source).LocalizedNames))
+			for key, value := range (*source).LocalizedNames {
+				converterProductModel.LocalizedNames[key] = value
+			}
+		}
+		if (*source).Attributes != nil {
+			converterProductModel.Attributes = make(map[string]interface{}, len((*source).Attributes))
+			for key, value := range (*source).Attributes {
+				converterProductModel.Attributes[key] = value
+			}
+		}
+		if (*source).VariantGroupID != nil {
+			xint64 := *(*source).VariantGroupID
+			converterProductModel.VariantGroupID = &xint64
+		}
 		converterProductModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
 		converterProductModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
 		converterProductModel.IsArchived = (*source).IsArchived
+		converterProductModel.Status = string((*source).Status)
+		converterProductModel.Version = (*source).Version
 		pConverterProductModel = &converterProductModel
 	}
 	return pConverterProductModel
 }
+
+type ProductPriceConverterImpl struct{}
+
+func (c *ProductPriceConverterImpl) ToArrEntity(source []*converter.ProductPriceModel) []*domain.ProductPrice {
+	var pDomainProductPriceList []*domain.ProductPrice
+	if source != nil {
+		pDomainProductPriceList = make([]*domain.ProductPrice, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainProductPriceList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainProductPriceList
+}
+func (c *ProductPriceConverterImpl) ToEntity(source *converter.ProductPriceModel) *domain.ProductPrice {
+	var pDomainProductPrice *domain.ProductPrice
+	if source != nil {
+		var domainProductPrice domain.ProductPrice
+		domainProductPrice.ID = (*source).ID
+		domainProductPrice.ProductID = (*source).ProductID
+		domainProductPrice.Price = converter.ProductPriceModelToMoney((*source))
+		domainProductPrice.EffectiveFrom = converter.ConvertTime((*source).EffectiveFrom)
+		domainProductPrice.AppliedAt = converter.ConvertPointerTime((*source).AppliedAt)
+		domainProductPrice.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pDomainProductPrice = &domainProductPrice
+	}
+	return pDomainProductPrice
+}
+func (c *ProductPriceConverterImpl) ToModel(source *domain.ProductPrice) *converter.ProductPriceModel {
+	var pConverterProductPriceModel *converter.ProductPriceModel
+	if source != nil {
+		var converterProductPriceModel converter.ProductPriceModel
+		converterProductPriceModel.ID = (*source).ID
+		converterProductPriceModel.ProductID = (*source).ProductID
+		converterProductPriceModel.Price = (*source).Price.Amount
+		converterProductPriceModel.Currency = string((*source).Price.Currency)
+		converterProductPriceModel.EffectiveFrom = converter.ConvertTime((*source).EffectiveFrom)
+		converterProductPriceModel.AppliedAt = converter.ConvertPointerTime((*source).AppliedAt)
+		converterProductPriceModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pConverterProductPriceModel = &converterProductPriceModel
+	}
+	return pConverterProductPriceModel
+}
+
+type ProductRedirectConverterImpl struct{}
+
+func (c *ProductRedirectConverterImpl) ToEntity(source *converter.ProductRedirectModel) *domain.ProductRedirect {
+	var pDomainProductRedirect *domain.ProductRedirect
+	if source != nil {
+		var domainProductRedirect domain.ProductRedirect
+		domainProductRedirect.SourceID = (*source).SourceID
+		domainProductRedirect.TargetID = (*source).TargetID
+		domainProductRedirect.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pDomainProductRedirect = &domainProductRedirect
+	}
+	return pDomainProductRedirect
+}
+func (c *ProductRedirectConverterImpl) ToModel(source *domain.ProductRedirect) *converter.ProductRedirectModel {
+	var pConverterProductRedirectModel *converter.ProductRedirectModel
+	if source != nil {
+		var converterProductRedirectModel converter.ProductRedirectModel
+		converterProductRedirectModel.SourceID = (*source).SourceID
+		converterProductRedirectModel.TargetID = (*source).TargetID
+		converterProductRedirectModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pConverterProductRedirectModel = &converterProductRedirectModel
+	}
+	return pConverterProductRedirectModel
+}
+
+type ProductTransitionConverterImpl struct{}
+
+func (c *ProductTransitionConverterImpl) ToArrEntity(source []*converter.ProductTransitionModel) []*domain.ProductTransition {
+	var pDomainProductTransitionList []*domain.ProductTransition
+	if source != nil {
+		pDomainProductTransitionList = make([]*domain.ProductTransition, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainProductTransitionList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainProductTransitionList
+}
+func (c *ProductTransitionConverterImpl) ToEntity(source *converter.ProductTransitionModel) *domain.ProductTransition {
+	var pDomainProductTransition *domain.ProductTransition
+	if source != nil {
+		var domainProductTransition domain.ProductTransition
+		domainProductTransition.ID = (*source).ID
+		domainProductTransition.ProductID = (*source).ProductID
+		domainProductTransition.FromStatus = domain.ProductStatus((*source).FromStatus)
+		domainProductTransition.ToStatus = domain.ProductStatus((*source).ToStatus)
+		if (*source).Reviewer != nil {
+			xstring := *(*source).Reviewer
+			domainProductTransition.Reviewer = &xstring
+		}
+		if (*source).Comment != nil {
+			xstring2 := *(*source).Comment
+			domainProductTransition.Comment = &xstring2
+		}
+		domainProductTransition.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pDomainProductTransition = &domainProductTransition
+	}
+	return pDomainProductTransition
+}
+func (c *ProductTransitionConverterImpl) ToModel(source *domain.ProductTransition) *converter.ProductTransitionModel {
+	var pConverterProductTransitionModel *converter.ProductTransitionModel
+	if source != nil {
+		var converterProductTransitionModel converter.ProductTransitionModel
+		converterProductTransitionModel.ID = (*source).ID
+		converterProductTransitionModel.ProductID = (*source).ProductID
+		converterProductTransitionModel.FromStatus = string((*source).FromStatus)
+		converterProductTransitionModel.ToStatus = string((*source).ToStatus)
+		if (*source).Reviewer != nil {
+			xstring := *(*source).Reviewer
+			converterProductTransitionModel.Reviewer = &xstring
+		}
+		if (*source).Comment != nil {
+			xstring2 := *(*source).Comment
+			converterProductTransitionModel.Comment = &xstring2
+		}
+		converterProductTransitionModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		pConverterProductTransitionModel = &converterProductTransitionModel
+	}
+	return pConverterProductTransitionModel
+}
+
+type PromotionConverterImpl struct{}
+
+func (c *PromotionConverterImpl) ToArrEntity(source []*converter.PromotionModel) []*domain.Promotion {
+	var pDomainPromotionList []*domain.Promotion
+	if source != nil {
+		pDomainPromotionList = make([]*domain.Promotion, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainPromotionList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainPromotionList
+}
+func (c *PromotionConverterImpl) ToEntity(source *converter.PromotionModel) *domain.Promotion {
+	var pDomainPromotion *domain.Promotion
+	if source != nil {
+		var domainPromotion domain.Promotion
+		domainPromotion.ID = (*source).ID
+		domainPromotion.Name = (*source).Name
+		domainPromotion.Kind = domain.PromotionKind((*source).Kind)
+		if (*source).ProductID != nil {
+			xint64 := *(*source).ProductID
+			domainPromotion.ProductID = &xint64
+		}
+		if (*source).CategoryID != nil {
+			xint642 := *(*source).CategoryID
+			domainPromotion.CategoryID = &xint642
+		}
+		domainPromotion.Percent = (*source).Percent
+		domainPromotion.Amount = converter.PromotionModelToMoney((*source))
+		domainPromotion.BuyQuantity = (*source).BuyQuantity
+		domainPromotion.PayQuantity = (*source).PayQuantity
+		domainPromotion.Priority = (*source).Priority
+		domainPromotion.StartsAt = converter.ConvertPointerTime((*source).StartsAt)
+		domainPromotion.EndsAt = converter.ConvertPointerTime((*source).EndsAt)
+		domainPromotion.IsActive = (*source).IsActive
+		domainPromotion.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		domainPromotion.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainPromotion = &domainPromotion
+	}
+	return pDomainPromotion
+}
+func (c *PromotionConverterImpl) ToModel(source *domain.Promotion) *converter.PromotionModel {
+	var pConverterPromotionModel *converter.PromotionModel
+	if source != nil {
+		var converterPromotionModel converter.PromotionModel
+		converterPromotionModel.ID = (*source).ID
+		converterPromotionModel.Name = (*source).Name
+		converterPromotionModel.Kind = string((*source).Kind)
+		if (*source).ProductID != nil {
+			xint64 := *(*source).ProductID
+			converterPromotionModel.ProductID = &xint64
+		}
+		if (*source).CategoryID != nil {
+			xint642 := *(*source).CategoryID
+			converterPromotionModel.CategoryID = &xint642
+		}
+		converterPromotionModel.Percent = (*source).Percent
+		converterPromotionModel.Amount = converter.MoneyToPointerAmount((*source).Amount)
+		converterPromotionModel.Currency = converter.MoneyToPointerCurrency((*source).Amount)
+		converterPromotionModel.BuyQuantity = (*source).BuyQuantity
+		converterPromotionModel.PayQuantity = (*source).PayQuantity
+		converterPromotionModel.Priority = (*source).Priority
+		converterPromotionModel.StartsAt = converter.ConvertPointerTime((*source).StartsAt)
+		converterPromotionModel.EndsAt = converter.ConvertPointerTime((*source).EndsAt)
+		converterPromotionModel.IsActive = (*source).IsActive
+		converterPromotionModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		converterPromotionModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterPromotionModel = &converterPromotionModel
+	}
+	return pConverterPromotionModel
+}
+
+type StoreConverterImpl struct{}
+
+func (c *StoreConverterImpl) ToArrEntity(source []*converter.StoreModel) []*domain.Store {
+	var pDomainStoreList []*domain.Store
+	if source != nil {
+		pDomainStoreList = make([]*domain.Store, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainStoreList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainStoreList
+}
+func (c *StoreConverterImpl) ToArrProductEntity(source []*converter.StoreProductModel) []*domain.StoreProduct {
+	var pDomainStoreProductList []*domain.StoreProduct
+	if source != nil {
+		pDomainStoreProductList = make([]*domain.StoreProduct, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainStoreProductList[i] = c.ToProductEntity(source[i])
+		}
+	}
+	return pDomainStoreProductList
+}
+func (c *StoreConverterImpl) ToEntity(source *converter.StoreModel) *domain.Store {
+	var pDomainStore *domain.Store
+	if source != nil {
+		var domainStore domain.Store
+		domainStore.ID = (*source).ID
+		domainStore.Code = (*source).Code
+		domainStore.Name = (*source).Name
+		domainStore.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		domainStore.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainStore = &domainStore
+	}
+	return pDomainStore
+}
+func (c *StoreConverterImpl) ToModel(source *domain.Store) *converter.StoreModel {
+	var pConverterStoreModel *converter.StoreModel
+	if source != nil {
+		var converterStoreModel converter.StoreModel
+		converterStoreModel.ID = (*source).ID
+		converterStoreModel.Code = (*source).Code
+		converterStoreModel.Name = (*source).Name
+		converterStoreModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		converterStoreModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterStoreModel = &converterStoreModel
+	}
+	return pConverterStoreModel
+}
+func (c *StoreConverterImpl) ToProductEntity(source *converter.StoreProductModel) *domain.StoreProduct {
+	var pDomainStoreProduct *domain.StoreProduct
+	if source != nil {
+		var domainStoreProduct domain.StoreProduct
+		domainStoreProduct.StoreID = (*source).StoreID
+		domainStoreProduct.ProductID = (*source).ProductID
+		domainStoreProduct.IsAvailable = (*source).IsAvailable
+		domainStoreProduct.Price = converter.StoreProductModelToMoney((*source))
+		domainStoreProduct.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainStoreProduct = &domainStoreProduct
+	}
+	return pDomainStoreProduct
+}
+func (c *StoreConverterImpl) ToProductModel(source *domain.StoreProduct) *converter.StoreProductModel {
+	var pConverterStoreProductModel *converter.StoreProductModel
+	if source != nil {
+		var converterStoreProductModel converter.StoreProductModel
+		converterStoreProductModel.StoreID = (*source).StoreID
+		converterStoreProductModel.ProductID = (*source).ProductID
+		converterStoreProductModel.IsAvailable = (*source).IsAvailable
+		converterStoreProductModel.Price = converter.MoneyToPointerAmount((*source).Price)
+		converterStoreProductModel.Currency = converter.MoneyToPointerCurrency((*source).Price)
+		converterStoreProductModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterStoreProductModel = &converterStoreProductModel
+	}
+	return pConverterStoreProductModel
+}
+
+type StockConverterImpl struct{}
+
+func (c *StockConverterImpl) ToArrLevelEntity(source []*converter.StockLevelModel) []*domain.StockLevel {
+	var pDomainStockLevelList []*domain.StockLevel
+	if source != nil {
+		pDomainStockLevelList = make([]*domain.StockLevel, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainStockLevelList[i] = c.ToLevelEntity(source[i])
+		}
+	}
+	return pDomainStockLevelList
+}
+func (c *StockConverterImpl) ToLevelEntity(source *converter.StockLevelModel) *domain.StockLevel {
+	var pDomainStockLevel *domain.StockLevel
+	if source != nil {
+		var domainStockLevel domain.StockLevel
+		domainStockLevel.StoreID = (*source).StoreID
+		domainStockLevel.ProductID = (*source).ProductID
+		domainStockLevel.OnHand = (*source).OnHand
+		domainStockLevel.Reserved = (*source).Reserved
+		domainStockLevel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainStockLevel = &domainStockLevel
+	}
+	return pDomainStockLevel
+}
+func (c *StockConverterImpl) ToReservationEntity(source *converter.StockReservationModel) *domain.StockReservation {
+	var pDomainStockReservation *domain.StockReservation
+	if source != nil {
+		var domainStockReservation domain.StockReservation
+		domainStockReservation.ID = c.uuidUUIDToUuidUUID((*source).ID)
+		domainStockReservation.StoreID = (*source).StoreID
+		domainStockReservation.ProductID = (*source).ProductID
+		domainStockReservation.Quantity = (*source).Quantity
+		domainStockReservation.Status = domain.ReservationStatus((*source).Status)
+		domainStockReservation.ExpiresAt = converter.ConvertTime((*source).ExpiresAt)
+		domainStockReservation.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		domainStockReservation.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainStockReservation = &domainStockReservation
+	}
+	return pDomainStockReservation
+}
+func (c *StockConverterImpl) ToReservationModel(source *domain.StockReservation) *converter.StockReservationModel {
+	var pConverterStockReservationModel *converter.StockReservationModel
+	if source != nil {
+		var converterStockReservationModel converter.StockReservationModel
+		converterStockReservationModel.ID = c.uuidUUIDToUuidUUID((*source).ID)
+		converterStockReservationModel.StoreID = (*source).StoreID
+		converterStockReservationModel.ProductID = (*source).ProductID
+		converterStockReservationModel.Quantity = (*source).Quantity
+		converterStockReservationModel.Status = string((*source).Status)
+		converterStockReservationModel.ExpiresAt = converter.ConvertTime((*source).ExpiresAt)
+		converterStockReservationModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		converterStockReservationModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterStockReservationModel = &converterStockReservationModel
+	}
+	return pConverterStockReservationModel
+}
+func (c *StockConverterImpl) uuidUUIDToUuidUUID(source uuid.UUID) uuid.UUID {
+	var uuidUUID uuid.UUID
+	for i := 0; i < len(source); i++ {
+		uuidUUID[i] = source[i]
+	}
+	return uuidUUID
+}
+
+type VariantGroupConverterImpl struct{}
+
+func (c *VariantGroupConverterImpl) ToArrEntity(source []*converter.VariantGroupModel) []*domain.VariantGroup {
+	var pDomainVariantGroupList []*domain.VariantGroup
+	if source != nil {
+		pDomainVariantGroupList = make([]*domain.VariantGroup, len(source))
+		for i := 0; i < len(source); i++ {
+			pDomainVariantGroupList[i] = c.ToEntity(source[i])
+		}
+	}
+	return pDomainVariantGroupList
+}
+func (c *VariantGroupConverterImpl) ToEntity(source *converter.VariantGroupModel) *domain.VariantGroup {
+	var pDomainVariantGroup *domain.VariantGroup
+	if source != nil {
+		var domainVariantGroup domain.VariantGroup
+		domainVariantGroup.ID = (*source).ID
+		domainVariantGroup.Name = (*source).Name
+		if (*source).Axes != nil {
+			domainVariantGroup.Axes = make([]string, len((*source).Axes))
+			for i := 0; i < len((*source).Axes); i++ {
+				domainVariantGroup.Axes[i] = (*source).Axes[i]
+			}
+		}
+		domainVariantGroup.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		domainVariantGroup.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainVariantGroup = &domainVariantGroup
+	}
+	return pDomainVariantGroup
+}
+func (c *VariantGroupConverterImpl) ToModel(source *domain.VariantGroup) *converter.VariantGroupModel {
+	var pConverterVariantGroupModel *converter.VariantGroupModel
+	if source != nil {
+		var converterVariantGroupModel converter.VariantGroupModel
+		converterVariantGroupModel.ID = (*source).ID
+		converterVariantGroupModel.Name = (*source).Name
+		if (*source).Axes != nil {
+			converterVariantGroupModel.Axes = make([]string, len((*source).Axes))
+			for i := 0; i < len((*source).Axes); i++ {
+				converterVariantGroupModel.Axes[i] = (*source).Axes[i]
+			}
+		}
+		converterVariantGroupModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		converterVariantGroupModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterVariantGroupModel = &converterVariantGroupModel
+	}
+	return pConverterVariantGroupModel
+}
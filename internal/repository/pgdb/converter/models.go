@@ -9,24 +9,171 @@ import (
 
 // ProductModel представляет запись таблицы product_types в PostgreSQL.
 type ProductModel struct {
-	ID         int64      `db:"id"`
-	Name       string     `db:"name"`
-	Price      int64      `db:"price"`
-	CategoryID int64      `db:"category_id"`
-	CreatedAt  time.Time  `db:"created_at"`
-	UpdatedAt  *time.Time `db:"updated_at"`
-	IsArchived bool       `db:"is_archived"`
+	ID             int64             `db:"id"`
+	Name           string            `db:"name"`
+	Price          int64             `db:"price"`
+	Currency       string            `db:"currency"`
+	SKU            *string           `db:"sku"`
+	Barcodes       []string          `db:"barcodes"`
+	Unit           string            `db:"unit"`
+	CategoryID     int64             `db:"category_id"`
+	LocalizedNames map[string]string `db:"localized_names"`
+	Attributes     map[string]any    `db:"attributes"`
+	VariantGroupID *int64            `db:"variant_group_id"`
+	CreatedAt      time.Time         `db:"created_at"`
+	UpdatedAt      *time.Time        `db:"updated_at"`
+	IsArchived     bool              `db:"is_archived"`
+	Status         string            `db:"status"`
+	Version        int64             `db:"version"`
 }
 
 // CategoryModel представляет запись таблицы categories в PostgreSQL.
 type CategoryModel struct {
 	ID         int64      `db:"id"`
 	Name       string     `db:"name"`
+	ParentID   *int64     `db:"parent_id"`
 	CreatedAt  time.Time  `db:"created_at"`
 	UpdatedAt  *time.Time `db:"updated_at"`
 	IsArchived bool       `db:"is_archived"`
 }
 
+// AttributeSchemaModel представляет запись таблицы category_attribute_schemas в PostgreSQL.
+type AttributeSchemaModel struct {
+	CategoryID int64               `db:"category_id"`
+	Attributes []AttributeDefModel `db:"attributes"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  *time.Time          `db:"updated_at"`
+}
+
+// AttributeDefModel — определение атрибута в JSONB-столбце attributes таблицы category_attribute_schemas.
+type AttributeDefModel struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Unit     string   `json:"unit,omitempty"`
+	Values   []string `json:"values,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// VariantGroupModel представляет запись таблицы variant_groups в PostgreSQL.
+type VariantGroupModel struct {
+	ID        int64      `db:"id"`
+	Name      string     `db:"name"`
+	Axes      []string   `db:"axes"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// DuplicateFlagModel представляет запись таблицы product_duplicate_flags в PostgreSQL.
+type DuplicateFlagModel struct {
+	ProductID   int64     `db:"product_id"`
+	CandidateID int64     `db:"candidate_id"`
+	Score       float32   `db:"score"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// ProductRedirectModel представляет запись таблицы product_redirects в PostgreSQL.
+type ProductRedirectModel struct {
+	SourceID  int64     `db:"source_id"`
+	TargetID  int64     `db:"target_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ProductTransitionModel представляет запись таблицы product_transitions в PostgreSQL.
+type ProductTransitionModel struct {
+	ID         int64     `db:"id"`
+	ProductID  int64     `db:"product_id"`
+	FromStatus string    `db:"from_status"`
+	ToStatus   string    `db:"to_status"`
+	Reviewer   *string   `db:"reviewer"`
+	Comment    *string   `db:"comment"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ProductPriceModel представляет запись таблицы product_prices в PostgreSQL.
+type ProductPriceModel struct {
+	ID            int64      `db:"id"`
+	ProductID     int64      `db:"product_id"`
+	Price         int64      `db:"price"`
+	Currency      string     `db:"currency"`
+	EffectiveFrom time.Time  `db:"effective_from"`
+	AppliedAt     *time.Time `db:"applied_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// PromotionModel представляет запись таблицы promotions в PostgreSQL.
+type PromotionModel struct {
+	ID          int64      `db:"id"`
+	Name        string     `db:"name"`
+	Kind        string     `db:"kind"`
+	ProductID   *int64     `db:"product_id"`
+	CategoryID  *int64     `db:"category_id"`
+	Percent     int64      `db:"percent"`
+	Amount      *int64     `db:"amount"`
+	Currency    *string    `db:"currency"`
+	BuyQuantity int64      `db:"buy_quantity"`
+	PayQuantity int64      `db:"pay_quantity"`
+	Priority    int64      `db:"priority"`
+	StartsAt    *time.Time `db:"starts_at"`
+	EndsAt      *time.Time `db:"ends_at"`
+	IsActive    bool       `db:"is_active"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
+
+// StoreModel представляет запись таблицы stores в PostgreSQL.
+type StoreModel struct {
+	ID        int64      `db:"id"`
+	Code      string     `db:"code"`
+	Name      string     `db:"name"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// StoreProductModel представляет запись таблицы store_products в PostgreSQL.
+type StoreProductModel struct {
+	StoreID     int64      `db:"store_id"`
+	ProductID   int64      `db:"product_id"`
+	IsAvailable bool       `db:"is_available"`
+	Price       *int64     `db:"price"`
+	Currency    *string    `db:"currency"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
+
+// StockLevelModel представляет запись таблицы stock_levels в PostgreSQL вместе с суммой активных резервов.
+type StockLevelModel struct {
+	StoreID   int64      `db:"store_id"`
+	ProductID int64      `db:"product_id"`
+	OnHand    int64      `db:"on_hand"`
+	Reserved  int64      `db:"reserved"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// StockReservationModel представляет запись таблицы stock_reservations в PostgreSQL.
+type StockReservationModel struct {
+	ID        uuid.UUID  `db:"id"`
+	StoreID   int64      `db:"store_id"`
+	ProductID int64      `db:"product_id"`
+	Quantity  int64      `db:"quantity"`
+	Status    string     `db:"status"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// ImageMetaModel представляет запись таблицы product_images в PostgreSQL.
+type ImageMetaModel struct {
+	ID           string    `db:"id"`
+	ProductID    int64     `db:"product_id"`
+	ObjectKey    string    `db:"object_key"`
+	MimeType     string    `db:"mime_type"`
+	Size         int64     `db:"size"`
+	SHA256       string    `db:"sha256"`
+	EmbeddingID  *string   `db:"embedding_id"`
+	ModelVersion *string   `db:"model_version"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
 // OutboxEventModel представляет запись таблицы outbox_events в PostgreSQL.
 type OutboxEventModel struct {
 	ID                  int64                   `db:"id"`
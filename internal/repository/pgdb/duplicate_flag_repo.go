@@ -0,0 +1,82 @@
+package pgdb
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+// DuplicateFlagRepo реализует хранение отметок вероятных дубликатов поверх PostgreSQL.
+type DuplicateFlagRepo struct {
+	pool *pgxpool.Pool
+	conv converter.DuplicateFlagConverter
+}
+
+func NewDuplicateFlagRepo(pool *pgxpool.Pool, conv converter.DuplicateFlagConverter) *DuplicateFlagRepo {
+	return &DuplicateFlagRepo{pool: pool, conv: conv}
+}
+
+// CreateBatch сохраняет отметки вероятных дубликатов в рамках текущей транзакции.
+// Повторная отметка той же пары продуктов заменяет score.
+func (d *DuplicateFlagRepo) CreateBatch(ctx context.Context, flags []domain.DuplicateFlag) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	// $1 product_id, $2 candidate_id, $3 score
+	query := `
+		INSERT INTO product_duplicate_flags (product_id, candidate_id, score)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id, candidate_id) DO UPDATE SET score = EXCLUDED.score
+	`
+
+	batch := &pgx.Batch{}
+	for _, flag := range flags {
+		model := d.conv.ToModel(&flag)
+		batch.Queue(query, model.ProductID, model.CandidateID, model.Score)
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// ListByProduct возвращает вероятные дубликаты продукта, начиная с самого похожего.
+func (d *DuplicateFlagRepo) ListByProduct(ctx context.Context, productID int64) ([]*domain.DuplicateFlag, error) {
+	query := `
+		SELECT product_id, candidate_id, score, created_at
+		FROM product_duplicate_flags
+		WHERE product_id = $1
+		ORDER BY score DESC, candidate_id
+	`
+
+	rows, err := d.pool.Query(ctx, query, productID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.DuplicateFlagModel, 0)
+	for rows.Next() {
+		var model converter.DuplicateFlagModel
+		if err := rows.Scan(&model.ProductID, &model.CandidateID, &model.Score, &model.CreatedAt); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return d.conv.ToArrEntity(models), nil
+}
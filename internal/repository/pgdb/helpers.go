@@ -14,3 +14,35 @@ func postgresDuplicate(err error) bool {
 
 	return false
 }
+
+// postgresUniqueViolation возвращает имя нарушенного ограничения уникальности.
+func postgresUniqueViolation(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return pgErr.ConstraintName, true
+	}
+
+	return "", false
+}
+
+// postgresForeignKeyViolation возвращает имя нарушенного ограничения внешнего ключа.
+func postgresForeignKeyViolation(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return pgErr.ConstraintName, true
+	}
+
+	return "", false
+}
+
+// categorySubtreeQuery возвращает подзапрос, выбирающий ID категории из параметра param и всех её потомков.
+func categorySubtreeQuery(param string) string {
+	return `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ` + param + `
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree
+	`
+}
@@ -0,0 +1,133 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+// ImageMetaRepo реализует реестр изображений продуктов поверх PostgreSQL.
+type ImageMetaRepo struct {
+	pool *pgxpool.Pool
+	conv converter.ImageMetaConverter
+}
+
+func NewImageMetaRepo(pool *pgxpool.Pool, conv converter.ImageMetaConverter) *ImageMetaRepo {
+	return &ImageMetaRepo{pool: pool, conv: conv}
+}
+
+// CreateBatch сохраняет записи об изображениях продукта в рамках текущей транзакции.
+func (i *ImageMetaRepo) CreateBatch(ctx context.Context, images []domain.ImageMeta) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO product_images (
+			id, product_id, object_key, mime_type, size, sha256, embedding_id, model_version
+		) VALUES ($1::uuid, $2, $3, $4, $5, $6, $7::uuid, $8)
+	`
+
+	batch := &pgx.Batch{}
+	for _, image := range images {
+		model := i.conv.ToModel(&image)
+		batch.Queue(query,
+			model.ID, model.ProductID, model.ObjectKey, model.MimeType,
+			model.Size, model.SHA256, model.EmbeddingID, model.ModelVersion,
+		)
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// ListByProduct возвращает изображения продукта в порядке добавления.
+func (i *ImageMetaRepo) ListByProduct(ctx context.Context, productID int64) ([]*domain.ImageMeta, error) {
+	query := `
+		SELECT
+			id::text, product_id, object_key, mime_type, size, sha256,
+			embedding_id::text, model_version, created_at
+		FROM product_images
+		WHERE product_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := i.pool.Query(ctx, query, productID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.ImageMetaModel, 0)
+	for rows.Next() {
+		var model converter.ImageMetaModel
+		if err := rows.Scan(
+			&model.ID, &model.ProductID, &model.ObjectKey, &model.MimeType, &model.Size, &model.SHA256,
+			&model.EmbeddingID, &model.ModelVersion, &model.CreatedAt,
+		); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return i.conv.ToArrEntity(models), nil
+}
+
+// Delete удаляет запись об изображении продукта в рамках текущей транзакции и возвращает удалённую запись.
+func (i *ImageMetaRepo) Delete(ctx context.Context, productID int64, id string) (*domain.ImageMeta, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		DELETE FROM product_images
+		WHERE id = $1::uuid AND product_id = $2
+		RETURNING
+			id::text, product_id, object_key, mime_type, size, sha256,
+			embedding_id::text, model_version, created_at
+	`
+
+	var model converter.ImageMetaModel
+	if err := tx.QueryRow(ctx, query, id, productID).Scan(
+		&model.ID, &model.ProductID, &model.ObjectKey, &model.MimeType, &model.Size, &model.SHA256,
+		&model.EmbeddingID, &model.ModelVersion, &model.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrImageNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return i.conv.ToEntity(&model), nil
+}
+
+// Reassign переносит записи об изображениях продукта fromID продукту toID в рамках текущей транзакции.
+func (i *ImageMetaRepo) Reassign(ctx context.Context, fromID int64, toID int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE product_images SET product_id = $2 WHERE product_id = $1`, fromID, toID); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
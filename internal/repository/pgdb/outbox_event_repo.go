@@ -3,12 +3,15 @@ package pgdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/e"
 	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jimlawless/whereami"
 )
@@ -67,6 +70,43 @@ func (o *OutboxEventRepo) Create(ctx context.Context, event *usecase.OutboxEvent
 	return o.conv.ToEntity(model), nil
 }
 
+// GetByEventID возвращает событие по его event_id.
+func (o *OutboxEventRepo) GetByEventID(ctx context.Context, eventID uuid.UUID) (*usecase.OutboxEvent, error) {
+	query := `
+		SELECT id, event_id, event_type, product_id, payload, status, created_at, processing_started_at, processed_at
+		FROM outbox_events
+		WHERE event_id = $1
+	`
+
+	var model converter.OutboxEventModel
+	var processingStartedAt, processedAt sql.NullTime
+	if err := o.pool.QueryRow(ctx, query, eventID).Scan(
+		&model.ID,
+		&model.EventID,
+		&model.EventType,
+		&model.ProductID,
+		&model.Payload,
+		&model.Status,
+		&model.CreatedAt,
+		&processingStartedAt,
+		&processedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrEventNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if processingStartedAt.Valid {
+		model.ProcessingStartedAt = &processingStartedAt.Time
+	}
+	if processedAt.Valid {
+		model.ProcessedAt = &processedAt.Time
+	}
+
+	return o.conv.ToEntity(&model), nil
+}
+
 func (o *OutboxEventRepo) GetAndMarkAsProcessing(ctx context.Context, limit int) ([]*usecase.OutboxEvent, error) {
 	tx, err := o.pool.Begin(ctx)
 	if err != nil {
@@ -0,0 +1,222 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+// PriceRepo реализует историю и расписание цен продуктов поверх PostgreSQL.
+type PriceRepo struct {
+	pool *pgxpool.Pool
+	conv converter.ProductPriceConverter
+}
+
+func NewPriceRepo(pool *pgxpool.Pool, conv converter.ProductPriceConverter) *PriceRepo {
+	return &PriceRepo{pool: pool, conv: conv}
+}
+
+// Record добавляет в историю цену, применённую к продукту в текущий момент.
+func (p *PriceRepo) Record(ctx context.Context, productID int64, price domain.Money) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO product_prices (product_id, price, currency, effective_from, applied_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+	`
+
+	if _, err := tx.Exec(ctx, query, productID, price.Amount, string(price.Currency)); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// Schedule сохраняет цену, которая будет применена к продукту в момент EffectiveFrom.
+func (p *PriceRepo) Schedule(ctx context.Context, price *domain.ProductPrice) (*domain.ProductPrice, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	// VALUES ($1, $2, $3, $4) product_id, price, currency, effective_from
+	query := `
+		INSERT INTO product_prices (product_id, price, currency, effective_from)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, product_id, price, currency, effective_from, applied_at, created_at
+	`
+
+	model := p.conv.ToModel(price)
+	err = tx.QueryRow(ctx, query, model.ProductID, model.Price, model.Currency, model.EffectiveFrom).
+		Scan(&model.ID, &model.ProductID, &model.Price, &model.Currency, &model.EffectiveFrom, &model.AppliedAt, &model.CreatedAt)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(model), nil
+}
+
+// ListByProduct возвращает историю и запланированные цены продукта, начиная с самой поздней.
+func (p *PriceRepo) ListByProduct(ctx context.Context, productID int64) ([]*domain.ProductPrice, error) {
+	query := `
+		SELECT id, product_id, price, currency, effective_from, applied_at, created_at
+		FROM product_prices
+		WHERE product_id = $1
+		ORDER BY effective_from DESC, id DESC
+	`
+
+	rows, err := p.pool.Query(ctx, query, productID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.ProductPriceModel, 0)
+	for rows.Next() {
+		var model converter.ProductPriceModel
+		if err := rows.Scan(
+			&model.ID, &model.ProductID, &model.Price, &model.Currency, &model.EffectiveFrom, &model.AppliedAt, &model.CreatedAt,
+		); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToArrEntity(models), nil
+}
+
+// GetEffective возвращает цену продукта, действующую в момент at, с учётом запланированных цен.
+func (p *PriceRepo) GetEffective(ctx context.Context, productID int64, at time.Time) (*domain.ProductPrice, error) {
+	query := `
+		SELECT id, product_id, price, currency, effective_from, applied_at, created_at
+		FROM product_prices
+		WHERE product_id = $1 AND effective_from <= $2
+		ORDER BY effective_from DESC, id DESC
+		LIMIT 1
+	`
+
+	var model converter.ProductPriceModel
+	err := p.pool.QueryRow(ctx, query, productID, at).
+		Scan(&model.ID, &model.ProductID, &model.Price, &model.Currency, &model.EffectiveFrom, &model.AppliedAt, &model.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrPriceNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(&model), nil
+}
+
+// GetDueForUpdate возвращает самую раннюю запланированную цену, время действия которой наступило,
+// и блокирует её до конца транзакции. Цены, заблокированные другими транзакциями, пропускаются.
+// Цены, неудачная попытка применения которых была менее retryDelay назад, и цены с maxAttempts
+// неудачными попытками не возвращаются.
+func (p *PriceRepo) GetDueForUpdate(ctx context.Context, retryDelay time.Duration, maxAttempts int) (*domain.ProductPrice, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		SELECT id, product_id, price, currency, effective_from, applied_at, created_at
+		FROM product_prices
+		WHERE applied_at IS NULL AND effective_from <= NOW()
+			AND attempts < $2 AND (failed_at IS NULL OR failed_at <= NOW() - make_interval(secs => $1))
+		ORDER BY effective_from, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	var model converter.ProductPriceModel
+	err = tx.QueryRow(ctx, query, retryDelay.Seconds(), maxAttempts).
+		Scan(&model.ID, &model.ProductID, &model.Price, &model.Currency, &model.EffectiveFrom, &model.AppliedAt, &model.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrPriceNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(&model), nil
+}
+
+// MarkApplied отмечает запланированную цену как применённую к продукту.
+func (p *PriceRepo) MarkApplied(ctx context.Context, id int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE product_prices SET applied_at = NOW() WHERE id = $1 AND applied_at IS NULL`, id)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(whereami.WhereAmI(), e.ErrPriceNotFound)
+	}
+
+	return nil
+}
+
+// MarkFailed отмечает неудачную попытку применения запланированной цены и возвращает кол-во попыток.
+// Выполняется вне транзакции применения, т.к. та откатывается.
+func (p *PriceRepo) MarkFailed(ctx context.Context, id int64) (int, error) {
+	query := `
+		UPDATE product_prices
+		SET attempts = attempts + 1, failed_at = NOW()
+		WHERE id = $1 AND applied_at IS NULL
+		RETURNING attempts
+	`
+
+	var attempts int
+	if err := p.pool.QueryRow(ctx, query, id).Scan(&attempts); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, e.Wrap(whereami.WhereAmI(), e.ErrPriceNotFound)
+		}
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return attempts, nil
+}
+
+// DeleteScheduled отменяет ещё не применённую цену продукта.
+func (p *PriceRepo) DeleteScheduled(ctx context.Context, productID int64, id int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		DELETE FROM product_prices
+		WHERE id = $1 AND product_id = $2 AND applied_at IS NULL
+	`
+
+	tag, err := tx.Exec(ctx, query, id, productID)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(whereami.WhereAmI(), e.ErrPriceNotFound)
+	}
+
+	return nil
+}
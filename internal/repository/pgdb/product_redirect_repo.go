@@ -0,0 +1,78 @@
+package pgdb
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+// ProductRedirectRepo реализует хранение перенаправлений слитых продуктов поверх PostgreSQL.
+type ProductRedirectRepo struct {
+	pool *pgxpool.Pool
+	conv converter.ProductRedirectConverter
+}
+
+func NewProductRedirectRepo(pool *pgxpool.Pool, conv converter.ProductRedirectConverter) *ProductRedirectRepo {
+	return &ProductRedirectRepo{pool: pool, conv: conv}
+}
+
+// Create сохраняет перенаправление с источника на получатель. Перенаправления, ведущие на источник,
+// переводятся на получатель, поэтому любой слитый ID разрешается за один шаг.
+func (p *ProductRedirectRepo) Create(ctx context.Context, redirect *domain.ProductRedirect) (*domain.ProductRedirect, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	model := p.conv.ToModel(redirect)
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE product_redirects SET target_id = $2 WHERE target_id = $1`,
+		model.SourceID, model.TargetID,
+	); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	// $1 source_id, $2 target_id
+	query := `
+		INSERT INTO product_redirects (source_id, target_id)
+		VALUES ($1, $2)
+		RETURNING source_id, target_id, created_at
+	`
+
+	if err := tx.QueryRow(ctx, query, model.SourceID, model.TargetID).
+		Scan(&model.SourceID, &model.TargetID, &model.CreatedAt); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(model), nil
+}
+
+// GetTargets возвращает продукты-получатели для слитых продуктов из ids. ID без перенаправления в ответ не попадают.
+func (p *ProductRedirectRepo) GetTargets(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	rows, err := p.pool.Query(ctx, `SELECT source_id, target_id FROM product_redirects WHERE source_id = ANY($1)`, ids)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	targets := make(map[int64]int64, len(ids))
+	for rows.Next() {
+		var sourceID, targetID int64
+		if err := rows.Scan(&sourceID, &targetID); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		targets[sourceID] = targetID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return targets, nil
+}
@@ -2,16 +2,23 @@ package pgdb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/e"
 	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jimlawless/whereami"
 )
 
+// productSKUConstraint — ограничение уникальности артикула продукта
+const productSKUConstraint = "products_sku_key"
+
 // ProductRepo реализует репозиторий продуктов поверх PostgreSQL.
 type ProductRepo struct {
 	pool *pgxpool.Pool
@@ -25,66 +32,389 @@ func NewProductRepo(pool *pgxpool.Pool, conv converter.ProductConverter) *Produc
 	}
 }
 
-// Upsert создаёт или обновляет продукт по уникальному имени,
-// Запись обновляется только при изменении цены или категории.
-func (p *ProductRepo) Upsert(ctx context.Context, product *domain.Product) (*usecase.UpsertProductRes, error) {
+// Create создаёт продукт. Если продукт с таким названием уже существует, возвращается ErrProductNameTaken.
+// При параллельной вставке того же названия запрос дожидается завершения конкурирующей транзакции.
+func (p *ProductRepo) Create(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	tx, err := tr.TxFromCtx(ctx)
 	if err != nil {
 		return nil, e.Wrap(whereami.WhereAmI(), err)
 	}
 
-	// VALUES ($1, $2, $3) name, price, category_id
+	// VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, status
 	query := `
-		WITH upsert AS (
-		INSERT INTO products (name, price, category_id)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (name)
-		DO UPDATE SET
-			price = EXCLUDED.price,
-			category_id = EXCLUDED.category_id,
-			updated_at = NOW()
-		WHERE
-			products.price IS DISTINCT FROM EXCLUDED.price OR
-			products.category_id IS DISTINCT FROM EXCLUDED.category_id
-		RETURNING
-			id, name, price, category_id, created_at, updated_at, is_archived
+		INSERT INTO products (name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id, name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, created_at, updated_at, is_archived, status, version
+	`
+
+	model := p.conv.ToModel(product)
+	err = tx.QueryRow(ctx, query,
+		model.Name, model.Price, model.Currency, model.SKU, model.Unit, model.CategoryID, model.LocalizedNames, model.Attributes, model.VariantGroupID,
+		model.Status,
+	).Scan(
+		&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+		&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+		&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNameTaken)
+		}
+		if constraint, ok := postgresUniqueViolation(err); ok && constraint == productSKUConstraint {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrSKUTaken)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(model), nil
+}
+
+// GetByNameForUpdate возвращает продукт по названию, блокируя запись до конца транзакции.
+func (p *ProductRepo) GetByNameForUpdate(ctx context.Context, name string) (*domain.Product, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		SELECT
+			id, name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, created_at, updated_at, is_archived, status, version,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = products.id ORDER BY b.barcode)
+		FROM products
+		WHERE name = $1
+		FOR UPDATE
+	`
+
+	var model converter.ProductModel
+	err = tx.QueryRow(ctx, query, name).
+		Scan(
+			&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+			&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version, &model.Barcodes,
 		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(&model), nil
+}
+
+// GetByID возвращает продукт, включая архивный, вместе с названием категории.
+func (p *ProductRepo) GetByID(ctx context.Context, id int64) (*usecase.ProductDetails, error) {
+	query := `
 		SELECT
-			id, name, price, category_id, created_at, updated_at, is_archived,
-			false AS no_changes
-		FROM upsert
-		
-		UNION ALL
-		
+			pr.id, pr.name, pr.price, pr.currency, pr.sku, pr.unit, pr.category_id, pr.localized_names, pr.attributes, pr.variant_group_id, pr.created_at, pr.updated_at, pr.is_archived, pr.status, pr.version,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = pr.id ORDER BY b.barcode), cat.name
+		FROM products pr
+		JOIN categories cat ON pr.category_id = cat.id
+		WHERE pr.id = $1
+	`
+
+	var model converter.ProductModel
+	var categoryName string
+	err := p.pool.QueryRow(ctx, query, id).
+		Scan(
+			&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+			&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version, &model.Barcodes, &categoryName,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return usecase.NewProductDetails(p.conv.ToEntity(&model), categoryName), nil
+}
+
+// List возвращает продукты по фильтру с keyset-пагинацией по полю сортировки и ID.
+func (p *ProductRepo) List(ctx context.Context, q *usecase.ListProductsQuery) ([]usecase.ProductDetails, error) {
+	sortExpr, sortType := productSortColumn(q.SortBy)
+
+	conds := make([]string, 0, 9)
+	args := make([]any, 0, 11)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	f := q.Filter
+	if f.CategoryID != nil {
+		if f.IncludeSubcategories {
+			conds = append(conds, "pr.category_id IN ("+categorySubtreeQuery(arg(*f.CategoryID))+")")
+		} else {
+			conds = append(conds, "pr.category_id = "+arg(*f.CategoryID))
+		}
+	}
+	if f.Currency != nil {
+		conds = append(conds, "pr.currency = "+arg(string(*f.Currency)))
+	}
+	if f.MinPrice != nil {
+		conds = append(conds, "pr.price >= "+arg(*f.MinPrice))
+	}
+	if f.MaxPrice != nil {
+		conds = append(conds, "pr.price <= "+arg(*f.MaxPrice))
+	}
+	if f.Archived != nil {
+		conds = append(conds, "pr.is_archived = "+arg(*f.Archived))
+	}
+	if f.Status != nil {
+		conds = append(conds, "pr.status = "+arg(string(*f.Status)))
+	}
+	if f.CreatedFrom != nil {
+		conds = append(conds, "pr.created_at >= "+arg(*f.CreatedFrom))
+	}
+	if f.CreatedTo != nil {
+		conds = append(conds, "pr.created_at < "+arg(*f.CreatedTo))
+	}
+	if f.UpdatedFrom != nil {
+		conds = append(conds, "COALESCE(pr.updated_at, pr.created_at) >= "+arg(*f.UpdatedFrom))
+	}
+	if f.UpdatedTo != nil {
+		conds = append(conds, "COALESCE(pr.updated_at, pr.created_at) < "+arg(*f.UpdatedTo))
+	}
+
+	direction, cmp := "ASC", ">"
+	if q.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if q.After != nil {
+		if q.SortBy == usecase.SortByID {
+			conds = append(conds, fmt.Sprintf("pr.id %s %s", cmp, arg(q.After.ID)))
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s, pr.id) %s (%s::%s, %s)", sortExpr, cmp, arg(q.After.Value), sortType, arg(q.After.ID)))
+		}
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	orderBy := fmt.Sprintf("%s %s, pr.id %s", sortExpr, direction, direction)
+	if q.SortBy == usecase.SortByID {
+		orderBy = "pr.id " + direction
+	}
+
+	query := fmt.Sprintf(`
 		SELECT
-			id, name, price, category_id, created_at, updated_at, is_archived,
-			true AS no_changes
+			pr.id, pr.name, pr.price, pr.currency, pr.sku, pr.unit, pr.category_id, pr.localized_names, pr.attributes, pr.variant_group_id, pr.created_at, pr.updated_at, pr.is_archived, pr.status, pr.version,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = pr.id ORDER BY b.barcode), cat.name
+		FROM products pr
+		JOIN categories cat ON pr.category_id = cat.id
+		%s
+		ORDER BY %s
+		LIMIT %s
+	`, where, orderBy, arg(q.Limit))
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	result := make([]usecase.ProductDetails, 0, q.Limit)
+	for rows.Next() {
+		var model converter.ProductModel
+		var categoryName string
+		if err := rows.Scan(
+			&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+			&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version, &model.Barcodes, &categoryName,
+		); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+
+		result = append(result, *usecase.NewProductDetails(p.conv.ToEntity(&model), categoryName))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return result, nil
+}
+
+// GetForUpdate возвращает продукт, блокируя запись до конца транзакции.
+func (p *ProductRepo) GetForUpdate(ctx context.Context, id int64) (*domain.Product, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		SELECT
+			id, name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, created_at, updated_at, is_archived, status, version,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = products.id ORDER BY b.barcode)
 		FROM products
-		WHERE name = $1
-		  AND NOT EXISTS (SELECT 1 FROM upsert);
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var model converter.ProductModel
+	err = tx.QueryRow(ctx, query, id).
+		Scan(
+			&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+			&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version, &model.Barcodes,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(&model), nil
+}
+
+// Update изменяет название, цену, артикул, единицу измерения, категорию, атрибуты и группу вариантов продукта по ID
+// и увеличивает его версию.
+// Штрихкоды изменяются отдельно через SetBarcodes.
+func (p *ProductRepo) Update(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	// $1 id, $2 name, $3 price, $4 currency, $5 sku, $6 unit, $7 category_id, $8 localized_names, $9 attributes, $10 variant_group_id
+	query := `
+		UPDATE products
+		SET
+			name = $2, price = $3, currency = $4, sku = $5, unit = $6, category_id = $7,
+			localized_names = $8, attributes = $9, variant_group_id = $10,
+			updated_at = NOW(), version = version + 1
+		WHERE id = $1
+		RETURNING
+			id, name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, created_at, updated_at, is_archived, status, version,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = products.id ORDER BY b.barcode)
+	`
+
+	model := p.conv.ToModel(product)
+	err = tx.QueryRow(ctx, query,
+		model.ID, model.Name, model.Price, model.Currency, model.SKU, model.Unit, model.CategoryID, model.LocalizedNames, model.Attributes, model.VariantGroupID,
+	).Scan(
+		&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+		&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+		&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version, &model.Barcodes,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		if constraint, ok := postgresUniqueViolation(err); ok {
+			if constraint == productSKUConstraint {
+				return nil, e.Wrap(whereami.WhereAmI(), e.ErrSKUTaken)
+			}
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNameTaken)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(model), nil
+}
+
+// SetStatus переводит продукт в статус status. Признак архивации обновляется вместе со статусом.
+// Допустимость перехода проверяется в usecase.
+func (p *ProductRepo) SetStatus(ctx context.Context, id int64, status domain.ProductStatus) (*domain.Product, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	// $1 id, $2 status
+	query := `
+		UPDATE products
+		SET
+			status = $2, is_archived = ($2 = 'archived'), updated_at = NOW(), version = version + 1
+		WHERE id = $1
+		RETURNING
+			id, name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id, created_at, updated_at, is_archived, status, version,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = products.id ORDER BY b.barcode)
 	`
 
 	var model converter.ProductModel
-	var noChanges bool
-	err = tx.QueryRow(ctx, query, product.Name, product.Price, product.CategoryID).
+	err = tx.QueryRow(ctx, query, id, string(status)).
 		Scan(
-			&model.ID, &model.Name, &model.Price, &model.CategoryID,
-			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &noChanges,
+			&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+			&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version, &model.Barcodes,
 		)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
 		return nil, e.Wrap(whereami.WhereAmI(), err)
 	}
 
-	return usecase.NewUpsertProductRes(p.conv.ToEntity(&model), noChanges), nil
+	return p.conv.ToEntity(&model), nil
 }
 
-// GetProductsInfo возвращает информацию о продуктах по их идентификаторам, включая название категории.
+// IncrementVersion увеличивает версию продукта при изменении, не затрагивающем его поля, например набора изображений.
+func (p *ProductRepo) IncrementVersion(ctx context.Context, id int64) (int64, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	var version int64
+	err = tx.QueryRow(ctx, `UPDATE products SET version = version + 1 WHERE id = $1 RETURNING version`, id).Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return version, nil
+}
+
+// Delete безвозвратно удаляет продукт и возвращает его последнюю версию.
+func (p *ProductRepo) Delete(ctx context.Context, id int64) (int64, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	var version int64
+	err = tx.QueryRow(ctx, `DELETE FROM products WHERE id = $1 RETURNING version`, id).Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return version, nil
+}
+
+// GetProductsInfo возвращает информацию о неархивных продуктах по их идентификаторам, включая название категории.
 func (p *ProductRepo) GetProductsInfo(ctx context.Context, ids []int64) ([]usecase.ProductInfo, error) {
+	// Путь категории строится снизу вверх от категорий запрошенных продуктов до корня
 	query := `
-		SELECT pr.id, pr.name, pr.price, cat.name
+		WITH RECURSIVE path AS (
+			SELECT id AS leaf_id, parent_id, ARRAY[name::text] AS names
+			FROM categories
+			WHERE id IN (SELECT category_id FROM products WHERE id = ANY($1))
+			UNION ALL
+			SELECT path.leaf_id, cat.parent_id, cat.name::text || path.names
+			FROM path
+			JOIN categories cat ON cat.id = path.parent_id
+		)
+		SELECT
+			pr.id, pr.name, pr.price, pr.currency, pr.sku, pr.unit, pr.localized_names, pr.attributes, pr.variant_group_id, pr.version, cat.name, path.names,
+			ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = pr.id ORDER BY b.barcode)
 		FROM products pr
 		JOIN categories cat ON pr.category_id = cat.id
+		JOIN path ON path.leaf_id = pr.category_id AND path.parent_id IS NULL
 		WHERE pr.id = ANY($1)
+		  AND pr.status = 'active'
 	`
 
 	rows, err := p.pool.Query(ctx, query, ids)
@@ -96,12 +426,81 @@ func (p *ProductRepo) GetProductsInfo(ctx context.Context, ids []int64) ([]useca
 	result := make([]usecase.ProductInfo, 0)
 	for rows.Next() {
 		var product usecase.ProductInfo
-		if err := rows.Scan(&product.ID, &product.Name, &product.Price, &product.CategoryName); err != nil {
+		var currency, unit string
+		if err := rows.Scan(
+			&product.ID, &product.Name, &product.Price.Amount, &currency, &product.SKU, &unit,
+			&product.LocalizedNames, &product.Attributes, &product.VariantGroupID, &product.Version,
+			&product.CategoryName, &product.CategoryPath, &product.Barcodes,
+		); err != nil {
 			return nil, e.Wrap(whereami.WhereAmI(), err)
 		}
+		product.Price.Currency = domain.Currency(currency)
+		product.Unit = domain.Unit(unit)
 
 		result = append(result, product)
 	}
 
 	return result, nil
 }
+
+// SetBarcodes заменяет штрихкоды продукта. Штрихкод, назначенный другому продукту, возвращает ErrBarcodeTaken.
+func (p *ProductRepo) SetBarcodes(ctx context.Context, productID int64, barcodes []string) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM product_barcodes WHERE product_id = $1`, productID); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	// $1 product_id, $2 barcodes
+	query := `
+		INSERT INTO product_barcodes (barcode, product_id)
+		SELECT barcode, $1
+		FROM unnest($2::text[]) AS barcode
+	`
+
+	if _, err := tx.Exec(ctx, query, productID, barcodes); err != nil {
+		if postgresDuplicate(err) {
+			return e.Wrap(whereami.WhereAmI(), e.ErrBarcodeTaken)
+		}
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// GetIDByBarcode возвращает ID продукта, включая архивный, по штрихкоду.
+func (p *ProductRepo) GetIDByBarcode(ctx context.Context, barcode string) (int64, error) {
+	var id int64
+	err := p.pool.QueryRow(ctx, `SELECT product_id FROM product_barcodes WHERE barcode = $1`, barcode).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+		}
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return id, nil
+}
+
+// productSortColumn возвращает выражение и тип PostgreSQL для поля сортировки продуктов.
+func productSortColumn(sortBy usecase.ProductSortField) (string, string) {
+	switch sortBy {
+	case usecase.SortByName:
+		return "pr.name", "text"
+	case usecase.SortByPrice:
+		return "pr.price", "bigint"
+	case usecase.SortByCreatedAt:
+		return "pr.created_at", "timestamp"
+	case usecase.SortByUpdatedAt:
+		return "COALESCE(pr.updated_at, pr.created_at)", "timestamp"
+	default:
+		return "pr.id", "bigint"
+	}
+}
@@ -0,0 +1,79 @@
+package pgdb
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+const productTransitionColumns = `id, product_id, from_status, to_status, reviewer, comment, created_at`
+
+// ProductTransitionRepo реализует хранение истории статусов продуктов поверх PostgreSQL.
+type ProductTransitionRepo struct {
+	pool *pgxpool.Pool
+	conv converter.ProductTransitionConverter
+}
+
+func NewProductTransitionRepo(pool *pgxpool.Pool, conv converter.ProductTransitionConverter) *ProductTransitionRepo {
+	return &ProductTransitionRepo{pool: pool, conv: conv}
+}
+
+// Create сохраняет переход продукта между статусами.
+func (p *ProductTransitionRepo) Create(ctx context.Context, transition *domain.ProductTransition) (*domain.ProductTransition, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	// $1 product_id, $2 from_status, $3 to_status, $4 reviewer, $5 comment
+	query := `
+		INSERT INTO product_transitions (product_id, from_status, to_status, reviewer, comment)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + productTransitionColumns
+
+	model := p.conv.ToModel(transition)
+	row := tx.QueryRow(ctx, query, model.ProductID, model.FromStatus, model.ToStatus, model.Reviewer, model.Comment)
+	if err := scanProductTransition(row, model); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(model), nil
+}
+
+// ListByProduct возвращает историю статусов продукта в порядке переходов.
+func (p *ProductTransitionRepo) ListByProduct(ctx context.Context, productID int64) ([]*domain.ProductTransition, error) {
+	query := `SELECT ` + productTransitionColumns + ` FROM product_transitions WHERE product_id = $1 ORDER BY id`
+
+	rows, err := p.pool.Query(ctx, query, productID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.ProductTransitionModel, 0)
+	for rows.Next() {
+		var model converter.ProductTransitionModel
+		if err := scanProductTransition(rows, &model); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToArrEntity(models), nil
+}
+
+func scanProductTransition(row pgx.Row, model *converter.ProductTransitionModel) error {
+	return row.Scan(
+		&model.ID, &model.ProductID, &model.FromStatus, &model.ToStatus, &model.Reviewer, &model.Comment, &model.CreatedAt,
+	)
+}
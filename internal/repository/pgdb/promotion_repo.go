@@ -0,0 +1,219 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/internal/usecase"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+const (
+	promotionProductConstraint  = "fk_promotions_product"
+	promotionCategoryConstraint = "fk_promotions_category"
+
+	promotionColumns = `pr.id, pr.name, pr.kind, pr.product_id, pr.category_id, pr.percent, pr.amount, pr.currency,
+		pr.buy_quantity, pr.pay_quantity, pr.priority, pr.starts_at, pr.ends_at, pr.is_active, pr.created_at, pr.updated_at`
+)
+
+// PromotionRepo реализует хранение акций поверх PostgreSQL.
+type PromotionRepo struct {
+	pool *pgxpool.Pool
+	conv converter.PromotionConverter
+}
+
+func NewPromotionRepo(pool *pgxpool.Pool, conv converter.PromotionConverter) *PromotionRepo {
+	return &PromotionRepo{pool: pool, conv: conv}
+}
+
+// Create сохраняет акцию. Отсутствующие продукт или категория акции приводят к ошибке "не найден".
+func (p *PromotionRepo) Create(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO promotions AS pr (name, kind, product_id, category_id, percent, amount, currency,
+			buy_quantity, pay_quantity, priority, starts_at, ends_at, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING ` + promotionColumns
+
+	model := p.conv.ToModel(promotion)
+	row := tx.QueryRow(ctx, query,
+		model.Name, model.Kind, model.ProductID, model.CategoryID, model.Percent, model.Amount, model.Currency,
+		model.BuyQuantity, model.PayQuantity, model.Priority, model.StartsAt, model.EndsAt, model.IsActive,
+	)
+	if err := scanPromotion(row, model); err != nil {
+		if constraint, ok := postgresForeignKeyViolation(err); ok {
+			switch constraint {
+			case promotionProductConstraint:
+				return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+			case promotionCategoryConstraint:
+				return nil, e.Wrap(whereami.WhereAmI(), e.ErrCategoryNotFound)
+			}
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(model), nil
+}
+
+// GetByID возвращает акцию по ID.
+func (p *PromotionRepo) GetByID(ctx context.Context, id int64) (*domain.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions pr WHERE pr.id = $1`
+
+	var model converter.PromotionModel
+	if err := scanPromotion(p.pool.QueryRow(ctx, query, id), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrPromotionNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToEntity(&model), nil
+}
+
+// List возвращает акции по возрастанию ID: все или только действующие в момент activeAt.
+func (p *PromotionRepo) List(ctx context.Context, activeAt *time.Time) ([]*domain.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions pr ORDER BY pr.id`
+	args := []any{}
+	if activeAt != nil {
+		query = `SELECT ` + promotionColumns + ` FROM promotions pr WHERE ` + promotionActiveCondition("$1") + ` ORDER BY pr.id`
+		args = append(args, *activeAt)
+	}
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	models, err := collectPromotions(rows, nil)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return p.conv.ToArrEntity(models), nil
+}
+
+// Delete удаляет акцию.
+func (p *PromotionRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM promotions WHERE id = $1`, id)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(whereami.WhereAmI(), e.ErrPromotionNotFound)
+	}
+
+	return nil
+}
+
+// ListApplicable возвращает акции, действующие в момент at, для каждого из продуктов:
+// акции на сам продукт и на его категорию или любую из категорий-предков.
+func (p *PromotionRepo) ListApplicable(ctx context.Context, productIDs []int64, at time.Time) ([]usecase.PromotionMatch, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT p.id AS product_id, p.category_id
+			FROM products p
+			WHERE p.id = ANY($1)
+			UNION ALL
+			SELECT a.product_id, c.parent_id
+			FROM ancestors a
+			JOIN categories c ON c.id = a.category_id
+			WHERE c.parent_id IS NOT NULL
+		)
+		SELECT a.product_id, ` + promotionColumns + `
+		FROM ancestors a
+		JOIN promotions pr ON pr.category_id = a.category_id
+		WHERE ` + promotionActiveCondition("$2") + `
+		UNION ALL
+		SELECT pr.product_id, ` + promotionColumns + `
+		FROM promotions pr
+		WHERE pr.product_id = ANY($1) AND ` + promotionActiveCondition("$2") + `
+		ORDER BY 1, 2
+	`
+
+	rows, err := p.pool.Query(ctx, query, productIDs, at)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	productIDsByRow := make([]int64, 0)
+	models, err := collectPromotions(rows, &productIDsByRow)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	matches := make([]usecase.PromotionMatch, 0, len(models))
+	for i, promotion := range p.conv.ToArrEntity(models) {
+		matches = append(matches, usecase.NewPromotionMatch(productIDsByRow[i], promotion))
+	}
+
+	return matches, nil
+}
+
+// promotionActiveCondition возвращает условие, отбирающее акции, действующие в момент из параметра param.
+func promotionActiveCondition(param string) string {
+	return `pr.is_active
+		AND (pr.starts_at IS NULL OR pr.starts_at <= ` + param + `)
+		AND (pr.ends_at IS NULL OR pr.ends_at > ` + param + `)`
+}
+
+func scanPromotion(row pgx.Row, model *converter.PromotionModel) error {
+	return row.Scan(
+		&model.ID, &model.Name, &model.Kind, &model.ProductID, &model.CategoryID, &model.Percent, &model.Amount,
+		&model.Currency, &model.BuyQuantity, &model.PayQuantity, &model.Priority, &model.StartsAt, &model.EndsAt,
+		&model.IsActive, &model.CreatedAt, &model.UpdatedAt,
+	)
+}
+
+// collectPromotions читает строки акций. Если productIDs не nil, первым столбцом строки ожидается ID продукта.
+func collectPromotions(rows pgx.Rows, productIDs *[]int64) ([]*converter.PromotionModel, error) {
+	defer rows.Close()
+
+	models := make([]*converter.PromotionModel, 0)
+	for rows.Next() {
+		var (
+			model     converter.PromotionModel
+			productID int64
+		)
+
+		dest := []any{
+			&model.ID, &model.Name, &model.Kind, &model.ProductID, &model.CategoryID, &model.Percent, &model.Amount,
+			&model.Currency, &model.BuyQuantity, &model.PayQuantity, &model.Priority, &model.StartsAt, &model.EndsAt,
+			&model.IsActive, &model.CreatedAt, &model.UpdatedAt,
+		}
+		if productIDs != nil {
+			dest = append([]any{&productID}, dest...)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		if productIDs != nil {
+			*productIDs = append(*productIDs, productID)
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
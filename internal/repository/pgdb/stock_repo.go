@@ -0,0 +1,263 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+const (
+	stockLevelStoreConstraint   = "fk_stock_levels_store"
+	stockLevelProductConstraint = "fk_stock_levels_product"
+
+	// stockLevelQuery выбирает остатки вместе с суммой активных непросроченных резервов
+	stockLevelQuery = `
+		SELECT l.store_id, l.product_id, l.on_hand,
+			COALESCE((
+				SELECT SUM(r.quantity) FROM stock_reservations r
+				WHERE r.store_id = l.store_id AND r.product_id = l.product_id
+					AND r.status = 'active' AND r.expires_at > NOW()
+			), 0)::BIGINT AS reserved,
+			l.updated_at
+		FROM stock_levels l`
+
+	stockReservationColumns = `id, store_id, product_id, quantity, status, expires_at, created_at, updated_at`
+)
+
+// StockRepo реализует хранение остатков и резервов поверх PostgreSQL.
+type StockRepo struct {
+	pool *pgxpool.Pool
+	conv converter.StockConverter
+}
+
+func NewStockRepo(pool *pgxpool.Pool, conv converter.StockConverter) *StockRepo {
+	return &StockRepo{pool: pool, conv: conv}
+}
+
+// GetLevel возвращает остаток товара в магазине.
+func (s *StockRepo) GetLevel(ctx context.Context, storeID int64, productID int64) (*domain.StockLevel, error) {
+	query := stockLevelQuery + ` WHERE l.store_id = $1 AND l.product_id = $2`
+
+	var model converter.StockLevelModel
+	if err := scanStockLevel(s.pool.QueryRow(ctx, query, storeID, productID), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrStockNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToLevelEntity(&model), nil
+}
+
+// GetLevels возвращает остатки перечисленных товаров в магазине. Товары без учёта остатка в результат не попадают.
+func (s *StockRepo) GetLevels(ctx context.Context, storeID int64, productIDs []int64) ([]*domain.StockLevel, error) {
+	query := stockLevelQuery + ` WHERE l.store_id = $1 AND l.product_id = ANY($2)`
+
+	rows, err := s.pool.Query(ctx, query, storeID, productIDs)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	models, err := collectStockLevels(rows)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToArrLevelEntity(models), nil
+}
+
+// ListLevels возвращает остатки магазина по возрастанию ID товара.
+func (s *StockRepo) ListLevels(ctx context.Context, storeID int64) ([]*domain.StockLevel, error) {
+	query := stockLevelQuery + ` WHERE l.store_id = $1 ORDER BY l.product_id`
+
+	rows, err := s.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	models, err := collectStockLevels(rows)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToArrLevelEntity(models), nil
+}
+
+// GetLevelForUpdate возвращает остаток товара в магазине и блокирует его до конца транзакции.
+func (s *StockRepo) GetLevelForUpdate(ctx context.Context, storeID int64, productID int64) (*domain.StockLevel, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := stockLevelQuery + ` WHERE l.store_id = $1 AND l.product_id = $2 FOR UPDATE OF l`
+
+	var model converter.StockLevelModel
+	if err := scanStockLevel(tx.QueryRow(ctx, query, storeID, productID), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrStockNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToLevelEntity(&model), nil
+}
+
+// SetOnHand задаёт фактический остаток и начинает учёт остатка товара в магазине, если он не вёлся.
+// Отсутствующие магазин или товар приводят к ошибке "не найден".
+func (s *StockRepo) SetOnHand(ctx context.Context, storeID int64, productID int64, onHand int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO stock_levels (store_id, product_id, on_hand)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (store_id, product_id) DO UPDATE
+		SET on_hand = EXCLUDED.on_hand,
+			updated_at = NOW()`
+
+	if _, err := tx.Exec(ctx, query, storeID, productID, onHand); err != nil {
+		if constraint, ok := postgresForeignKeyViolation(err); ok {
+			switch constraint {
+			case stockLevelStoreConstraint:
+				return e.Wrap(whereami.WhereAmI(), e.ErrStoreNotFound)
+			case stockLevelProductConstraint:
+				return e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+			}
+		}
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// AddOnHand изменяет фактический остаток на delta. Остаток не может стать отрицательным.
+func (s *StockRepo) AddOnHand(ctx context.Context, storeID int64, productID int64, delta int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		UPDATE stock_levels
+		SET on_hand = on_hand + $3, updated_at = NOW()
+		WHERE store_id = $1 AND product_id = $2 AND on_hand + $3 >= 0`
+
+	tag, err := tx.Exec(ctx, query, storeID, productID, delta)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(whereami.WhereAmI(), e.ErrInsufficientStock)
+	}
+
+	return nil
+}
+
+// CreateReservation сохраняет резерв остатка.
+func (s *StockRepo) CreateReservation(ctx context.Context, reservation *domain.StockReservation) (*domain.StockReservation, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO stock_reservations (id, store_id, product_id, quantity, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + stockReservationColumns
+
+	model := s.conv.ToReservationModel(reservation)
+	row := tx.QueryRow(ctx, query, model.ID, model.StoreID, model.ProductID, model.Quantity, model.Status, model.ExpiresAt)
+	if err := scanStockReservation(row, model); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToReservationEntity(model), nil
+}
+
+// GetReservationForUpdate возвращает резерв и блокирует его до конца транзакции.
+func (s *StockRepo) GetReservationForUpdate(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `SELECT ` + stockReservationColumns + ` FROM stock_reservations WHERE id = $1 FOR UPDATE`
+
+	var model converter.StockReservationModel
+	if err := scanStockReservation(tx.QueryRow(ctx, query, id), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrReservationNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToReservationEntity(&model), nil
+}
+
+// UpdateReservationStatus переводит резерв в новое состояние.
+func (s *StockRepo) UpdateReservationStatus(ctx context.Context, id uuid.UUID, status domain.ReservationStatus) (*domain.StockReservation, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `UPDATE stock_reservations SET status = $2, updated_at = NOW() WHERE id = $1 RETURNING ` + stockReservationColumns
+
+	var model converter.StockReservationModel
+	if err := scanStockReservation(tx.QueryRow(ctx, query, id, string(status)), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrReservationNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToReservationEntity(&model), nil
+}
+
+func scanStockLevel(row pgx.Row, model *converter.StockLevelModel) error {
+	return row.Scan(&model.StoreID, &model.ProductID, &model.OnHand, &model.Reserved, &model.UpdatedAt)
+}
+
+func scanStockReservation(row pgx.Row, model *converter.StockReservationModel) error {
+	return row.Scan(
+		&model.ID,
+		&model.StoreID,
+		&model.ProductID,
+		&model.Quantity,
+		&model.Status,
+		&model.ExpiresAt,
+		&model.CreatedAt,
+		&model.UpdatedAt,
+	)
+}
+
+func collectStockLevels(rows pgx.Rows) ([]*converter.StockLevelModel, error) {
+	defer rows.Close()
+
+	models := make([]*converter.StockLevelModel, 0)
+	for rows.Next() {
+		var model converter.StockLevelModel
+		if err := scanStockLevel(rows, &model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
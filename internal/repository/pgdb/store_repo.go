@@ -0,0 +1,229 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+const (
+	storeCodeConstraint         = "uq_stores_code"
+	storeProductStoreConstraint = "fk_store_products_store"
+	storeProductConstraint      = "fk_store_products_product"
+
+	storeColumns        = `id, code, name, created_at, updated_at`
+	storeProductColumns = `store_id, product_id, is_available, price, currency, updated_at`
+)
+
+// StoreRepo реализует хранение магазинов и их ассортимента поверх PostgreSQL.
+type StoreRepo struct {
+	pool *pgxpool.Pool
+	conv converter.StoreConverter
+}
+
+func NewStoreRepo(pool *pgxpool.Pool, conv converter.StoreConverter) *StoreRepo {
+	return &StoreRepo{pool: pool, conv: conv}
+}
+
+// Create сохраняет магазин. Занятый код магазина приводит к ошибке e.ErrStoreCodeTaken.
+func (s *StoreRepo) Create(ctx context.Context, store *domain.Store) (*domain.Store, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `INSERT INTO stores (code, name) VALUES ($1, $2) RETURNING ` + storeColumns
+
+	var model converter.StoreModel
+	if err := scanStore(tx.QueryRow(ctx, query, store.Code, store.Name), &model); err != nil {
+		if constraint, ok := postgresUniqueViolation(err); ok && constraint == storeCodeConstraint {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrStoreCodeTaken)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToEntity(&model), nil
+}
+
+// GetByID возвращает магазин по ID.
+func (s *StoreRepo) GetByID(ctx context.Context, id int64) (*domain.Store, error) {
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE id = $1`
+
+	var model converter.StoreModel
+	if err := scanStore(s.pool.QueryRow(ctx, query, id), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrStoreNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToEntity(&model), nil
+}
+
+// List возвращает все магазины по возрастанию ID.
+func (s *StoreRepo) List(ctx context.Context) ([]*domain.Store, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+storeColumns+` FROM stores ORDER BY id`)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.StoreModel, 0)
+	for rows.Next() {
+		var model converter.StoreModel
+		if err := scanStore(rows, &model); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToArrEntity(models), nil
+}
+
+// SetProduct добавляет продукт в ассортимент магазина или изменяет его доступность и цену.
+// Отсутствующие магазин или продукт приводят к ошибке "не найден".
+func (s *StoreRepo) SetProduct(ctx context.Context, product *domain.StoreProduct) (*domain.StoreProduct, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `
+		INSERT INTO store_products (store_id, product_id, is_available, price, currency)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (store_id, product_id) DO UPDATE
+		SET is_available = EXCLUDED.is_available,
+			price = EXCLUDED.price,
+			currency = EXCLUDED.currency,
+			updated_at = NOW()
+		RETURNING ` + storeProductColumns
+
+	model := s.conv.ToProductModel(product)
+	row := tx.QueryRow(ctx, query, model.StoreID, model.ProductID, model.IsAvailable, model.Price, model.Currency)
+	if err := scanStoreProduct(row, model); err != nil {
+		if constraint, ok := postgresForeignKeyViolation(err); ok {
+			switch constraint {
+			case storeProductStoreConstraint:
+				return nil, e.Wrap(whereami.WhereAmI(), e.ErrStoreNotFound)
+			case storeProductConstraint:
+				return nil, e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+			}
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToProductEntity(model), nil
+}
+
+// DeleteProduct исключает продукт из ассортимента магазина.
+func (s *StoreRepo) DeleteProduct(ctx context.Context, storeID int64, productID int64) error {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM store_products WHERE store_id = $1 AND product_id = $2`, storeID, productID)
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.Wrap(whereami.WhereAmI(), e.ErrProductNotFound)
+	}
+
+	return nil
+}
+
+// GetProducts возвращает записи ассортимента магазина для перечисленных продуктов.
+// Продукты вне ассортимента в результат не попадают.
+func (s *StoreRepo) GetProducts(ctx context.Context, storeID int64, productIDs []int64) ([]*domain.StoreProduct, error) {
+	query := `SELECT ` + storeProductColumns + ` FROM store_products WHERE store_id = $1 AND product_id = ANY($2)`
+
+	rows, err := s.pool.Query(ctx, query, storeID, productIDs)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	models, err := collectStoreProducts(rows)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToArrProductEntity(models), nil
+}
+
+// ListProducts возвращает ассортимент магазина по возрастанию ID продукта.
+func (s *StoreRepo) ListProducts(ctx context.Context, storeID int64) ([]*domain.StoreProduct, error) {
+	query := `SELECT ` + storeProductColumns + ` FROM store_products WHERE store_id = $1 ORDER BY product_id`
+
+	rows, err := s.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	models, err := collectStoreProducts(rows)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return s.conv.ToArrProductEntity(models), nil
+}
+
+// ListProductStoreIDs возвращает по возрастанию ID магазинов, в которых продукт доступен для продажи.
+// Читает в транзакции из контекста, чтобы учесть её незафиксированные изменения ассортимента.
+func (s *StoreRepo) ListProductStoreIDs(ctx context.Context, productID int64) ([]int64, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	rows, err := tx.Query(ctx, `SELECT store_id FROM store_products WHERE product_id = $1 AND is_available ORDER BY store_id`, productID)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return ids, nil
+}
+
+func scanStore(row pgx.Row, model *converter.StoreModel) error {
+	return row.Scan(&model.ID, &model.Code, &model.Name, &model.CreatedAt, &model.UpdatedAt)
+}
+
+func scanStoreProduct(row pgx.Row, model *converter.StoreProductModel) error {
+	return row.Scan(&model.StoreID, &model.ProductID, &model.IsAvailable, &model.Price, &model.Currency, &model.UpdatedAt)
+}
+
+func collectStoreProducts(rows pgx.Rows) ([]*converter.StoreProductModel, error) {
+	defer rows.Close()
+
+	models := make([]*converter.StoreProductModel, 0)
+	for rows.Next() {
+		var model converter.StoreProductModel
+		if err := scanStoreProduct(rows, &model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
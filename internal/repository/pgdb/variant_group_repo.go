@@ -0,0 +1,154 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/pgdb/converter"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/tr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jimlawless/whereami"
+)
+
+const (
+	variantGroupNameConstraint = "uq_variant_groups_name"
+
+	variantGroupColumns = `id, name, axes, created_at, updated_at`
+)
+
+// VariantGroupRepo реализует хранение групп вариантов поверх PostgreSQL.
+// Принадлежность продукта группе хранится в products.variant_group_id.
+type VariantGroupRepo struct {
+	pool        *pgxpool.Pool
+	conv        converter.VariantGroupConverter
+	productConv converter.ProductConverter
+}
+
+func NewVariantGroupRepo(pool *pgxpool.Pool, conv converter.VariantGroupConverter, productConv converter.ProductConverter) *VariantGroupRepo {
+	return &VariantGroupRepo{pool: pool, conv: conv, productConv: productConv}
+}
+
+// Create сохраняет группу вариантов. Занятое название приводит к ошибке e.ErrVariantGroupNameTaken.
+func (v *VariantGroupRepo) Create(ctx context.Context, group *domain.VariantGroup) (*domain.VariantGroup, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `INSERT INTO variant_groups (name, axes) VALUES ($1, $2) RETURNING ` + variantGroupColumns
+
+	model := v.conv.ToModel(group)
+	if err := scanVariantGroup(tx.QueryRow(ctx, query, model.Name, model.Axes), model); err != nil {
+		if constraint, ok := postgresUniqueViolation(err); ok && constraint == variantGroupNameConstraint {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrVariantGroupNameTaken)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return v.conv.ToEntity(model), nil
+}
+
+// GetByID возвращает группу вариантов по ID.
+func (v *VariantGroupRepo) GetByID(ctx context.Context, id int64) (*domain.VariantGroup, error) {
+	query := `SELECT ` + variantGroupColumns + ` FROM variant_groups WHERE id = $1`
+
+	var model converter.VariantGroupModel
+	if err := scanVariantGroup(v.pool.QueryRow(ctx, query, id), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrVariantGroupNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return v.conv.ToEntity(&model), nil
+}
+
+// GetForUpdate возвращает группу вариантов, блокируя запись до конца транзакции.
+// Блокировка упорядочивает параллельные изменения состава группы.
+func (v *VariantGroupRepo) GetForUpdate(ctx context.Context, id int64) (*domain.VariantGroup, error) {
+	tx, err := tr.TxFromCtx(ctx)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	query := `SELECT ` + variantGroupColumns + ` FROM variant_groups WHERE id = $1 FOR UPDATE`
+
+	var model converter.VariantGroupModel
+	if err := scanVariantGroup(tx.QueryRow(ctx, query, id), &model); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, e.Wrap(whereami.WhereAmI(), e.ErrVariantGroupNotFound)
+		}
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return v.conv.ToEntity(&model), nil
+}
+
+// List возвращает все группы вариантов по возрастанию ID.
+func (v *VariantGroupRepo) List(ctx context.Context) ([]*domain.VariantGroup, error) {
+	rows, err := v.pool.Query(ctx, `SELECT `+variantGroupColumns+` FROM variant_groups ORDER BY id`)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	models := make([]*converter.VariantGroupModel, 0)
+	for rows.Next() {
+		var model converter.VariantGroupModel
+		if err := scanVariantGroup(rows, &model); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		models = append(models, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return v.conv.ToArrEntity(models), nil
+}
+
+// ListProducts возвращает продукты группы вариантов, включая архивные, по возрастанию ID.
+// Штрихкоды продуктов не заполняются.
+func (v *VariantGroupRepo) ListProducts(ctx context.Context, id int64) ([]*domain.Product, error) {
+	query := `
+		SELECT
+			id, name, price, currency, sku, unit, category_id, localized_names, attributes, variant_group_id,
+			created_at, updated_at, is_archived, status, version
+		FROM products
+		WHERE variant_group_id = $1
+		ORDER BY id
+	`
+
+	rows, err := v.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+	defer rows.Close()
+
+	products := make([]*domain.Product, 0)
+	for rows.Next() {
+		var model converter.ProductModel
+		if err := rows.Scan(
+			&model.ID, &model.Name, &model.Price, &model.Currency, &model.SKU, &model.Unit, &model.CategoryID,
+			&model.LocalizedNames, &model.Attributes, &model.VariantGroupID,
+			&model.CreatedAt, &model.UpdatedAt, &model.IsArchived, &model.Status, &model.Version,
+		); err != nil {
+			return nil, e.Wrap(whereami.WhereAmI(), err)
+		}
+		products = append(products, v.productConv.ToEntity(&model))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return products, nil
+}
+
+func scanVariantGroup(row pgx.Row, model *converter.VariantGroupModel) error {
+	return row.Scan(&model.ID, &model.Name, &model.Axes, &model.CreatedAt, &model.UpdatedAt)
+}
@@ -2,6 +2,7 @@ package qdrant
 
 import (
 	"context"
+	"slices"
 
 	"github.com/DRSN-tech/go-backend/internal/cfg"
 	"github.com/DRSN-tech/go-backend/internal/domain"
@@ -10,6 +11,9 @@ import (
 	"github.com/qdrant/go-client/qdrant"
 )
 
+// scrollLimit — макс. кол-во векторов одного продукта, читаемых за запрос
+const scrollLimit = 1000
+
 // EmbeddingRepo репозиторий для работы с embedding-векторами в Qdrant
 type EmbeddingRepo struct {
 	client *qdrant.Client
@@ -67,3 +71,222 @@ func (q *EmbeddingRepo) Delete(ctx context.Context, vectors []domain.Embedding)
 
 	return nil
 }
+
+// Get возвращает вектор по ID вместе с payload.
+func (q *EmbeddingRepo) Get(ctx context.Context, id string) (*domain.Embedding, error) {
+	points, err := q.client.Get(ctx, &qdrant.GetPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Ids:            []*qdrant.PointId{qdrant.NewIDUUID(id)},
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    qdrant.NewWithVectors(true),
+	})
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if len(points) == 0 {
+		return nil, e.Wrap(whereami.WhereAmI(), e.ErrImageNotFound)
+	}
+
+	return &toEmbeddings(points)[0], nil
+}
+
+// GetByProduct возвращает все векторы продукта вместе с payload.
+func (q *EmbeddingRepo) GetByProduct(ctx context.Context, productID int64) ([]domain.Embedding, error) {
+	points, err := q.client.Scroll(ctx, &qdrant.ScrollPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Filter:         productFilter(productID),
+		Limit:          qdrant.PtrOf(uint32(scrollLimit)),
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    qdrant.NewWithVectors(true),
+	})
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return toEmbeddings(points), nil
+}
+
+// CountByProduct возвращает точное кол-во векторов продукта.
+func (q *EmbeddingRepo) CountByProduct(ctx context.Context, productID int64) (int, error) {
+	count, err := q.client.Count(ctx, &qdrant.CountPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Filter:         productFilter(productID),
+		Exact:          qdrant.PtrOf(true),
+	})
+	if err != nil {
+		return 0, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return int(count), nil
+}
+
+// DeleteByProduct удаляет все векторы продукта.
+func (q *EmbeddingRepo) DeleteByProduct(ctx context.Context, productID int64) error {
+	if _, err := q.client.Delete(ctx, &qdrant.DeletePoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Points:         qdrant.NewPointsSelectorFilter(productFilter(productID)),
+	}); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// SetStatus задаёт векторам продукта его статус, векторы неопубликованных и архивных продуктов исключаются из поиска.
+func (q *EmbeddingRepo) SetStatus(ctx context.Context, productID int64, status domain.ProductStatus) error {
+	payload := domain.Payload{}
+	payload.SetStatus(status)
+
+	if _, err := q.client.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Payload:        qdrant.NewValueMap(payload),
+		PointsSelector: qdrant.NewPointsSelectorFilter(productFilter(productID)),
+	}); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// SetStores заменяет у векторов продукта список магазинов, в которых продукт распознаётся.
+func (q *EmbeddingRepo) SetStores(ctx context.Context, productID int64, storeIDs []int64) error {
+	payload := domain.Payload{}
+	payload.SetStoreIDs(storeIDs)
+
+	if _, err := q.client.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Payload:        qdrant.NewValueMap(payload),
+		PointsSelector: qdrant.NewPointsSelectorFilter(productFilter(productID)),
+	}); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// SetVariant заменяет у векторов продукта атрибуты и группу вариантов, по которым фильтруется распознавание.
+func (q *EmbeddingRepo) SetVariant(ctx context.Context, productID int64, attrs domain.Attributes, variantGroupID *int64) error {
+	payload := domain.Payload{}
+	payload.SetVariant(attrs, variantGroupID)
+
+	if _, err := q.client.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Payload:        qdrant.NewValueMap(payload),
+		PointsSelector: qdrant.NewPointsSelectorFilter(productFilter(productID)),
+	}); err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// Search выполняет поиск ближайших соседей для вектора запроса и возвращает найденные точки с их payload.
+// Непустой productIDs ограничивает поиск векторами перечисленных продуктов, storeID — ассортиментом магазина,
+// attrs — продуктами с указанными значениями атрибутов.
+func (q *EmbeddingRepo) Search(
+	ctx context.Context,
+	vector []float32,
+	limit uint64,
+	productIDs []int64,
+	storeID *int64,
+	attrs domain.Attributes,
+) ([]domain.SearchHit, error) {
+	points, err := q.client.Query(ctx, q.searchQuery(vector, limit, productIDs, storeID, attrs))
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return toSearchHits(points), nil
+}
+
+// SearchBatch выполняет поиск ближайших соседей для нескольких векторов одним запросом к Qdrant.
+// Результаты возвращаются в порядке векторов запроса.
+func (q *EmbeddingRepo) SearchBatch(
+	ctx context.Context,
+	vectors [][]float32,
+	limit uint64,
+	productIDs []int64,
+	storeID *int64,
+	attrs domain.Attributes,
+) ([][]domain.SearchHit, error) {
+	queries := make([]*qdrant.QueryPoints, 0, len(vectors))
+	for _, vector := range vectors {
+		queries = append(queries, q.searchQuery(vector, limit, productIDs, storeID, attrs))
+	}
+
+	results, err := q.client.QueryBatch(ctx, &qdrant.QueryBatchPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		QueryPoints:    queries,
+	})
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	hits := make([][]domain.SearchHit, 0, len(results))
+	for _, result := range results {
+		hits = append(hits, toSearchHits(result.GetResult()))
+	}
+
+	return hits, nil
+}
+
+// searchQuery формирует запрос поиска ближайших соседей среди векторов опубликованных продуктов.
+// Векторы без status записаны до появления статусов и относятся к опубликованным продуктам.
+// Векторы без store_ids не находятся при поиске в ассортименте магазина.
+func (q *EmbeddingRepo) searchQuery(vector []float32, limit uint64, productIDs []int64, storeID *int64, attrs domain.Attributes) *qdrant.QueryPoints {
+	filter := &qdrant.Filter{
+		MustNot: []*qdrant.Condition{
+			qdrant.NewMatchBool("is_archived", true),
+			qdrant.NewMatchKeywords("status", string(domain.ProductDraft), string(domain.ProductPendingReview)),
+		},
+	}
+	if len(productIDs) > 0 {
+		filter.Must = append(filter.Must, qdrant.NewMatchInts("product_id", productIDs...))
+	}
+	// store_ids — массив, условие выполняется, если в нём есть магазин запроса
+	if storeID != nil {
+		filter.Must = append(filter.Must, qdrant.NewMatchInt("store_ids", *storeID))
+	}
+	filter.Must = append(filter.Must, attributeConditions(attrs)...)
+
+	return &qdrant.QueryPoints{
+		CollectionName: q.cfg.QdrantCollectionName,
+		Query:          qdrant.NewQueryDense(vector),
+		Limit:          qdrant.PtrOf(limit),
+		Filter:         filter,
+		WithPayload:    qdrant.NewWithPayloadInclude("product_id", "image_path", "model_version"),
+	}
+}
+
+// attributeConditions формирует условия точного совпадения атрибутов из payload "attributes".
+// Коды сортируются, чтобы одинаковые фильтры давали одинаковые запросы.
+func attributeConditions(attrs domain.Attributes) []*qdrant.Condition {
+	codes := make([]string, 0, len(attrs))
+	for code := range attrs {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	conditions := make([]*qdrant.Condition, 0, len(codes))
+	for _, code := range codes {
+		field := "attributes." + code
+		switch v := attrs[code].(type) {
+		case bool:
+			conditions = append(conditions, qdrant.NewMatchBool(field, v))
+		case float64:
+			conditions = append(conditions, qdrant.NewRange(field, &qdrant.Range{Gte: &v, Lte: &v}))
+		case string:
+			conditions = append(conditions, qdrant.NewMatchKeyword(field, v))
+		}
+	}
+
+	return conditions
+}
+
+// productFilter формирует фильтр по идентификатору продукта.
+func productFilter(productID int64) *qdrant.Filter {
+	return &qdrant.Filter{
+		Must: []*qdrant.Condition{qdrant.NewMatchInt("product_id", productID)},
+	}
+}
@@ -0,0 +1,84 @@
+package qdrant
+
+import (
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// toDomainPayload преобразует payload точки Qdrant в domain.Payload.
+// Поддерживаются скалярные значения, списки скалярных значений и объекты со скалярными значениями (атрибуты продукта),
+// остальные типы пропускаются.
+func toDomainPayload(payload map[string]*qdrant.Value) domain.Payload {
+	result := make(domain.Payload, len(payload))
+	for key, value := range payload {
+		if object, ok := value.GetKind().(*qdrant.Value_StructValue); ok {
+			values := make(map[string]any, len(object.StructValue.GetFields()))
+			for field, item := range object.StructValue.GetFields() {
+				if v, ok := toScalar(item); ok {
+					values[field] = v
+				}
+			}
+			result[key] = values
+			continue
+		}
+
+		if list, ok := value.GetKind().(*qdrant.Value_ListValue); ok {
+			values := make([]any, 0, len(list.ListValue.GetValues()))
+			for _, item := range list.ListValue.GetValues() {
+				if v, ok := toScalar(item); ok {
+					values = append(values, v)
+				}
+			}
+			result[key] = values
+			continue
+		}
+
+		if v, ok := toScalar(value); ok {
+			result[key] = v
+		}
+	}
+
+	return result
+}
+
+// toScalar возвращает скалярное значение Qdrant; false для остальных типов.
+func toScalar(value *qdrant.Value) (any, bool) {
+	switch kind := value.GetKind().(type) {
+	case *qdrant.Value_IntegerValue:
+		return kind.IntegerValue, true
+	case *qdrant.Value_DoubleValue:
+		return kind.DoubleValue, true
+	case *qdrant.Value_StringValue:
+		return kind.StringValue, true
+	case *qdrant.Value_BoolValue:
+		return kind.BoolValue, true
+	default:
+		return nil, false
+	}
+}
+
+// toSearchHits преобразует найденные точки Qdrant в []domain.SearchHit.
+func toSearchHits(points []*qdrant.ScoredPoint) []domain.SearchHit {
+	hits := make([]domain.SearchHit, 0, len(points))
+	for _, point := range points {
+		hits = append(hits, *domain.NewSearchHit(point.GetId().GetUuid(), point.GetScore(), toDomainPayload(point.GetPayload())))
+	}
+
+	return hits
+}
+
+// toEmbeddings преобразует точки Qdrant в []domain.Embedding.
+func toEmbeddings(points []*qdrant.RetrievedPoint) []domain.Embedding {
+	embeddings := make([]domain.Embedding, 0, len(points))
+	for _, point := range points {
+		vector := point.GetVectors().GetVector()
+		data := vector.GetDense().GetData()
+		if len(data) == 0 {
+			data = vector.GetData()
+		}
+
+		embeddings = append(embeddings, *domain.NewEmbedding(point.GetId().GetUuid(), data, toDomainPayload(point.GetPayload())))
+	}
+
+	return embeddings
+}
@@ -3,31 +3,37 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/internal/repository/redis/converter"
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 	"github.com/DRSN-tech/go-backend/pkg/clients"
 	"github.com/DRSN-tech/go-backend/pkg/e"
 	"github.com/DRSN-tech/go-backend/pkg/logger"
 	"github.com/jimlawless/whereami"
+	"github.com/redis/go-redis/v9"
 )
 
 type CacheRepo struct {
-	client *clients.RedisClient
-	conv   converter.ProductInfoConverter
-	cfg    *cfg.RedisCfg
-	logger logger.Logger
+	client    *clients.RedisClient
+	conv      converter.ProductInfoConverter
+	storeConv converter.StoreProductConverter
+	cfg       *cfg.RedisCfg
+	logger    logger.Logger
 }
 
-func NewCacheRepo(client *clients.RedisClient, conv converter.ProductInfoConverter,
+func NewCacheRepo(client *clients.RedisClient, conv converter.ProductInfoConverter, storeConv converter.StoreProductConverter,
 	cfg *cfg.RedisCfg, logger logger.Logger) *CacheRepo {
 	return &CacheRepo{
-		client: client,
-		conv:   conv,
-		cfg:    cfg,
-		logger: logger,
+		client:    client,
+		conv:      conv,
+		storeConv: storeConv,
+		cfg:       cfg,
+		logger:    logger,
 	}
 }
 
@@ -106,6 +112,113 @@ func (r *CacheRepo) DeleteProducts(ctx context.Context, ids []int64) error {
 	return nil
 }
 
+// GetStoreProducts возвращает закэшированные записи ассортимента магазина по ID продуктов, игнорируя промахи.
+// Продукт вне ассортимента возвращается записью с IsAvailable = false.
+func (r *CacheRepo) GetStoreProducts(ctx context.Context, storeID int64, ids []int64) (map[int64]domain.StoreProduct, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.storeProductKey(storeID, id)
+	}
+
+	values, err := r.client.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Warnf("Redis MGET failed: %v", e.Wrap(whereami.WhereAmI(), err))
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	result := make(map[int64]domain.StoreProduct, len(values))
+	for i, val := range values {
+		data, err := redisValueToBytes(val, keys[i])
+		if err != nil {
+			r.logger.Warnf("%v", e.Wrap(whereami.WhereAmI(), err))
+		}
+
+		if data == nil {
+			continue // cache miss
+		}
+
+		var model converter.StoreProductRedisModel
+		if err := json.Unmarshal(data, &model); err != nil {
+			r.logger.Warnf("Redis unmarshal failed: %v", e.Wrap(whereami.WhereAmI(), err))
+			continue
+		}
+
+		if model.StoreID != storeID || model.ProductID != ids[i] {
+			r.logger.Warnf("Cache ID mismatch: key: %s, store_id: %d, product_id: %d", keys[i], model.StoreID, model.ProductID)
+			continue // cache miss
+		}
+		result[ids[i]] = *r.storeConv.ToEntity(&model)
+	}
+
+	return result, nil
+}
+
+// SetStoreProducts кэширует записи ассортимента магазинов с TTL продуктов.
+// Игнорирует ошибки сериализации/записи, логируя их.
+func (r *CacheRepo) SetStoreProducts(ctx context.Context, products []domain.StoreProduct) error {
+	pipeline := r.client.Client.Pipeline()
+	for i := range products {
+		data, err := json.Marshal(r.storeConv.ToRedisModel(&products[i]))
+		if err != nil {
+			r.logger.Warnf("Failed to marshal store product for caching (Store ID: %d, Product ID: %d): %v",
+				products[i].StoreID, products[i].ProductID, e.Wrap(whereami.WhereAmI(), err))
+			continue
+		}
+
+		pipeline.Set(ctx, r.storeProductKey(products[i].StoreID, products[i].ProductID), data, r.cfg.ProductTTL)
+	}
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		r.logger.Warnf("Cache pipeline failed: %v", e.Wrap(whereami.WhereAmI(), err))
+	}
+
+	return nil
+}
+
+// DeleteStoreProducts удаляет из кэша записи ассортимента магазина по ID продуктов
+func (r *CacheRepo) DeleteStoreProducts(ctx context.Context, storeID int64, ids []int64) error {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.storeProductKey(storeID, id)
+	}
+
+	if err := r.client.Client.Del(ctx, keys...).Err(); err != nil {
+		r.logger.Warnf("Redis DEL failed: %v", e.Wrap(whereami.WhereAmI(), err))
+	}
+
+	return nil
+}
+
+// GetBarcodeProductID возвращает закэшированный ID продукта по штрихкоду; false означает промах кэша
+func (r *CacheRepo) GetBarcodeProductID(ctx context.Context, barcode string) (int64, bool, error) {
+	val, err := r.client.Client.Get(ctx, r.barcodeKey(barcode)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil // cache miss
+	}
+	if err != nil {
+		r.logger.Warnf("Redis GET failed: %v", e.Wrap(whereami.WhereAmI(), err))
+		return 0, false, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	id, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		r.logger.Warnf("Invalid cached product ID for barcode %s: %v", barcode, e.Wrap(whereami.WhereAmI(), err))
+		return 0, false, nil
+	}
+
+	return id, true, nil
+}
+
+// SetBarcodeProductID кэширует ID продукта по штрихкоду с TTL продуктов
+func (r *CacheRepo) SetBarcodeProductID(ctx context.Context, barcode string, productID int64) error {
+	if err := r.client.Client.Set(ctx, r.barcodeKey(barcode), productID, r.cfg.ProductTTL).Err(); err != nil {
+		r.logger.Warnf("Redis SET failed: %v", e.Wrap(whereami.WhereAmI(), err))
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
 // marshalProductForCache сериализует продукт в JSON для кэша
 func (r *CacheRepo) marshalProductForCache(model converter.ProductInfoRedisModel) ([]byte, error) {
 	data, err := json.Marshal(model)
@@ -136,9 +249,21 @@ func (r *CacheRepo) buildProductCacheKeys(ids []int64) []string {
 	return keys
 }
 
-// productKey возвращает Redis-ключ для одного продукта
+// productKey возвращает Redis-ключ для одного продукта.
+// Версия в ключе меняется при изменении формата ProductInfoRedisModel, чтобы не читать записи старого формата.
 func (r *CacheRepo) productKey(id int64) string {
-	return fmt.Sprintf("product:%d", id)
+	return fmt.Sprintf("product:v5:%d", id)
+}
+
+// storeProductKey возвращает Redis-ключ записи ассортимента магазина.
+// Общие данные продукта хранятся под productKey и не зависят от магазина.
+func (r *CacheRepo) storeProductKey(storeID int64, productID int64) string {
+	return fmt.Sprintf("store:v1:%d:product:%d", storeID, productID)
+}
+
+// barcodeKey возвращает Redis-ключ соответствия штрихкода продукту
+func (r *CacheRepo) barcodeKey(barcode string) string {
+	return fmt.Sprintf("barcode:v1:%s", barcode)
 }
 
 // redisValueToBytes конвертирует значение из Redis в []byte.
@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/internal/repository/redis/converter"
+	"github.com/DRSN-tech/go-backend/pkg/clients"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/jimlawless/whereami"
+	"github.com/redis/go-redis/v9"
+)
+
+type CheckoutRepo struct {
+	client *clients.RedisClient
+	conv   converter.CheckoutConverter
+	cfg    *cfg.RedisCfg
+	logger logger.Logger
+}
+
+func NewCheckoutRepo(client *clients.RedisClient, conv converter.CheckoutConverter,
+	cfg *cfg.RedisCfg, logger logger.Logger) *CheckoutRepo {
+	return &CheckoutRepo{
+		client: client,
+		conv:   conv,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Create сохраняет новую сессию с TTL сессий оформления покупки
+func (r *CheckoutRepo) Create(ctx context.Context, session *domain.CheckoutSession) error {
+	data, err := json.Marshal(r.conv.ToRedisModel(session))
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	ok, err := r.client.Client.SetNX(ctx, r.checkoutKey(session.ID), data, r.cfg.CheckoutTTL).Result()
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	if !ok {
+		return e.Wrap(whereami.WhereAmI(), e.ErrCheckoutConflict)
+	}
+
+	return nil
+}
+
+// Get возвращает сессию по ID. Сессия с истёкшим TTL считается не найденной.
+func (r *CheckoutRepo) Get(ctx context.Context, id string) (*domain.CheckoutSession, error) {
+	data, err := r.client.Client.Get(ctx, r.checkoutKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, e.Wrap(whereami.WhereAmI(), e.ErrCheckoutNotFound)
+	}
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	model, err := r.unmarshalCheckout(data)
+	if err != nil {
+		return nil, e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return r.conv.ToEntity(model), nil
+}
+
+// Update сохраняет сессию и продлевает её TTL, только если в Redis хранится предыдущая версия сессии.
+// Иначе, как и при изменении ключа во время сохранения, возвращает ErrCheckoutConflict.
+func (r *CheckoutRepo) Update(ctx context.Context, session *domain.CheckoutSession) error {
+	key := r.checkoutKey(session.ID)
+
+	data, err := json.Marshal(r.conv.ToRedisModel(session))
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	err = r.client.Client.Watch(ctx, func(tx *redis.Tx) error {
+		stored, err := tx.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return e.ErrCheckoutNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		model, err := r.unmarshalCheckout(stored)
+		if err != nil {
+			return err
+		}
+
+		if model.Version != session.Version-1 {
+			return e.ErrCheckoutConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, r.cfg.CheckoutTTL)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return e.Wrap(whereami.WhereAmI(), e.ErrCheckoutConflict)
+	}
+	if err != nil {
+		return e.Wrap(whereami.WhereAmI(), err)
+	}
+
+	return nil
+}
+
+// unmarshalCheckout десериализует JSON сессии из Redis
+func (r *CheckoutRepo) unmarshalCheckout(data []byte) (*converter.CheckoutSessionRedisModel, error) {
+	var model converter.CheckoutSessionRedisModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+// checkoutKey возвращает Redis-ключ сессии оформления покупки
+func (r *CheckoutRepo) checkoutKey(id string) string {
+	return fmt.Sprintf("checkout:v1:%s", id)
+}
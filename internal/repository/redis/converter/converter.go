@@ -5,6 +5,7 @@ package converter
 import (
 	"time"
 
+	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/internal/usecase"
 )
 
@@ -12,12 +13,38 @@ import (
 // goverter:extend ConvertTime
 // goverter:extend ConvertPointerTime
 type ProductInfoConverter interface {
+	// goverter:map Price.Amount Price
+	// goverter:map Price.Currency Currency
 	ToRedisModel(entity *usecase.ProductInfo) *ProductInfoRedisModel
+	// goverter:map . Price | RedisModelToMoney
 	ToUseCase(model *ProductInfoRedisModel) *usecase.ProductInfo
 	ToArrRedisModel(entities []usecase.ProductInfo) []ProductInfoRedisModel
 	ToArrUseCase(models []ProductInfoRedisModel) []usecase.ProductInfo
 }
 
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type CheckoutConverter interface {
+	ToRedisModel(entity *domain.CheckoutSession) *CheckoutSessionRedisModel
+	ToEntity(model *CheckoutSessionRedisModel) *domain.CheckoutSession
+}
+
+// goverter:converter
+// goverter:extend ConvertTime
+// goverter:extend ConvertPointerTime
+type StoreProductConverter interface {
+	// goverter:map Price Price | MoneyToPointerAmount
+	// goverter:map Price Currency | MoneyToPointerCurrency
+	ToRedisModel(entity *domain.StoreProduct) *StoreProductRedisModel
+	// goverter:map . Price | StoreProductRedisModelToMoney
+	ToEntity(model *StoreProductRedisModel) *domain.StoreProduct
+}
+
+func RedisModelToMoney(model ProductInfoRedisModel) domain.Money {
+	return domain.NewMoney(model.Price, domain.Currency(model.Currency))
+}
+
 func ConvertPointerTime(t *time.Time) *time.Time {
 	return t
 }
@@ -25,3 +52,30 @@ func ConvertPointerTime(t *time.Time) *time.Time {
 func ConvertTime(t time.Time) time.Time {
 	return t
 }
+
+// StoreProductRedisModelToMoney возвращает цену продукта в магазине или nil, если действует общая цена.
+func StoreProductRedisModelToMoney(model StoreProductRedisModel) *domain.Money {
+	if model.Price == nil || model.Currency == nil {
+		return nil
+	}
+
+	money := domain.NewMoney(*model.Price, domain.Currency(*model.Currency))
+	return &money
+}
+
+func MoneyToPointerAmount(m *domain.Money) *int64 {
+	if m == nil {
+		return nil
+	}
+
+	return &m.Amount
+}
+
+func MoneyToPointerCurrency(m *domain.Money) *string {
+	if m == nil {
+		return nil
+	}
+
+	currency := string(m.Currency)
+	return &currency
+}
@@ -4,6 +4,7 @@
 package generated
 
 import (
+	domain "github.com/DRSN-tech/go-backend/internal/domain"
 	converter "github.com/DRSN-tech/go-backend/internal/repository/redis/converter"
 	usecase "github.com/DRSN-tech/go-backend/internal/usecase"
 )
@@ -51,7 +52,41 @@ func (c *ProductInfoConverterImpl) converterProductInfoRedisModelToUsecaseProduc
 	usecaseProductInfo.ID = source.ID
 	usecaseProductInfo.Name = source.Name
 	usecaseProductInfo.CategoryName = source.CategoryName
-	usecaseProductInfo.Price = source.Price
+	if source.CategoryPath != nil {
+		usecaseProductInfo.CategoryPath = make([]string, len(source.CategoryPath))
+		for i := 0; i < len(source.CategoryPath); i++ {
+			usecaseProductInfo.CategoryPath[i] = source.CategoryPath[i]
+		}
+	}
+	usecaseProductInfo.Price = converter.RedisModelToMoney(source)
+	if source.SKU != nil {
+		xstring := *source.SKU
+		usecaseProductInfo.SKU = &xstring
+	}
+	if source.Barcodes != nil {
+		usecaseProductInfo.Barcodes = make([]string, len(source.Barcodes))
+		for j := 0; j < len(source.Barcodes); j++ {
+			usecaseProductInfo.Barcodes[j] = source.Barcodes[j]
+		}
+	}
+	usecaseProductInfo.Unit = domain.Unit(source.Unit)
+	if source.LocalizedNames != nil {
+		usecaseProductInfo.LocalizedNames = make(domain.LocalizedNames, len(source.LocalizedNames))
+		for key, value := range source.LocalizedNames {
+			usecaseProductInfo.LocalizedNames[key] = value
+		}
+	}
+	if source.Attributes != nil {
+		usecaseProductInfo.Attributes = make(domain.Attributes, len(source.Attributes))
+		for key, value := range source.Attributes {
+			usecaseProductInfo.Attributes[key] = value
+		}
+	}
+	if source.VariantGroupID != nil {
+		xint64 := *source.VariantGroupID
+		usecaseProductInfo.VariantGroupID = &xint64
+	}
+	usecaseProductInfo.Version = source.Version
 	return usecaseProductInfo
 }
 func (c *ProductInfoConverterImpl) usecaseProductInfoToConverterProductInfoRedisModel(source usecase.ProductInfo) converter.ProductInfoRedisModel {
@@ -59,6 +94,166 @@ func (c *ProductInfoConverterImpl) usecaseProductInfoToConverterProductInfoRedis
 	converterProductInfoRedisModel.ID = source.ID
 	converterProductInfoRedisModel.Name = source.Name
 	converterProductInfoRedisModel.CategoryName = source.CategoryName
-	converterProductInfoRedisModel.Price = source.Price
+	if source.CategoryPath != nil {
+		converterProductInfoRedisModel.CategoryPath = make([]string, len(source.CategoryPath))
+		for i := 0; i < len(source.CategoryPath); i++ {
+			converterProductInfoRedisModel.CategoryPath[i] = source.CategoryPath[i]
+		}
+	}
+	converterProductInfoRedisModel.Price = source.Price.Amount
+	converterProductInfoRedisModel.Currency = string(source.Price.Currency)
+	if source.SKU != nil {
+		xstring := *source.SKU
+		converterProductInfoRedisModel.SKU = &xstring
+	}
+	if source.Barcodes != nil {
+		converterProductInfoRedisModel.Barcodes = make([]string, len(source.Barcodes))
+		for j := 0; j < len(source.Barcodes); j++ {
+			converterProductInfoRedisModel.Barcodes[j] = source.Barcodes[j]
+		}
+	}
+	converterProductInfoRedisModel.Unit = string(source.Unit)
+	if source.LocalizedNames != nil {
+		converterProductInfoRedisModel.LocalizedNames = make(map[string]string, len(source.LocalizedNames))
+		for key, value := range source.LocalizedNames {
+			converterProductInfoRedisModel.LocalizedNames[key] = value
+		}
+	}
+	if source.Attributes != nil {
+		converterProductInfoRedisModel.Attributes = make(map[string]interface{}, len(source.Attributes))
+		for key, value := range source.Attributes {
+			converterProductInfoRedisModel.Attributes[key] = value
+		}
+	}
+	if source.VariantGroupID != nil {
+		xint64 := *source.VariantGroupID
+		converterProductInfoRedisModel.VariantGroupID = &xint64
+	}
+	converterProductInfoRedisModel.Version = source.Version
 	return converterProductInfoRedisModel
 }
+
+type CheckoutConverterImpl struct{}
+
+func (c *CheckoutConverterImpl) ToEntity(source *converter.CheckoutSessionRedisModel) *domain.CheckoutSession {
+	var pDomainCheckoutSession *domain.CheckoutSession
+	if source != nil {
+		var domainCheckoutSession domain.CheckoutSession
+		domainCheckoutSession.ID = (*source).ID
+		domainCheckoutSession.Status = domain.CheckoutStatus((*source).Status)
+		if (*source).StoreID != nil {
+			xint64 := *(*source).StoreID
+			domainCheckoutSession.StoreID = &xint64
+		}
+		if (*source).Lines != nil {
+			domainCheckoutSession.Lines = make([]domain.CheckoutLine, len((*source).Lines))
+			for i := 0; i < len((*source).Lines); i++ {
+				domainCheckoutSession.Lines[i] = c.converterCheckoutLineRedisModelToDomainCheckoutLine((*source).Lines[i])
+			}
+		}
+		domainCheckoutSession.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		domainCheckoutSession.UpdatedAt = converter.ConvertTime((*source).UpdatedAt)
+		domainCheckoutSession.CompletedAt = converter.ConvertPointerTime((*source).CompletedAt)
+		domainCheckoutSession.Version = (*source).Version
+		pDomainCheckoutSession = &domainCheckoutSession
+	}
+	return pDomainCheckoutSession
+}
+func (c *CheckoutConverterImpl) ToRedisModel(source *domain.CheckoutSession) *converter.CheckoutSessionRedisModel {
+	var pConverterCheckoutSessionRedisModel *converter.CheckoutSessionRedisModel
+	if source != nil {
+		var converterCheckoutSessionRedisModel converter.CheckoutSessionRedisModel
+		converterCheckoutSessionRedisModel.ID = (*source).ID
+		converterCheckoutSessionRedisModel.Status = string((*source).Status)
+		if (*source).StoreID != nil {
+			xint64 := *(*source).StoreID
+			converterCheckoutSessionRedisModel.StoreID = &xint64
+		}
+		if (*source).Lines != nil {
+			converterCheckoutSessionRedisModel.Lines = make([]converter.CheckoutLineRedisModel, len((*source).Lines))
+			for i := 0; i < len((*source).Lines); i++ {
+				converterCheckoutSessionRedisModel.Lines[i] = c.domainCheckoutLineToConverterCheckoutLineRedisModel((*source).Lines[i])
+			}
+		}
+		converterCheckoutSessionRedisModel.CreatedAt = converter.ConvertTime((*source).CreatedAt)
+		converterCheckoutSessionRedisModel.UpdatedAt = converter.ConvertTime((*source).UpdatedAt)
+		converterCheckoutSessionRedisModel.CompletedAt = converter.ConvertPointerTime((*source).CompletedAt)
+		converterCheckoutSessionRedisModel.Version = (*source).Version
+		pConverterCheckoutSessionRedisModel = &converterCheckoutSessionRedisModel
+	}
+	return pConverterCheckoutSessionRedisModel
+}
+func (c *CheckoutConverterImpl) converterCheckoutLineRedisModelToDomainCheckoutLine(source converter.CheckoutLineRedisModel) domain.CheckoutLine {
+	var domainCheckoutLine domain.CheckoutLine
+	domainCheckoutLine.ID = source.ID
+	domainCheckoutLine.ProductID = source.ProductID
+	domainCheckoutLine.Quantity = source.Quantity
+	domainCheckoutLine.WeightGrams = source.WeightGrams
+	domainCheckoutLine.Method = domain.IdentificationMethod(source.Method)
+	if source.Barcode != nil {
+		xstring := *source.Barcode
+		domainCheckoutLine.Barcode = &xstring
+	}
+	if source.RecognitionScore != nil {
+		xfloat32 := *source.RecognitionScore
+		domainCheckoutLine.RecognitionScore = &xfloat32
+	}
+	if source.ModelVersion != nil {
+		xstring2 := *source.ModelVersion
+		domainCheckoutLine.ModelVersion = &xstring2
+	}
+	domainCheckoutLine.AddedAt = converter.ConvertTime(source.AddedAt)
+	return domainCheckoutLine
+}
+func (c *CheckoutConverterImpl) domainCheckoutLineToConverterCheckoutLineRedisModel(source domain.CheckoutLine) converter.CheckoutLineRedisModel {
+	var converterCheckoutLineRedisModel converter.CheckoutLineRedisModel
+	converterCheckoutLineRedisModel.ID = source.ID
+	converterCheckoutLineRedisModel.ProductID = source.ProductID
+	converterCheckoutLineRedisModel.Quantity = source.Quantity
+	converterCheckoutLineRedisModel.WeightGrams = source.WeightGrams
+	converterCheckoutLineRedisModel.Method = string(source.Method)
+	if source.Barcode != nil {
+		xstring := *source.Barcode
+		converterCheckoutLineRedisModel.Barcode = &xstring
+	}
+	if source.RecognitionScore != nil {
+		xfloat32 := *source.RecognitionScore
+		converterCheckoutLineRedisModel.RecognitionScore = &xfloat32
+	}
+	if source.ModelVersion != nil {
+		xstring2 := *source.ModelVersion
+		converterCheckoutLineRedisModel.ModelVersion = &xstring2
+	}
+	converterCheckoutLineRedisModel.AddedAt = converter.ConvertTime(source.AddedAt)
+	return converterCheckoutLineRedisModel
+}
+
+type StoreProductConverterImpl struct{}
+
+func (c *StoreProductConverterImpl) ToEntity(source *converter.StoreProductRedisModel) *domain.StoreProduct {
+	var pDomainStoreProduct *domain.StoreProduct
+	if source != nil {
+		var domainStoreProduct domain.StoreProduct
+		domainStoreProduct.StoreID = (*source).StoreID
+		domainStoreProduct.ProductID = (*source).ProductID
+		domainStoreProduct.IsAvailable = (*source).IsAvailable
+		domainStoreProduct.Price = converter.StoreProductRedisModelToMoney((*source))
+		domainStoreProduct.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pDomainStoreProduct = &domainStoreProduct
+	}
+	return pDomainStoreProduct
+}
+func (c *StoreProductConverterImpl) ToRedisModel(source *domain.StoreProduct) *converter.StoreProductRedisModel {
+	var pConverterStoreProductRedisModel *converter.StoreProductRedisModel
+	if source != nil {
+		var converterStoreProductRedisModel converter.StoreProductRedisModel
+		converterStoreProductRedisModel.StoreID = (*source).StoreID
+		converterStoreProductRedisModel.ProductID = (*source).ProductID
+		converterStoreProductRedisModel.IsAvailable = (*source).IsAvailable
+		converterStoreProductRedisModel.Price = converter.MoneyToPointerAmount((*source).Price)
+		converterStoreProductRedisModel.Currency = converter.MoneyToPointerCurrency((*source).Price)
+		converterStoreProductRedisModel.UpdatedAt = converter.ConvertPointerTime((*source).UpdatedAt)
+		pConverterStoreProductRedisModel = &converterStoreProductRedisModel
+	}
+	return pConverterStoreProductRedisModel
+}
@@ -1,8 +1,53 @@
 package converter
 
+import "time"
+
 type ProductInfoRedisModel struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	CategoryName string `json:"category_name"`
-	Price        int64  `json:"price"`
+	ID             int64             `json:"id"`
+	Name           string            `json:"name"`
+	CategoryName   string            `json:"category_name"`
+	CategoryPath   []string          `json:"category_path"`
+	Price          int64             `json:"price"`
+	Currency       string            `json:"currency"`
+	SKU            *string           `json:"sku,omitempty"`
+	Barcodes       []string          `json:"barcodes"`
+	Unit           string            `json:"unit"`
+	LocalizedNames map[string]string `json:"localized_names,omitempty"`
+	Attributes     map[string]any    `json:"attributes,omitempty"`
+	VariantGroupID *int64            `json:"variant_group_id,omitempty"`
+	Version        int64             `json:"version"`
+}
+
+// StoreProductRedisModel — запись ассортимента магазина в кэше. Продукт вне ассортимента
+// кэшируется с IsAvailable = false, чтобы не запрашивать его из БД повторно.
+type StoreProductRedisModel struct {
+	StoreID     int64      `json:"store_id"`
+	ProductID   int64      `json:"product_id"`
+	IsAvailable bool       `json:"is_available"`
+	Price       *int64     `json:"price,omitempty"`
+	Currency    *string    `json:"currency,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+type CheckoutSessionRedisModel struct {
+	ID          string                   `json:"id"`
+	Status      string                   `json:"status"`
+	StoreID     *int64                   `json:"store_id,omitempty"`
+	Lines       []CheckoutLineRedisModel `json:"lines"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+	Version     int64                    `json:"version"`
+}
+
+type CheckoutLineRedisModel struct {
+	ID               string    `json:"id"`
+	ProductID        int64     `json:"product_id"`
+	Quantity         int64     `json:"quantity"`
+	WeightGrams      int64     `json:"weight_grams,omitempty"`
+	Method           string    `json:"method"`
+	Barcode          *string   `json:"barcode,omitempty"`
+	RecognitionScore *float32  `json:"recognition_score,omitempty"`
+	ModelVersion     *string   `json:"model_version,omitempty"`
+	AddedAt          time.Time `json:"added_at"`
 }
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAttributeSchema возвращает собственную схему атрибутов категории и определения, действующие для её продуктов.
+// Категория без собственной схемы возвращает пустую схему.
+func (c *CategoryUseCase) GetAttributeSchema(ctx context.Context, id int64) (*AttributeSchemaDetails, error) {
+	const op = "CategoryUseCase.GetAttributeSchema"
+
+	if id <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	if _, err := c.categoryRepo.GetByID(ctx, id); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	schemas, err := c.attributeRepo.ListForCategory(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return newAttributeSchemaDetails(id, schemas), nil
+}
+
+// SetAttributeSchema заменяет собственную схему атрибутов категории.
+// Уже сохранённые атрибуты продуктов не перепроверяются: новая схема применяется при их следующем изменении.
+func (c *CategoryUseCase) SetAttributeSchema(ctx context.Context, schema *domain.AttributeSchema) (*AttributeSchemaDetails, error) {
+	const op = "CategoryUseCase.SetAttributeSchema"
+
+	var err error
+	if schema.CategoryID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	if err = schema.Validate(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	// Блокировка категории исключает её параллельное удаление
+	if _, err = c.categoryRepo.GetForUpdate(ctx, schema.CategoryID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	saved, err := c.attributeRepo.Set(ctx, schema)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Схемы читаются вне транзакции, поэтому прежняя схема категории (последняя в пути) заменяется сохранённой
+	schemas, err := c.attributeRepo.ListForCategory(ctx, schema.CategoryID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if n := len(schemas); n > 0 && schemas[n-1].CategoryID == saved.CategoryID {
+		schemas = schemas[:n-1]
+	}
+	schemas = append(schemas, saved)
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	c.logger.Infof("Attribute schema set. category_id: %d, attributes: %d", saved.CategoryID, len(saved.Attributes))
+
+	return newAttributeSchemaDetails(saved.CategoryID, schemas), nil
+}
+
+// newAttributeSchemaDetails выделяет собственную схему категории из схем её пути, упорядоченных от корня к категории.
+func newAttributeSchemaDetails(categoryID int64, schemas []*domain.AttributeSchema) *AttributeSchemaDetails {
+	own := domain.NewAttributeSchema(categoryID, []domain.AttributeDef{})
+	for _, schema := range schemas {
+		if schema.CategoryID == categoryID {
+			own = schema
+		}
+	}
+
+	return NewAttributeSchemaDetails(own, domain.MergeAttributeSchemas(schemas))
+}
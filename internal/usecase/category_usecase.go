@@ -0,0 +1,385 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// CategoryUseCase реализует бизнес-логику управления категориями.
+type CategoryUseCase struct {
+	categoryRepo  CategoryRepository
+	attributeRepo AttributeSchemaRepository
+	dbPool        transaction.Transactional
+	cacheRepo     CacheRepository
+	logger        logger.Logger
+}
+
+func NewCategoryUC(
+	categoryRepo CategoryRepository,
+	attributeRepo AttributeSchemaRepository,
+	dbPool transaction.Transactional,
+	cacheRepo CacheRepository,
+	logger logger.Logger,
+) *CategoryUseCase {
+	return &CategoryUseCase{
+		categoryRepo:  categoryRepo,
+		attributeRepo: attributeRepo,
+		dbPool:        dbPool,
+		cacheRepo:     cacheRepo,
+		logger:        logger,
+	}
+}
+
+// CreateCategory идемпотентно создаёт категорию: при существующем названии с тем же родителем
+// возвращается имеющаяся категория. Родительская категория должна существовать и не быть архивной.
+func (c *CategoryUseCase) CreateCategory(ctx context.Context, req *CreateCategoryReq) (*domain.Category, error) {
+	const op = "CategoryUseCase.CreateCategory"
+
+	var err error
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, e.Wrap(op, e.ErrCategoryNameRequired)
+	}
+
+	if req.ParentID != nil && *req.ParentID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	if req.ParentID != nil {
+		// Блокировка родителя исключает его параллельную архивацию
+		var parent *domain.Category
+		parent, err = c.categoryRepo.GetForUpdate(ctx, *req.ParentID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if parent.IsArchived {
+			err = e.ErrCategoryArchived
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	category, err := c.categoryRepo.Create(ctx, domain.NewCategory(name, req.ParentID))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Название уникально во всём дереве: категория с ним уже существует под другим родителем
+	if !sameParent(category.ParentID, req.ParentID) {
+		err = e.ErrCategoryNameTaken
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return category, nil
+}
+
+// GetCategory возвращает категорию по ID, включая архивную.
+func (c *CategoryUseCase) GetCategory(ctx context.Context, id int64) (*CategoryDetails, error) {
+	const op = "CategoryUseCase.GetCategory"
+
+	details, err := c.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// ListCategories возвращает категории в порядке обхода дерева с количеством неархивных продуктов.
+// При archived == nil возвращаются и активные, и архивные категории.
+func (c *CategoryUseCase) ListCategories(ctx context.Context, archived *bool) ([]CategoryDetails, error) {
+	const op = "CategoryUseCase.ListCategories"
+
+	categories, err := c.categoryRepo.List(ctx, archived)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return categories, nil
+}
+
+// RenameCategory изменяет название категории и сбрасывает кэш продуктов её поддерева,
+// т.к. название категории входит в ProductInfo и в путь категорий потомков.
+func (c *CategoryUseCase) RenameCategory(ctx context.Context, id int64, name string) (*domain.Category, error) {
+	const op = "CategoryUseCase.RenameCategory"
+
+	var err error
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, e.Wrap(op, e.ErrCategoryNameRequired)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	current, err := c.categoryRepo.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if current.Name == name {
+		err = e.ErrNoChanges
+		return nil, e.Wrap(op, err)
+	}
+
+	category, err := c.categoryRepo.Rename(ctx, id, name)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	c.invalidateProducts(ctx, id)
+
+	return category, nil
+}
+
+// MoveCategory переносит категорию вместе с поддеревом к новому родителю. parentID == nil делает категорию корневой.
+// Перенос в собственное поддерево отклоняется.
+func (c *CategoryUseCase) MoveCategory(ctx context.Context, id int64, parentID *int64) (*domain.Category, error) {
+	const op = "CategoryUseCase.MoveCategory"
+
+	var err error
+	if parentID != nil && *parentID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	if err = c.categoryRepo.LockTree(ctx); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	current, err := c.categoryRepo.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if sameParent(current.ParentID, parentID) {
+		err = e.ErrNoChanges
+		return nil, e.Wrap(op, err)
+	}
+
+	if parentID != nil {
+		var parent *domain.Category
+		parent, err = c.categoryRepo.GetForUpdate(ctx, *parentID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		// Активная категория не может находиться внутри архивной
+		if parent.IsArchived && !current.IsArchived {
+			err = e.ErrCategoryArchived
+			return nil, e.Wrap(op, err)
+		}
+
+		var subtree []int64
+		subtree, err = c.categoryRepo.SubtreeIDs(ctx, id)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if slices.Contains(subtree, *parentID) {
+			err = e.ErrCategoryCycle
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	category, err := c.categoryRepo.Move(ctx, id, parentID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	c.invalidateProducts(ctx, id)
+
+	return category, nil
+}
+
+// ArchiveCategory архивирует категорию. Категорию с неархивными продуктами или подкатегориями архивировать нельзя.
+func (c *CategoryUseCase) ArchiveCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	return c.setArchived(ctx, id, true)
+}
+
+// UnarchiveCategory восстанавливает архивную категорию. Родительская категория должна быть активной.
+func (c *CategoryUseCase) UnarchiveCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	return c.setArchived(ctx, id, false)
+}
+
+// setArchived изменяет признак архивации категории.
+func (c *CategoryUseCase) setArchived(ctx context.Context, id int64, archived bool) (*domain.Category, error) {
+	const op = "CategoryUseCase.setArchived"
+
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	// Блокировка категории исключает параллельное восстановление её продуктов и подкатегорий
+	current, err := c.categoryRepo.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if current.IsArchived == archived {
+		err = e.ErrNoChanges
+		return nil, e.Wrap(op, err)
+	}
+
+	if archived {
+		var count int64
+		count, err = c.categoryRepo.CountActiveProducts(ctx, id)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if count > 0 {
+			err = e.ErrCategoryHasProducts
+			return nil, e.Wrap(op, err)
+		}
+
+		count, err = c.categoryRepo.CountActiveChildren(ctx, id)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if count > 0 {
+			err = e.ErrCategoryHasChildren
+			return nil, e.Wrap(op, err)
+		}
+	} else if current.ParentID != nil {
+		var parent *domain.Category
+		parent, err = c.categoryRepo.GetForUpdate(ctx, *current.ParentID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if parent.IsArchived {
+			err = e.ErrCategoryArchived
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	category, err := c.categoryRepo.SetArchived(ctx, id, archived)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return category, nil
+}
+
+// DeleteCategory безвозвратно удаляет категорию, на которую не ссылаются ни продукты, ни подкатегории.
+func (c *CategoryUseCase) DeleteCategory(ctx context.Context, id int64) error {
+	const op = "CategoryUseCase.DeleteCategory"
+
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	if err = c.categoryRepo.Delete(ctx, id); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// invalidateProducts удаляет из кэша продукты категории и её потомков. Ошибка только логируется:
+// запись в БД уже зафиксирована.
+func (c *CategoryUseCase) invalidateProducts(ctx context.Context, id int64) {
+	const op = "CategoryUseCase.invalidateProducts"
+
+	ids, err := c.categoryRepo.SubtreeProductIDs(ctx, id)
+	if err != nil {
+		c.logger.Warnf("Failed to get category products: %v", e.Wrap(op, err))
+		return
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := c.cacheRepo.DeleteProducts(ctx, ids); err != nil {
+		c.logger.Warnf("Failed to delete products from cache: %v", e.Wrap(op, err))
+	}
+}
+
+// sameParent сравнивает родителей категорий с учётом корневых категорий.
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
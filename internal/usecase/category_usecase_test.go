@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/jackc/pgx/v5"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// fakeTx — транзакция, которая только запоминает, чем она завершилась.
+type fakeTx struct {
+	pgx.Tx
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+type fakeDB struct {
+	tx *fakeTx
+}
+
+func (d *fakeDB) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	d.tx = &fakeTx{}
+	return d.tx, nil
+}
+
+// fakeCategoryRepo хранит дерево категорий в памяти. Методы, не используемые тестами, не реализованы.
+type fakeCategoryRepo struct {
+	CategoryRepository
+	categories map[int64]*domain.Category
+}
+
+func newFakeCategoryRepo(categories ...domain.Category) *fakeCategoryRepo {
+	r := &fakeCategoryRepo{categories: make(map[int64]*domain.Category, len(categories))}
+	for _, category := range categories {
+		r.categories[category.ID] = &category
+	}
+	return r
+}
+
+func (r *fakeCategoryRepo) LockTree(context.Context) error {
+	return nil
+}
+
+func (r *fakeCategoryRepo) GetForUpdate(_ context.Context, id int64) (*domain.Category, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, e.ErrCategoryNotFound
+	}
+
+	c := *category
+	return &c, nil
+}
+
+func (r *fakeCategoryRepo) SubtreeIDs(_ context.Context, id int64) ([]int64, error) {
+	ids := []int64{id}
+	for i := 0; i < len(ids); i++ {
+		for _, category := range r.categories {
+			if category.ParentID != nil && *category.ParentID == ids[i] {
+				ids = append(ids, category.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeCategoryRepo) Move(_ context.Context, id int64, parentID *int64) (*domain.Category, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, e.ErrCategoryNotFound
+	}
+
+	category.ParentID = parentID
+	c := *category
+	return &c, nil
+}
+
+func (r *fakeCategoryRepo) SubtreeProductIDs(context.Context, int64) ([]int64, error) {
+	return nil, nil
+}
+
+func TestMoveCategory(t *testing.T) {
+	// 1 Напитки
+	// └── 2 Газировка
+	//     └── 3 Кола
+	// 4 Архив (архивная)
+	// └── 6 Старое (архивная)
+	// 5 Снеки
+	// 7 Сезонное (архивная)
+	categories := []domain.Category{
+		{ID: 1, Name: "Напитки"},
+		{ID: 2, Name: "Газировка", ParentID: int64Ptr(1)},
+		{ID: 3, Name: "Кола", ParentID: int64Ptr(2)},
+		{ID: 4, Name: "Архив", IsArchived: true},
+		{ID: 5, Name: "Снеки"},
+		{ID: 6, Name: "Старое", ParentID: int64Ptr(4), IsArchived: true},
+		{ID: 7, Name: "Сезонное", IsArchived: true},
+	}
+
+	tests := []struct {
+		name     string
+		id       int64
+		parentID *int64
+		err      error
+	}{
+		{"into itself", 2, int64Ptr(2), e.ErrCategoryCycle},
+		{"into child", 1, int64Ptr(2), e.ErrCategoryCycle},
+		{"into grandchild", 1, int64Ptr(3), e.ErrCategoryCycle},
+		{"active under archived", 2, int64Ptr(4), e.ErrCategoryArchived},
+		{"active under archived descendant", 5, int64Ptr(6), e.ErrCategoryArchived},
+		{"archived under archived", 7, int64Ptr(4), nil},
+		{"archived under active", 6, int64Ptr(5), nil},
+		{"into other subtree", 3, int64Ptr(5), nil},
+		{"to root", 2, nil, nil},
+		{"same parent", 3, int64Ptr(2), e.ErrNoChanges},
+		{"root to root", 1, nil, e.ErrNoChanges},
+		{"unknown parent", 3, int64Ptr(100), e.ErrCategoryNotFound},
+		{"unknown category", 100, int64Ptr(1), e.ErrCategoryNotFound},
+		{"invalid parent ID", 3, int64Ptr(0), e.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeCategoryRepo(categories...)
+			db := &fakeDB{}
+			uc := &CategoryUseCase{categoryRepo: repo, dbPool: db}
+
+			var before *int64
+			if category, ok := repo.categories[tt.id]; ok {
+				before = category.ParentID
+			}
+
+			got, err := uc.MoveCategory(context.Background(), tt.id, tt.parentID)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("MoveCategory() error = %v, want %v", err, tt.err)
+			}
+
+			if err != nil {
+				if category, ok := repo.categories[tt.id]; ok && !sameParent(category.ParentID, before) {
+					t.Errorf("MoveCategory() changed parent to %v on error", category.ParentID)
+				}
+				if db.tx != nil && (db.tx.committed || !db.tx.rolledBack) {
+					t.Errorf("MoveCategory() committed = %t, rolled back = %t, want rollback", db.tx.committed, db.tx.rolledBack)
+				}
+				return
+			}
+
+			if !sameParent(got.ParentID, tt.parentID) || !sameParent(repo.categories[tt.id].ParentID, tt.parentID) {
+				t.Errorf("MoveCategory() parent = %v, want %v", got.ParentID, tt.parentID)
+			}
+			if !db.tx.committed {
+				t.Errorf("MoveCategory() did not commit")
+			}
+		})
+	}
+}
@@ -0,0 +1,586 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/DRSN-tech/go-backend/pkg/storectx"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxCheckoutAttempts ограничивает кол-во повторов изменения сессии при параллельных изменениях
+const maxCheckoutAttempts = 3
+
+// checkoutEventNamespace — пространство имён UUID событий checkout_completed
+var checkoutEventNamespace = uuid.MustParse("6f1c2b8e-3d4a-4e5f-9a7b-1c2d3e4f5a6b")
+
+// CheckoutUseCase реализует корзину покупателя на кассе. Сессии хранятся в Redis,
+// стоимость позиций рассчитывается по текущим ценам из GetProductsInfo.
+type CheckoutUseCase struct {
+	productUC    ProductUC
+	checkoutRepo CheckoutRepository
+	outboxRepo   OutboxRepository
+	producer     MessageProducer
+	dbPool       transaction.Transactional
+	logger       logger.Logger
+}
+
+func NewCheckoutUC(
+	productUC ProductUC,
+	checkoutRepo CheckoutRepository,
+	outboxRepo OutboxRepository,
+	producer MessageProducer,
+	dbPool transaction.Transactional,
+	logger logger.Logger,
+) *CheckoutUseCase {
+	return &CheckoutUseCase{
+		productUC:    productUC,
+		checkoutRepo: checkoutRepo,
+		outboxRepo:   outboxRepo,
+		producer:     producer,
+		dbPool:       dbPool,
+		logger:       logger,
+	}
+}
+
+// CreateCheckout создаёт пустую сессию оформления покупки. Если в контексте задан магазин,
+// сессия привязывается к нему: корзина оценивается по ассортименту и ценам магазина.
+func (c *CheckoutUseCase) CreateCheckout(ctx context.Context) (*CheckoutDetails, error) {
+	const op = "CheckoutUseCase.CreateCheckout"
+
+	var storeID *int64
+	if id, ok := storectx.StoreIDFromCtx(ctx); ok {
+		storeID = &id
+	}
+
+	session := domain.NewCheckoutSession(uuid.NewString(), storeID, time.Now().UTC())
+	if err := c.checkoutRepo.Create(ctx, session); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return NewCheckoutDetails(session, []CheckoutLineDetails{}, domain.NewMoney(0, domain.DefaultCurrency)), nil
+}
+
+// GetCheckout возвращает сессию с позициями, оценёнными по текущим ценам.
+func (c *CheckoutUseCase) GetCheckout(ctx context.Context, id string) (*CheckoutDetails, error) {
+	const op = "CheckoutUseCase.GetCheckout"
+
+	session, err := c.checkoutRepo.Get(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	details, err := c.priceSession(ctx, session)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// AddCheckoutItem добавляет в корзину продукт, выбранный по ID или отсканированный по штрихкоду.
+// Повторное добавление штучного продукта тем же способом увеличивает кол-во в имеющейся позиции.
+func (c *CheckoutUseCase) AddCheckoutItem(ctx context.Context, req *AddCheckoutItemReq) (*CheckoutDetails, error) {
+	const op = "CheckoutUseCase.AddCheckoutItem"
+
+	if (req.ProductID == nil) == (req.Barcode == nil) {
+		return nil, e.Wrap(op, e.ErrMissingFields)
+	}
+
+	// Продукт ищется в ассортименте магазина сессии
+	session, err := c.checkoutRepo.Get(ctx, req.SessionID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	ctx, err = sessionContext(ctx, session)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	var (
+		product *ProductInfo
+		method  domain.IdentificationMethod
+		barcode *string
+	)
+	if req.Barcode != nil {
+		code, err := domain.ParseBarcode(*req.Barcode)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		product, err = c.productUC.GetProductByBarcode(ctx, code)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		method, barcode = domain.IdentifiedByBarcode, &code
+	} else {
+		if *req.ProductID <= 0 {
+			return nil, e.Wrap(op, e.ErrInvalidID)
+		}
+
+		product, err = c.getProduct(ctx, *req.ProductID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		method = domain.IdentifiedByID
+	}
+
+	line, err := newCheckoutLine(product, req.Quantity, req.WeightGrams, method)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	line.Barcode = barcode
+
+	details, err := c.modifySession(ctx, req.SessionID, func(session *domain.CheckoutSession) error {
+		_, err := addCheckoutLine(session, line)
+		return err
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// AddRecognizedItem распознаёт продукт по кадрам и добавляет его в корзину, если распознавание уверенное.
+// Позиция сохраняет score распознавания и версию модели.
+func (c *CheckoutUseCase) AddRecognizedItem(ctx context.Context, req *AddRecognizedItemReq) (*AddRecognizedItemRes, error) {
+	const op = "CheckoutUseCase.AddRecognizedItem"
+
+	// Сессия проверяется до обращения к ML-сервису
+	session, err := c.checkoutRepo.Get(ctx, req.SessionID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if session.Status != domain.CheckoutOpen {
+		return nil, e.Wrap(op, e.ErrCheckoutCompleted)
+	}
+
+	// Распознавание ограничивается ассортиментом магазина сессии
+	ctx, err = sessionContext(ctx, session)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	recognition, err := c.productUC.RecognizeProduct(ctx, req.Recognition)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if recognition.Verdict != VerdictAccepted {
+		details, err := c.priceSession(ctx, session)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		return NewAddRecognizedItemRes(details, "", recognition), nil
+	}
+
+	candidate := recognition.Candidates[0]
+	line, err := newCheckoutLine(&candidate.Product, req.Quantity, req.WeightGrams, domain.IdentifiedByRecognition)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	line.RecognitionScore = &candidate.Score
+	line.ModelVersion = &recognition.ModelVersion
+
+	var lineID string
+	details, err := c.modifySession(ctx, req.SessionID, func(session *domain.CheckoutSession) error {
+		var err error
+		lineID, err = addCheckoutLine(session, line)
+		return err
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return NewAddRecognizedItemRes(details, lineID, recognition), nil
+}
+
+// UpdateCheckoutLine изменяет кол-во штучного продукта или вес весового продукта в позиции.
+func (c *CheckoutUseCase) UpdateCheckoutLine(ctx context.Context, req *UpdateCheckoutLineReq) (*CheckoutDetails, error) {
+	const op = "CheckoutUseCase.UpdateCheckoutLine"
+
+	if (req.Quantity == nil) == (req.WeightGrams == nil) {
+		return nil, e.Wrap(op, e.ErrMissingFields)
+	}
+
+	details, err := c.modifySession(ctx, req.SessionID, func(session *domain.CheckoutSession) error {
+		i := session.FindLine(req.LineID)
+		if i < 0 {
+			return e.ErrLineNotFound
+		}
+
+		line := &session.Lines[i]
+		weighted := line.WeightGrams > 0
+		switch {
+		case req.Quantity != nil:
+			if weighted || *req.Quantity <= 0 || *req.Quantity > domain.MaxLineQuantity {
+				return e.ErrInvalidQuantity
+			}
+			line.Quantity = *req.Quantity
+		default:
+			if !weighted {
+				return e.ErrProductNotWeighted
+			}
+			if *req.WeightGrams <= 0 || *req.WeightGrams > domain.MaxScaleGrams {
+				return e.ErrInvalidWeight
+			}
+			line.WeightGrams = *req.WeightGrams
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// RemoveCheckoutLine удаляет позицию из корзины.
+func (c *CheckoutUseCase) RemoveCheckoutLine(ctx context.Context, sessionID string, lineID string) (*CheckoutDetails, error) {
+	const op = "CheckoutUseCase.RemoveCheckoutLine"
+
+	details, err := c.modifySession(ctx, sessionID, func(session *domain.CheckoutSession) error {
+		i := session.FindLine(lineID)
+		if i < 0 {
+			return e.ErrLineNotFound
+		}
+
+		session.Lines = slices.Delete(session.Lines, i, i+1)
+		return nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// CompleteCheckout оформляет покупку: фиксирует корзину по текущим ценам и публикует событие
+// checkout_completed через outbox. Событие сохраняется в транзакции Postgres до того, как сессия
+// помечается оформленной, и фиксируется только после изменения сессии, поэтому корзина не меняется после расчёта.
+// ID события выводится из ID сессии: если сессия оформлена, а событие не сохранилось (сбой коммита
+// или процесса), повторный вызов публикует событие заново, а не возвращает ErrCheckoutCompleted.
+func (c *CheckoutUseCase) CompleteCheckout(ctx context.Context, id string) (*CompleteCheckoutRes, error) {
+	const op = "CheckoutUseCase.CompleteCheckout"
+
+	for attempt := 1; ; attempt++ {
+		session, err := c.checkoutRepo.Get(ctx, id)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if session.Status != domain.CheckoutOpen {
+			res, err := c.recoverCheckoutEvent(ctx, session)
+			if err != nil {
+				return nil, e.Wrap(op, err)
+			}
+			return res, nil
+		}
+
+		if len(session.Lines) == 0 {
+			return nil, e.Wrap(op, e.ErrCheckoutEmpty)
+		}
+
+		details, err := c.priceSession(ctx, session)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		for _, line := range details.Lines {
+			if !line.Available {
+				return nil, e.Wrap(op, e.ErrLinesUnavailable)
+			}
+		}
+
+		event, err := c.completeSession(ctx, details)
+		if err == nil {
+			c.logger.Infof("Checkout completed. session_id: %s, lines: %d, total: %s", session.ID, len(details.Lines), details.Total)
+			return NewCompleteCheckoutRes(details, event), nil
+		}
+		if !errors.Is(err, e.ErrCheckoutConflict) || attempt == maxCheckoutAttempts {
+			return nil, e.Wrap(op, err)
+		}
+	}
+}
+
+// completeSession сохраняет событие checkout_completed в outbox и помечает сессию оформленной.
+// Транзакция фиксируется только после изменения сессии в Redis: если сессию изменили параллельно,
+// событие не сохраняется.
+func (c *CheckoutUseCase) completeSession(ctx context.Context, details *CheckoutDetails) (*OutboxEvent, error) {
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	session := details.Session
+	now := time.Now().UTC()
+	session.Status = domain.CheckoutCompleted
+	session.CompletedAt = &now
+	session.UpdatedAt = now
+	session.Version++
+
+	// Событие формируется по уже оформленной сессии, чтобы в него попало время оформления
+	event, err := c.createCheckoutEvent(ctx, details)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.checkoutRepo.Update(ctx, session); err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// recoverCheckoutEvent публикует событие checkout_completed оформленной сессии, если оно не было сохранено.
+// Корзина оценивается по текущим ценам. Если событие уже есть, возвращается ErrCheckoutCompleted.
+func (c *CheckoutUseCase) recoverCheckoutEvent(ctx context.Context, session *domain.CheckoutSession) (*CompleteCheckoutRes, error) {
+	_, err := c.outboxRepo.GetByEventID(ctx, checkoutEventID(session.ID))
+	if err == nil {
+		return nil, e.ErrCheckoutCompleted
+	}
+	if !errors.Is(err, e.ErrEventNotFound) {
+		return nil, err
+	}
+
+	details, err := c.priceSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, c.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	event, err := c.createCheckoutEvent(ctx, details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Warnf("Checkout event recovered. session_id: %s, event_id: %s", session.ID, event.EventID)
+
+	return NewCompleteCheckoutRes(details, event), nil
+}
+
+// createCheckoutEvent сохраняет событие checkout_completed в outbox в транзакции из контекста.
+func (c *CheckoutUseCase) createCheckoutEvent(ctx context.Context, details *CheckoutDetails) (*OutboxEvent, error) {
+	eventID := checkoutEventID(details.Session.ID)
+	payload, err := c.producer.GetCheckoutPayloadBytes(NewCheckoutCompletedMessageReq(eventID, details))
+	if err != nil {
+		return nil, err
+	}
+
+	// Событие не относится к одному продукту, поэтому product_id равен 0
+	return c.outboxRepo.Create(ctx, NewOutboxEvent(eventID, 0, CheckoutCompletedEvent, payload))
+}
+
+// checkoutEventID возвращает ID события checkout_completed сессии. ID детерминирован и служит ключом
+// идемпотентности: у сессии может быть только одно событие оформления.
+func checkoutEventID(sessionID string) uuid.UUID {
+	return uuid.NewSHA1(checkoutEventNamespace, []byte(sessionID))
+}
+
+// modifySession применяет изменение к открытой сессии и сохраняет её, если версия в Redis не изменилась.
+// При параллельном изменении сессия перечитывается и изменение применяется повторно.
+// Возвращает сессию, оценённую по текущим ценам; корзина с позициями в разных валютах не сохраняется.
+func (c *CheckoutUseCase) modifySession(ctx context.Context, id string, modify func(session *domain.CheckoutSession) error) (*CheckoutDetails, error) {
+	for attempt := 1; ; attempt++ {
+		session, err := c.checkoutRepo.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if session.Status != domain.CheckoutOpen {
+			return nil, e.ErrCheckoutCompleted
+		}
+
+		if err := modify(session); err != nil {
+			return nil, err
+		}
+		session.UpdatedAt = time.Now().UTC()
+		session.Version++
+
+		details, err := c.priceSession(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+
+		err = c.checkoutRepo.Update(ctx, session)
+		if err == nil {
+			return details, nil
+		}
+		if !errors.Is(err, e.ErrCheckoutConflict) || attempt == maxCheckoutAttempts {
+			return nil, err
+		}
+	}
+}
+
+// priceSession оценивает позиции сессии по текущим ценам продуктов, в магазине — по его ценам.
+// Позиции архивных и удалённых продуктов, продуктов вне ассортимента магазина, а также позиции,
+// не соответствующие единице измерения продукта, отмечаются недоступными.
+func (c *CheckoutUseCase) priceSession(ctx context.Context, session *domain.CheckoutSession) (*CheckoutDetails, error) {
+	ctx, err := sessionContext(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	total := domain.NewMoney(0, domain.DefaultCurrency)
+	lines := make([]CheckoutLineDetails, 0, len(session.Lines))
+	if len(session.Lines) == 0 {
+		return NewCheckoutDetails(session, lines, total), nil
+	}
+
+	res, err := c.productUC.GetProductsInfo(ctx, NewGetProductsReq(session.ProductIDs()))
+	if err != nil {
+		return nil, err
+	}
+
+	products := make(map[int64]*ProductInfo, len(res.Products))
+	for i := range res.Products {
+		products[res.Products[i].ID] = &res.Products[i]
+	}
+
+	priced := 0
+	for _, line := range session.Lines {
+		// Позиция слитого продукта оценивается и публикуется по продукту-получателю, в сессии ID не меняется
+		if targetID, ok := res.Redirects[line.ProductID]; ok {
+			line.ProductID = targetID
+		}
+
+		details := CheckoutLineDetails{Line: line, Product: products[line.ProductID]}
+		if details.Product != nil {
+			lineTotal, err := line.Total(details.Product.Price, details.Product.Unit)
+			if err == nil {
+				details.Total, details.Available = lineTotal, true
+			}
+		}
+
+		if details.Available {
+			if priced == 0 {
+				total.Currency = details.Total.Currency
+			} else if details.Total.Currency != total.Currency {
+				return nil, e.ErrCurrencyMismatch
+			}
+
+			total.Amount += details.Total.Amount
+			priced++
+		}
+
+		lines = append(lines, details)
+	}
+
+	return NewCheckoutDetails(session, lines, total), nil
+}
+
+// sessionContext ограничивает контекст магазином сессии. Запрос из другого магазина или
+// из магазина к сессии без магазина отклоняется.
+func sessionContext(ctx context.Context, session *domain.CheckoutSession) (context.Context, error) {
+	storeID, ok := storectx.StoreIDFromCtx(ctx)
+	if session.StoreID == nil {
+		if ok {
+			return nil, e.ErrStoreMismatch
+		}
+		return ctx, nil
+	}
+
+	if ok && storeID != *session.StoreID {
+		return nil, e.ErrStoreMismatch
+	}
+
+	return storectx.WithStoreID(ctx, *session.StoreID), nil
+}
+
+// getProduct возвращает неархивный продукт по ID.
+func (c *CheckoutUseCase) getProduct(ctx context.Context, id int64) (*ProductInfo, error) {
+	res, err := c.productUC.GetProductsInfo(ctx, NewGetProductsReq([]int64{id}))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Products) == 0 {
+		return nil, e.ErrProductNotFound
+	}
+
+	return &res.Products[0], nil
+}
+
+// newCheckoutLine создаёт позицию продукта и проверяет кол-во и вес по его единице измерения.
+// Для весового продукта обязателен вес, для штучного кол-во по умолчанию равно 1.
+func newCheckoutLine(product *ProductInfo, quantity int64, weightGrams int64, method domain.IdentificationMethod) (domain.CheckoutLine, error) {
+	quantity, err := itemQuantity(product.Unit, quantity)
+	if err != nil {
+		return domain.CheckoutLine{}, err
+	}
+
+	line := domain.NewCheckoutLine(uuid.NewString(), product.ID, quantity, weightGrams, method, time.Now().UTC())
+	if _, err := line.Total(product.Price, product.Unit); err != nil {
+		return domain.CheckoutLine{}, err
+	}
+
+	return line, nil
+}
+
+// addCheckoutLine добавляет позицию в сессию и возвращает ID позиции, в которую попал продукт.
+// Штучный продукт, добавленный по ID или штрихкоду, объединяется с позицией того же продукта,
+// добавленной тем же способом; распознанные и весовые продукты всегда образуют новую позицию.
+func addCheckoutLine(session *domain.CheckoutSession, line domain.CheckoutLine) (string, error) {
+	if line.Method != domain.IdentifiedByRecognition && line.WeightGrams == 0 {
+		for i := range session.Lines {
+			existing := &session.Lines[i]
+			if existing.ProductID != line.ProductID || existing.Method != line.Method ||
+				existing.WeightGrams != 0 || !equalOptionalString(existing.Barcode, line.Barcode) {
+				continue
+			}
+
+			if existing.Quantity+line.Quantity > domain.MaxLineQuantity {
+				return "", e.ErrInvalidQuantity
+			}
+			existing.Quantity += line.Quantity
+
+			return existing.ID, nil
+		}
+	}
+
+	if len(session.Lines) >= domain.MaxCheckoutLines {
+		return "", e.ErrTooManyLines
+	}
+	session.Lines = append(session.Lines, line)
+
+	return line.ID, nil
+}
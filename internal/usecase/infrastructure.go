@@ -14,5 +14,7 @@ type ImagesInfra interface {
 type MessageProducer interface {
 	WriteMessage(ctx context.Context, req *WriteMessageReq) error
 	GetPayloadBytes(req *WriteMessageReq) ([]byte, error)
+	GetCheckoutPayloadBytes(req *CheckoutCompletedMessageReq) ([]byte, error)
+	GetStockPayloadBytes(req *StockChangedMessageReq) ([]byte, error)
 	WriteRawMessage(ctx context.Context, req *WriteRawMessageReq) error
 }
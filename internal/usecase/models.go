@@ -12,10 +12,44 @@ import (
 
 // AddNewProductReq — запрос на добавление нового продукта.
 type AddNewProductReq struct {
-	Name         string
-	CategoryName string
-	Price        int64
-	Images       []ProductImage
+	Name            string
+	CategoryName    string
+	Price           domain.Money
+	LocalizedNames  domain.LocalizedNames // переводы названия, nil — не изменяются
+	Identifiers     ProductIdentifiers
+	Variant         ProductVariant
+	Images          []ProductImage
+	ExpectedVersion *int64                 // версия существующего продукта с тем же названием, nil — только создание
+	OnDuplicate     domain.DuplicatePolicy // действие, если изображения нового продукта похожи на опубликованные
+}
+
+// RegisterProductRes — событие изменения продукта и вероятные дубликаты, отмеченные для проверяющего.
+// Event равен nil, если изменились только поля продукта без изображений.
+type RegisterProductRes struct {
+	Event      *OutboxEvent
+	Duplicates []DuplicateCandidate
+}
+
+// DuplicateCandidate — опубликованный продукт, изображения которого похожи на изображения регистрируемого продукта.
+type DuplicateCandidate struct {
+	ProductID int64
+	Name      string
+	Score     float32
+}
+
+// ProductIdentifiers — артикул, штрихкоды и единица измерения продукта. Nil-поля не изменяются;
+// пустой артикул удаляет артикул, пустой (не nil) список удаляет все штрихкоды.
+type ProductIdentifiers struct {
+	SKU      *string
+	Barcodes []string
+	Unit     *domain.Unit
+}
+
+// ProductVariant — атрибуты продукта и его группа вариантов. Nil-поля не изменяются;
+// пустой (не nil) набор удаляет все атрибуты, нулевой ID группы исключает продукт из группы.
+type ProductVariant struct {
+	Attributes     domain.Attributes
+	VariantGroupID *int64
 }
 
 // ProductImage представляет изображение, загруженное через multipart/form-data.
@@ -32,17 +66,335 @@ type GetProductsReq struct {
 }
 
 // GetProductsRes — ответ с данными запрошенных продуктов.
+// Для ID слитых продуктов возвращается продукт-получатель, Redirects сопоставляет запрошенный ID с его ID.
 type GetProductsRes struct {
 	Products         []ProductInfo
 	NotFoundProducts []int64
+	Redirects        map[int64]int64
 }
 
 // ProductInfo — DTO с информацией о продукте для внешнего использования.
 type ProductInfo struct {
-	ID           int64
-	Name         string
+	ID             int64
+	Name           string
+	CategoryName   string
+	CategoryPath   []string // названия категорий от корня до категории продукта включительно
+	Price          domain.Money
+	SKU            *string
+	Barcodes       []string
+	Unit           domain.Unit
+	LocalizedNames domain.LocalizedNames
+	Attributes     domain.Attributes
+	VariantGroupID *int64
+	Version        int64
+}
+
+// UpdateProductReq — запрос на частичное изменение продукта. Nil-поля не изменяются.
+type UpdateProductReq struct {
+	ID              int64
+	Name            *string
+	CategoryName    *string
+	Price           *domain.Money
+	LocalizedNames  domain.LocalizedNames // пустой (не nil) набор удаляет все переводы
+	Identifiers     ProductIdentifiers
+	Variant         ProductVariant
+	ExpectedVersion *int64 // версия продукта, известная клиенту; обязательна
+}
+
+// ProductTransitionReq — запрос на перевод продукта в другой статус. Проверяющий обязателен
+// для одобрения и отклонения, комментарий — для отклонения.
+type ProductTransitionReq struct {
+	ProductID int64
+	Reviewer  string
+	Comment   string
+}
+
+// ProductTransitionRes — продукт после перехода, запись истории статусов и событие перехода.
+type ProductTransitionRes struct {
+	Product    *domain.Product
+	Transition *domain.ProductTransition
+	Event      *OutboxEvent
+}
+
+// MergeProductsReq — запрос на слияние продукта-дубликата SourceID с продуктом TargetID.
+type MergeProductsReq struct {
+	SourceID int64
+	TargetID int64
+}
+
+// MergeProductsRes — продукты после слияния, перенесённые векторы и события слияния источника
+// и добавления векторов получателю.
+type MergeProductsRes struct {
+	Source      *domain.Product
+	Target      *domain.Product
+	Redirect    *domain.ProductRedirect
+	Embeddings  []domain.Embedding
+	SourceEvent *OutboxEvent
+	TargetEvent *OutboxEvent
+}
+
+// ProductDetails — продукт с названием категории.
+type ProductDetails struct {
+	Product      *domain.Product
 	CategoryName string
-	Price        int64
+}
+
+// AttributeSchemaDetails — собственная схема атрибутов категории и действующие для её продуктов
+// определения с учётом схем предков.
+type AttributeSchemaDetails struct {
+	Schema    *domain.AttributeSchema
+	Effective []domain.AttributeDef
+}
+
+// VariantGroupDetails — группа вариантов и её неархивные продукты.
+type VariantGroupDetails struct {
+	Group    *domain.VariantGroup
+	Variants []ProductInfo
+}
+
+// CategoryDetails — категория с путём от корня дерева и количеством неархивных продуктов в ней.
+type CategoryDetails struct {
+	Category     *domain.Category
+	Path         []string // названия категорий от корня до текущей включительно
+	ProductCount int64
+}
+
+// CreateCategoryReq — запрос на создание категории.
+type CreateCategoryReq struct {
+	Name     string
+	ParentID *int64 // nil — корневая категория
+}
+
+// SchedulePriceReq — запрос на планирование цены продукта.
+type SchedulePriceReq struct {
+	ProductID     int64
+	Price         domain.Money
+	EffectiveFrom time.Time
+}
+
+// PriceByWeightReq — запрос на расчёт стоимости весового продукта по показанию весов.
+type PriceByWeightReq struct {
+	ProductID   int64
+	WeightGrams int64
+}
+
+// WeightedPrice — стоимость весового продукта: цена за килограмм в Product.Price и итог за взвешенное кол-во.
+type WeightedPrice struct {
+	Product     ProductInfo
+	WeightGrams int64
+	Total       domain.Money
+}
+
+// UpdateProductRes — результат изменения продукта.
+type UpdateProductRes struct {
+	Product ProductDetails
+	Event   *OutboxEvent
+}
+
+// ProductSortField — поле сортировки списка продуктов.
+type ProductSortField string
+
+const (
+	SortByID        ProductSortField = "id"
+	SortByName      ProductSortField = "name"
+	SortByPrice     ProductSortField = "price"
+	SortByCreatedAt ProductSortField = "created_at"
+	SortByUpdatedAt ProductSortField = "updated_at" // для неизменённых продуктов используется created_at
+)
+
+// ProductFilter — условия выборки продуктов. Nil-поля не ограничивают выборку.
+type ProductFilter struct {
+	CategoryID           *int64
+	IncludeSubcategories bool // учитывать продукты всех потомков CategoryID
+	Currency             *domain.Currency
+	MinPrice             *int64 // в минимальных единицах валюты
+	MaxPrice             *int64 // в минимальных единицах валюты
+	Archived             *bool
+	Status               *domain.ProductStatus
+	CreatedFrom          *time.Time
+	CreatedTo            *time.Time
+	UpdatedFrom          *time.Time
+	UpdatedTo            *time.Time
+}
+
+// ListProductsReq — запрос страницы списка продуктов.
+type ListProductsReq struct {
+	Filter ProductFilter
+	SortBy ProductSortField // пустая строка — SortByID
+	Desc   bool
+	Limit  int    // 0 — значение по умолчанию
+	Cursor string // курсор из предыдущего ответа, пустая строка — первая страница
+}
+
+// ListProductsRes — страница списка продуктов. NextCursor пуст на последней странице.
+type ListProductsRes struct {
+	Products   []ProductDetails
+	NextCursor string
+}
+
+// ProductCursor — позиция в списке продуктов: значение поля сортировки и ID последнего продукта страницы.
+type ProductCursor struct {
+	SortBy ProductSortField `json:"s"`
+	Desc   bool             `json:"d"`
+	Value  string           `json:"v"`
+	ID     int64            `json:"id"`
+}
+
+// ListProductsQuery — выборка продуктов для репозитория.
+type ListProductsQuery struct {
+	Filter ProductFilter
+	SortBy ProductSortField
+	Desc   bool
+	Limit  int
+	After  *ProductCursor
+}
+
+// AddProductImagesReq — запрос на добавление изображений существующему продукту.
+type AddProductImagesReq struct {
+	ProductID int64
+	Images    []ProductImage
+}
+
+// AddProductImagesRes — ID добавленных изображений (векторов) и событие изменения продукта.
+type AddProductImagesRes struct {
+	ImageIDs []string
+	Event    *OutboxEvent
+}
+
+// RecognizeProductReq — запрос на распознавание продукта по одному или нескольким кадрам одного товара.
+type RecognizeProductReq struct {
+	Images       []ProductImage
+	Limit        int               // макс. кол-во кандидатов, 0 — значение из конфигурации
+	Fusion       string            // стратегия объединения кадров (rrf, centroid), пустая строка — значение из конфигурации
+	CategoryID   *int64            // ограничение поиска поддеревом категории, nil — без ограничения
+	IncludeStock bool              // добавить к кандидатам остатки магазина из контекста
+	Attributes   domain.Attributes // фильтр по значениям атрибутов, например осям группы вариантов; nil — без ограничения
+}
+
+// RecognitionCandidate — продукт-кандидат с агрегированной оценкой схожести.
+type RecognitionCandidate struct {
+	Product ProductInfo
+	Score   float32
+	Hits    int                // кол-во найденных изображений продукта
+	Stock   *domain.StockLevel // остаток в магазине, если запрошен и учитывается
+}
+
+// RecognitionVerdict — итоговое решение по результату распознавания.
+type RecognitionVerdict string
+
+const (
+	VerdictAccepted  RecognitionVerdict = "accepted"  // лучший кандидат уверенно распознан
+	VerdictAmbiguous RecognitionVerdict = "ambiguous" // несколько близких кандидатов или недостаточный score
+	VerdictUnknown   RecognitionVerdict = "unknown"   // продукт отсутствует в каталоге
+	VerdictVariant   RecognitionVerdict = "variant"   // уверенно распознана группа вариантов, но не вариант
+)
+
+// RecognizeProductRes — результат распознавания: кандидаты, отсортированные по убыванию score.
+// При вердикте VerdictAccepted распознанным продуктом является первый кандидат. При вердикте VerdictVariant
+// VariantGroup содержит распознанную группу, а вариант выбирается кассиром или повторным распознаванием
+// с фильтром по атрибутам-осям группы.
+type RecognizeProductRes struct {
+	Verdict      RecognitionVerdict
+	Candidates   []RecognitionCandidate
+	VariantGroup *VariantGroupDetails
+	ModelVersion string
+}
+
+// AddCheckoutItemReq — добавление продукта в корзину по ID или штрихкоду; задаётся ровно одно из полей.
+type AddCheckoutItemReq struct {
+	SessionID   string
+	ProductID   *int64
+	Barcode     *string
+	Quantity    int64 // кол-во штук, 0 — одна штука
+	WeightGrams int64 // показание весов, обязательно для весового продукта
+}
+
+// AddRecognizedItemReq — добавление в корзину продукта, распознанного по кадрам.
+type AddRecognizedItemReq struct {
+	SessionID   string
+	Recognition *RecognizeProductReq
+	Quantity    int64 // кол-во штук, 0 — одна штука
+	WeightGrams int64 // показание весов, обязательно для весового продукта
+}
+
+// AddRecognizedItemRes — корзина и результат распознавания. Продукт добавляется только при вердикте
+// VerdictAccepted; иначе LineID пуст, а кассир выбирает продукт из кандидатов и добавляет его по ID.
+type AddRecognizedItemRes struct {
+	Checkout    *CheckoutDetails
+	LineID      string
+	Recognition *RecognizeProductRes
+}
+
+// UpdateCheckoutLineReq — изменение кол-ва штучного или веса весового продукта в позиции.
+type UpdateCheckoutLineReq struct {
+	SessionID   string
+	LineID      string
+	Quantity    *int64
+	WeightGrams *int64
+}
+
+// CheckoutLineDetails — позиция корзины со стоимостью по текущей цене продукта.
+// Недоступная позиция (продукт архивирован или удалён) не учитывается в итоге.
+type CheckoutLineDetails struct {
+	Line      domain.CheckoutLine
+	Product   *ProductInfo // nil, если продукт недоступен
+	Total     domain.Money
+	Available bool
+}
+
+// CheckoutDetails — корзина с позициями и итоговой стоимостью.
+type CheckoutDetails struct {
+	Session *domain.CheckoutSession
+	Lines   []CheckoutLineDetails
+	Total   domain.Money
+}
+
+// CompleteCheckoutRes — оформленная корзина и событие checkout_completed.
+type CompleteCheckoutRes struct {
+	Checkout *CheckoutDetails
+	Event    *OutboxEvent
+}
+
+// PromotionMatch — действующая акция, подходящая продукту напрямую или через его категорию.
+type PromotionMatch struct {
+	ProductID int64
+	Promotion *domain.Promotion
+}
+
+// PromotionItem — продукт и его кол-во или вес для расчёта скидок.
+type PromotionItem struct {
+	ProductID   int64
+	Quantity    int64 // для штучного продукта, 0 означает 1
+	WeightGrams int64 // для весового продукта
+}
+
+// EvaluatePromotionsReq — расчёт скидок для списка продуктов по акциям, действующим в момент At.
+type EvaluatePromotionsReq struct {
+	Items []PromotionItem
+	At    time.Time
+}
+
+// DiscountedLine — позиция со стоимостью, скидкой и результатами проверки каждой подходящей акции.
+// Недоступная позиция (продукт архивирован или не найден) не оценивается.
+type DiscountedLine struct {
+	LineID      string // ID позиции корзины; пустой при расчёте для списка продуктов
+	ProductID   int64
+	Product     *ProductInfo
+	Quantity    int64
+	WeightGrams int64
+	Total       domain.Money
+	Discount    domain.Money
+	Available   bool
+	Evaluations []domain.PromotionEvaluation
+}
+
+// DiscountedCart — позиции со скидками и итоги: стоимость без скидок, сумма скидок и стоимость к оплате.
+type DiscountedCart struct {
+	Lines       []DiscountedLine
+	Subtotal    domain.Money
+	Discount    domain.Money
+	Total       domain.Money
+	EvaluatedAt time.Time
 }
 
 // INFRASTUCTURE
@@ -59,7 +411,9 @@ const (
 type OutboxEventType string
 
 const (
-	ProductEvent OutboxEventType = "product_event"
+	ProductEvent           OutboxEventType = "product_event"
+	CheckoutCompletedEvent OutboxEventType = "checkout_completed"
+	StockEvent             OutboxEventType = "stock_changed"
 )
 
 type OutboxEvent struct {
@@ -74,16 +428,69 @@ type OutboxEvent struct {
 	ProcessedAt         *time.Time
 }
 
+// ProductOperation — тип изменения продукта, передаваемого в ProductChangeEvent.
+type ProductOperation string
+
+const (
+	OperationUpsert          ProductOperation = "upsert"
+	OperationUpdate          ProductOperation = "update"
+	OperationArchive         ProductOperation = "archive"
+	OperationUnarchive       ProductOperation = "unarchive"
+	OperationDelete          ProductOperation = "delete"
+	OperationDeleteImages    ProductOperation = "delete_images"
+	OperationStoreAssortment ProductOperation = "store_assortment"
+	OperationStatusChange    ProductOperation = "status_change"
+	OperationMerge           ProductOperation = "merge"
+)
+
 type WriteMessageReq struct {
+	Operation  ProductOperation
 	ProductID  int64
-	Embeddings []domain.Embedding
+	Version    int64                     // версия продукта после изменения, для OperationDelete — следующая за последней
+	Embeddings []domain.Embedding        // для OperationDelete и OperationDeleteImages — удалённые векторы, для OperationMerge — перенесённые
+	Product    *ProductDetails           // для OperationUpdate — продукт после изменения
+	Assortment *StoreAssortment          // для OperationStoreAssortment — изменение ассортимента магазина
+	Transition *domain.ProductTransition // для OperationStatusChange — переход между статусами
+	TargetID   int64                     // для OperationMerge — продукт, в который слит продукт
+}
+
+// StoreAssortment — изменение ассортимента магазина для продукта.
+type StoreAssortment struct {
+	Product domain.StoreProduct
+	Removed bool // продукт исключён из ассортимента магазина
+}
+
+// SetStoreProductRes — запись ассортимента магазина после изменения и событие изменения.
+type SetStoreProductRes struct {
+	Product *domain.StoreProduct
+	Event   *OutboxEvent
 }
 
 type WriteRawMessageReq struct {
-	ProductID int64
+	EventType OutboxEventType // определяет топик Kafka
+	Key       string          // ключ сообщения: ID продукта или ID события
 	Payload   []byte
 }
 
+// CheckoutCompletedMessageReq — данные события checkout_completed для аналитики.
+type CheckoutCompletedMessageReq struct {
+	EventID  uuid.UUID
+	Checkout *CheckoutDetails
+}
+
+// StockChangedMessageReq — данные события stock_changed об изменении остатка товара в магазине.
+type StockChangedMessageReq struct {
+	EventID uuid.UUID
+	Change  *domain.StockChange
+}
+
+// StockChangeRes — остаток после изменения, резерв для операций с резервом и событие изменения.
+type StockChangeRes struct {
+	Level       *domain.StockLevel
+	Reservation *domain.StockReservation
+	Event       *OutboxEvent
+}
+
 // VectorizeReq — запрос на векторизацию изображений.
 type VectorizeReq struct {
 	Images []ProductImage
@@ -95,10 +502,18 @@ type VectorizeRes struct {
 	ModelVersion string
 }
 
-// TODO: пересмотреть структуру
-// UploadImagesRes — результат загрузки изображений (ключи в MinIO).
+// UploadedImage — изображение, загруженное в MinIO.
+type UploadedImage struct {
+	ID       string // uuid изображения, используется также как ID вектора
+	Key      string // ключ объекта в MinIO
+	MimeType string
+	Size     int64
+	SHA256   string // hex-представление sha256 содержимого
+}
+
+// UploadImagesRes — результат загрузки изображений в порядке изображений запроса.
 type UploadImagesRes struct {
-	ImagesKeys []string
+	Images []UploadedImage
 }
 
 // UploadImagesReq — запрос на загрузку изображений продукта.
@@ -112,6 +527,7 @@ type UploadImagesReq struct {
 type UpsertProductRes struct {
 	Product   *domain.Product
 	NoChanges bool
+	Previous  *domain.Product // существующий продукт до изменения; nil, если продукт создан или не изменился
 }
 
 // MAPPERS
@@ -122,12 +538,14 @@ func NewUpsertProductRes(product *domain.Product, noChanges bool) *UpsertProduct
 	}
 }
 
-func NewProductInfo(id int64, name string, category string, price int64) ProductInfo {
+func NewProductInfo(id int64, name string, category string, categoryPath []string, price domain.Money, version int64) ProductInfo {
 	return ProductInfo{
 		ID:           id,
 		Name:         name,
 		CategoryName: category,
+		CategoryPath: categoryPath,
 		Price:        price,
+		Version:      version,
 	}
 }
 
@@ -145,24 +563,82 @@ func NewUploadImagesReq(name string, images []ProductImage) *UploadImagesReq {
 	}
 }
 
-func NewUploadImagesRes(imagesKeys []string) *UploadImagesRes {
+func NewUploadImagesRes(images []UploadedImage) *UploadImagesRes {
 	return &UploadImagesRes{
-		ImagesKeys: imagesKeys,
+		Images: images,
+	}
+}
+
+func NewUploadedImage(id string, key string, mimeType string, size int64, sha256 string) *UploadedImage {
+	return &UploadedImage{
+		ID:       id,
+		Key:      key,
+		MimeType: mimeType,
+		Size:     size,
+		SHA256:   sha256,
 	}
 }
 
+// Keys возвращает ключи загруженных изображений в MinIO.
+func (r *UploadImagesRes) Keys() []string {
+	keys := make([]string, 0, len(r.Images))
+	for _, image := range r.Images {
+		keys = append(keys, image.Key)
+	}
+
+	return keys
+}
+
 func NewVectorizeReq(images []ProductImage) *VectorizeReq {
 	return &VectorizeReq{
 		Images: images,
 	}
 }
 
-func NewAddNewProductReq(name string, category string, price int64, images []ProductImage) *AddNewProductReq {
+func NewAddNewProductReq(
+	name string,
+	category string,
+	price domain.Money,
+	localizedNames domain.LocalizedNames,
+	identifiers ProductIdentifiers,
+	variant ProductVariant,
+	images []ProductImage,
+	expectedVersion *int64,
+	onDuplicate domain.DuplicatePolicy,
+) *AddNewProductReq {
 	return &AddNewProductReq{
-		Name:         name,
-		CategoryName: category,
-		Price:        price,
-		Images:       images,
+		Name:            name,
+		CategoryName:    category,
+		Price:           price,
+		LocalizedNames:  localizedNames,
+		Identifiers:     identifiers,
+		Variant:         variant,
+		Images:          images,
+		ExpectedVersion: expectedVersion,
+		OnDuplicate:     onDuplicate,
+	}
+}
+
+func NewRegisterProductRes(event *OutboxEvent, duplicates []DuplicateCandidate) *RegisterProductRes {
+	return &RegisterProductRes{
+		Event:      event,
+		Duplicates: duplicates,
+	}
+}
+
+func NewDuplicateCandidate(productID int64, name string, score float32) *DuplicateCandidate {
+	return &DuplicateCandidate{
+		ProductID: productID,
+		Name:      name,
+		Score:     score,
+	}
+}
+
+func NewProductIdentifiers(sku *string, barcodes []string, unit *domain.Unit) ProductIdentifiers {
+	return ProductIdentifiers{
+		SKU:      sku,
+		Barcodes: barcodes,
+		Unit:     unit,
 	}
 }
 
@@ -175,10 +651,11 @@ func NewProductImage(data []byte, mimeType string, size int64, name string) *Pro
 	}
 }
 
-func NewGetProductsRes(pr []ProductInfo, notFoundProducts []int64) *GetProductsRes {
+func NewGetProductsRes(pr []ProductInfo, notFoundProducts []int64, redirects map[int64]int64) *GetProductsRes {
 	return &GetProductsRes{
 		Products:         pr,
 		NotFoundProducts: notFoundProducts,
+		Redirects:        redirects,
 	}
 }
 
@@ -186,20 +663,89 @@ func NewGetProductsReq(ids []int64) *GetProductsReq {
 	return &GetProductsReq{ids}
 }
 
-func NewWriteMessageReq(productID int64, embeddings []domain.Embedding) *WriteMessageReq {
+func NewWriteMessageReq(operation ProductOperation, productID int64, version int64, embeddings []domain.Embedding) *WriteMessageReq {
 	return &WriteMessageReq{
+		Operation:  operation,
 		ProductID:  productID,
+		Version:    version,
+		Embeddings: embeddings,
+	}
+}
+
+func NewStoreAssortmentMessageReq(productID int64, version int64, assortment *StoreAssortment) *WriteMessageReq {
+	return &WriteMessageReq{
+		Operation:  OperationStoreAssortment,
+		ProductID:  productID,
+		Version:    version,
+		Assortment: assortment,
+	}
+}
+
+func NewSetStoreProductRes(product *domain.StoreProduct, event *OutboxEvent) *SetStoreProductRes {
+	return &SetStoreProductRes{
+		Product: product,
+		Event:   event,
+	}
+}
+
+func NewStatusChangeMessageReq(version int64, transition *domain.ProductTransition) *WriteMessageReq {
+	return &WriteMessageReq{
+		Operation:  OperationStatusChange,
+		ProductID:  transition.ProductID,
+		Version:    version,
+		Transition: transition,
+	}
+}
+
+func NewMergeMessageReq(sourceID int64, version int64, targetID int64, embeddings []domain.Embedding) *WriteMessageReq {
+	return &WriteMessageReq{
+		Operation:  OperationMerge,
+		ProductID:  sourceID,
+		Version:    version,
 		Embeddings: embeddings,
+		TargetID:   targetID,
 	}
 }
 
-func NewWriteRawMessageReq(productID int64, payload []byte) *WriteRawMessageReq {
+func NewUpdateMessageReq(product *ProductDetails) *WriteMessageReq {
+	return &WriteMessageReq{
+		Operation: OperationUpdate,
+		ProductID: product.Product.ID,
+		Version:   product.Product.Version,
+		Product:   product,
+	}
+}
+
+func NewWriteRawMessageReq(eventType OutboxEventType, key string, payload []byte) *WriteRawMessageReq {
 	return &WriteRawMessageReq{
-		ProductID: productID,
+		EventType: eventType,
+		Key:       key,
 		Payload:   payload,
 	}
 }
 
+func NewCheckoutCompletedMessageReq(eventID uuid.UUID, checkout *CheckoutDetails) *CheckoutCompletedMessageReq {
+	return &CheckoutCompletedMessageReq{
+		EventID:  eventID,
+		Checkout: checkout,
+	}
+}
+
+func NewStockChangedMessageReq(eventID uuid.UUID, change *domain.StockChange) *StockChangedMessageReq {
+	return &StockChangedMessageReq{
+		EventID: eventID,
+		Change:  change,
+	}
+}
+
+func NewStockChangeRes(level *domain.StockLevel, reservation *domain.StockReservation, event *OutboxEvent) *StockChangeRes {
+	return &StockChangeRes{
+		Level:       level,
+		Reservation: reservation,
+		Event:       event,
+	}
+}
+
 func NewOutboxEvent(eventID uuid.UUID, productID int64, eventType OutboxEventType, payload []byte) *OutboxEvent {
 	return &OutboxEvent{
 		EventID:   eventID,
@@ -210,3 +756,280 @@ func NewOutboxEvent(eventID uuid.UUID, productID int64, eventType OutboxEventTyp
 		CreatedAt: time.Now(),
 	}
 }
+
+func NewRecognizeProductReq(
+	images []ProductImage,
+	limit int,
+	fusion string,
+	categoryID *int64,
+	includeStock bool,
+	attributes domain.Attributes,
+) *RecognizeProductReq {
+	return &RecognizeProductReq{
+		Images:       images,
+		Limit:        limit,
+		Fusion:       fusion,
+		CategoryID:   categoryID,
+		IncludeStock: includeStock,
+		Attributes:   attributes,
+	}
+}
+
+func NewRecognitionCandidate(product ProductInfo, score float32, hits int) RecognitionCandidate {
+	return RecognitionCandidate{
+		Product: product,
+		Score:   score,
+		Hits:    hits,
+	}
+}
+
+func NewRecognizeProductRes(verdict RecognitionVerdict, candidates []RecognitionCandidate, modelVersion string) *RecognizeProductRes {
+	return &RecognizeProductRes{
+		Verdict:      verdict,
+		Candidates:   candidates,
+		ModelVersion: modelVersion,
+	}
+}
+
+func NewUpdateProductReq(
+	id int64,
+	name *string,
+	categoryName *string,
+	price *domain.Money,
+	localizedNames domain.LocalizedNames,
+	identifiers ProductIdentifiers,
+	variant ProductVariant,
+	expectedVersion *int64,
+) *UpdateProductReq {
+	return &UpdateProductReq{
+		ID:              id,
+		Name:            name,
+		CategoryName:    categoryName,
+		Price:           price,
+		LocalizedNames:  localizedNames,
+		Identifiers:     identifiers,
+		Variant:         variant,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func NewSchedulePriceReq(productID int64, price domain.Money, effectiveFrom time.Time) *SchedulePriceReq {
+	return &SchedulePriceReq{
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+	}
+}
+
+func NewPriceByWeightReq(productID int64, weightGrams int64) *PriceByWeightReq {
+	return &PriceByWeightReq{
+		ProductID:   productID,
+		WeightGrams: weightGrams,
+	}
+}
+
+func NewWeightedPrice(product ProductInfo, weightGrams int64, total domain.Money) *WeightedPrice {
+	return &WeightedPrice{
+		Product:     product,
+		WeightGrams: weightGrams,
+		Total:       total,
+	}
+}
+
+func NewProductTransitionReq(productID int64, reviewer string, comment string) *ProductTransitionReq {
+	return &ProductTransitionReq{
+		ProductID: productID,
+		Reviewer:  reviewer,
+		Comment:   comment,
+	}
+}
+
+func NewProductTransitionRes(product *domain.Product, transition *domain.ProductTransition, event *OutboxEvent) *ProductTransitionRes {
+	return &ProductTransitionRes{
+		Product:    product,
+		Transition: transition,
+		Event:      event,
+	}
+}
+
+func NewMergeProductsReq(sourceID int64, targetID int64) *MergeProductsReq {
+	return &MergeProductsReq{
+		SourceID: sourceID,
+		TargetID: targetID,
+	}
+}
+
+func NewMergeProductsRes(
+	source *domain.Product,
+	target *domain.Product,
+	redirect *domain.ProductRedirect,
+	embeddings []domain.Embedding,
+	sourceEvent *OutboxEvent,
+	targetEvent *OutboxEvent,
+) *MergeProductsRes {
+	return &MergeProductsRes{
+		Source:      source,
+		Target:      target,
+		Redirect:    redirect,
+		Embeddings:  embeddings,
+		SourceEvent: sourceEvent,
+		TargetEvent: targetEvent,
+	}
+}
+
+func NewProductDetails(product *domain.Product, categoryName string) *ProductDetails {
+	return &ProductDetails{
+		Product:      product,
+		CategoryName: categoryName,
+	}
+}
+
+func NewAttributeSchemaDetails(schema *domain.AttributeSchema, effective []domain.AttributeDef) *AttributeSchemaDetails {
+	return &AttributeSchemaDetails{
+		Schema:    schema,
+		Effective: effective,
+	}
+}
+
+func NewVariantGroupDetails(group *domain.VariantGroup, variants []ProductInfo) *VariantGroupDetails {
+	return &VariantGroupDetails{
+		Group:    group,
+		Variants: variants,
+	}
+}
+
+func NewCategoryDetails(category *domain.Category, path []string, productCount int64) *CategoryDetails {
+	return &CategoryDetails{
+		Category:     category,
+		Path:         path,
+		ProductCount: productCount,
+	}
+}
+
+func NewCreateCategoryReq(name string, parentID *int64) *CreateCategoryReq {
+	return &CreateCategoryReq{
+		Name:     name,
+		ParentID: parentID,
+	}
+}
+
+func NewUpdateProductRes(product ProductDetails, event *OutboxEvent) *UpdateProductRes {
+	return &UpdateProductRes{
+		Product: product,
+		Event:   event,
+	}
+}
+
+func NewAddProductImagesReq(productID int64, images []ProductImage) *AddProductImagesReq {
+	return &AddProductImagesReq{
+		ProductID: productID,
+		Images:    images,
+	}
+}
+
+func NewAddProductImagesRes(imageIDs []string, event *OutboxEvent) *AddProductImagesRes {
+	return &AddProductImagesRes{
+		ImageIDs: imageIDs,
+		Event:    event,
+	}
+}
+
+func NewListProductsReq(filter ProductFilter, sortBy ProductSortField, desc bool, limit int, cursor string) *ListProductsReq {
+	return &ListProductsReq{
+		Filter: filter,
+		SortBy: sortBy,
+		Desc:   desc,
+		Limit:  limit,
+		Cursor: cursor,
+	}
+}
+
+func NewListProductsRes(products []ProductDetails, nextCursor string) *ListProductsRes {
+	return &ListProductsRes{
+		Products:   products,
+		NextCursor: nextCursor,
+	}
+}
+
+func NewListProductsQuery(filter ProductFilter, sortBy ProductSortField, desc bool, limit int, after *ProductCursor) *ListProductsQuery {
+	return &ListProductsQuery{
+		Filter: filter,
+		SortBy: sortBy,
+		Desc:   desc,
+		Limit:  limit,
+		After:  after,
+	}
+}
+
+func NewAddCheckoutItemReq(sessionID string, productID *int64, barcode *string, quantity int64, weightGrams int64) *AddCheckoutItemReq {
+	return &AddCheckoutItemReq{
+		SessionID:   sessionID,
+		ProductID:   productID,
+		Barcode:     barcode,
+		Quantity:    quantity,
+		WeightGrams: weightGrams,
+	}
+}
+
+func NewAddRecognizedItemReq(sessionID string, recognition *RecognizeProductReq, quantity int64, weightGrams int64) *AddRecognizedItemReq {
+	return &AddRecognizedItemReq{
+		SessionID:   sessionID,
+		Recognition: recognition,
+		Quantity:    quantity,
+		WeightGrams: weightGrams,
+	}
+}
+
+func NewAddRecognizedItemRes(checkout *CheckoutDetails, lineID string, recognition *RecognizeProductRes) *AddRecognizedItemRes {
+	return &AddRecognizedItemRes{
+		Checkout:    checkout,
+		LineID:      lineID,
+		Recognition: recognition,
+	}
+}
+
+func NewUpdateCheckoutLineReq(sessionID string, lineID string, quantity *int64, weightGrams *int64) *UpdateCheckoutLineReq {
+	return &UpdateCheckoutLineReq{
+		SessionID:   sessionID,
+		LineID:      lineID,
+		Quantity:    quantity,
+		WeightGrams: weightGrams,
+	}
+}
+
+func NewCheckoutDetails(session *domain.CheckoutSession, lines []CheckoutLineDetails, total domain.Money) *CheckoutDetails {
+	return &CheckoutDetails{
+		Session: session,
+		Lines:   lines,
+		Total:   total,
+	}
+}
+
+func NewCompleteCheckoutRes(checkout *CheckoutDetails, event *OutboxEvent) *CompleteCheckoutRes {
+	return &CompleteCheckoutRes{
+		Checkout: checkout,
+		Event:    event,
+	}
+}
+
+func NewPromotionMatch(productID int64, promotion *domain.Promotion) PromotionMatch {
+	return PromotionMatch{
+		ProductID: productID,
+		Promotion: promotion,
+	}
+}
+
+func NewPromotionItem(productID int64, quantity int64, weightGrams int64) PromotionItem {
+	return PromotionItem{
+		ProductID:   productID,
+		Quantity:    quantity,
+		WeightGrams: weightGrams,
+	}
+}
+
+func NewEvaluatePromotionsReq(items []PromotionItem, at time.Time) *EvaluatePromotionsReq {
+	return &EvaluatePromotionsReq{
+		Items: items,
+		At:    at,
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ArchiveProduct архивирует опубликованный продукт: он перестаёт участвовать в распознавании и в выдаче GetProductsInfo.
+func (p *ProductUseCase) ArchiveProduct(ctx context.Context, id int64) (*OutboxEvent, error) {
+	const op = "ProductUseCase.ArchiveProduct"
+
+	res, err := p.changeStatus(ctx, id, domain.ProductArchived, nil, nil)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res.Event, nil
+}
+
+// UnarchiveProduct восстанавливает архивный продукт, он снова публикуется.
+func (p *ProductUseCase) UnarchiveProduct(ctx context.Context, id int64) (*OutboxEvent, error) {
+	const op = "ProductUseCase.UnarchiveProduct"
+
+	res, err := p.changeStatus(ctx, id, domain.ProductActive, nil, nil)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res.Event, nil
+}
+
+// DeleteProduct безвозвратно удаляет продукт, его векторы и изображения.
+func (p *ProductUseCase) DeleteProduct(ctx context.Context, id int64) (*OutboxEvent, error) {
+	const op = "ProductUseCase.DeleteProduct"
+
+	var (
+		err        error
+		embeddings []domain.Embedding
+		deleted    bool
+	)
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	// Если произошла ошибка, происходит Rollback транзакции и восстановление удалённых векторов
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if deleted && len(embeddings) > 0 {
+				if _, err := p.embeddingRepo.Upsert(ctx, embeddings); err != nil {
+					p.logger.Warnf("Failed to restore Qdrant points. product_id: %d, error: %v", id, err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	// Записи реестра изображений удаляются каскадно вместе с продуктом
+	metas, err := p.imageMetaRepo.ListByProduct(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	version, err := p.productRepo.Delete(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Векторы сохраняются до удаления для восстановления при ошибке и для события
+	embeddings, err = p.embeddingRepo.GetByProduct(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err = p.embeddingRepo.DeleteByProduct(ctx, id); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	deleted = true
+
+	// Удаление следует за последней версией продукта
+	event, err := p.createProductEvent(ctx, NewWriteMessageReq(OperationDelete, id, version+1, embeddings))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err := p.cacheRepo.DeleteProducts(ctx, []int64{id}); err != nil {
+		p.logger.Warnf("Failed to delete products from cache: %v", e.Wrap(op, err))
+	}
+
+	// Изображения удаляются только после коммита, т.к. их невозможно восстановить
+	p.imagesInfra.CleanupImages(productImageKeys(metas, embeddings))
+
+	return event, nil
+}
+
+// createProductEvent сохраняет ProductChangeEvent в outbox в рамках текущей транзакции.
+func (p *ProductUseCase) createProductEvent(ctx context.Context, req *WriteMessageReq) (*OutboxEvent, error) {
+	payload, err := p.producer.GetPayloadBytes(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.outboxRepo.Create(ctx, NewOutboxEvent(uuid.New(), req.ProductID, ProductEvent, payload))
+}
+
+// imageKeys возвращает ключи изображений, по которым построены векторы.
+func imageKeys(embeddings []domain.Embedding) []string {
+	keys := make([]string, 0, len(embeddings))
+	for _, embedding := range embeddings {
+		if key, ok := embedding.Payload.ImagePath(); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// productImageKeys объединяет ключи изображений из реестра и из payload векторов без повторов.
+func productImageKeys(metas []*domain.ImageMeta, embeddings []domain.Embedding) []string {
+	seen := make(map[string]struct{}, len(metas)+len(embeddings))
+	keys := make([]string, 0, len(metas)+len(embeddings))
+	for _, key := range imageKeys(embeddings) {
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	for _, meta := range metas {
+		if _, ok := seen[meta.ObjectKey]; !ok {
+			seen[meta.ObjectKey] = struct{}{}
+			keys = append(keys, meta.ObjectKey)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// applyAssortment оставляет продукты, которые продаются в магазине, и заменяет их цену ценой магазина.
+// Продукты вне ассортимента и недоступные в магазине добавляются к ненайденным.
+func (p *ProductUseCase) applyAssortment(ctx context.Context, storeID int64, products []ProductInfo, notFound []int64) ([]ProductInfo, []int64, error) {
+	ids := make([]int64, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	assortment, err := p.getStoreProducts(ctx, storeID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]ProductInfo, 0, len(products))
+	for _, product := range products {
+		storeProduct, ok := assortment[product.ID]
+		if !ok || !storeProduct.Sells() {
+			notFound = append(notFound, product.ID)
+			continue
+		}
+
+		if storeProduct.Price != nil {
+			product.Price = *storeProduct.Price
+		}
+		result = append(result, product)
+	}
+
+	return result, notFound, nil
+}
+
+// getStoreProducts возвращает записи ассортимента магазина с использованием кэша.
+// Для продуктов вне ассортимента возвращаются и кэшируются записи с IsAvailable = false.
+func (p *ProductUseCase) getStoreProducts(ctx context.Context, storeID int64, ids []int64) (map[int64]*domain.StoreProduct, error) {
+	const op = "ProductUseCase.getStoreProducts"
+
+	result := make(map[int64]*domain.StoreProduct, len(ids))
+
+	cached, err := p.cacheRepo.GetStoreProducts(ctx, storeID, ids)
+	missing := make([]int64, 0)
+	for _, id := range ids {
+		if storeProduct, ok := cached[id]; ok && err == nil {
+			result[id] = &storeProduct
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fromDB, err := p.storeRepo.GetProducts(ctx, storeID, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storeProduct := range fromDB {
+		result[storeProduct.ProductID] = storeProduct
+	}
+
+	toCache := make([]domain.StoreProduct, 0, len(missing))
+	for _, id := range missing {
+		if _, ok := result[id]; !ok {
+			result[id] = domain.NewStoreProduct(storeID, id, false, nil)
+		}
+		toCache = append(toCache, *result[id])
+	}
+
+	// Фоновое добавление ассортимента в кэш
+	go func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		if err := p.cacheRepo.SetStoreProducts(bgCtx, toCache); err != nil {
+			p.logger.Warnf("Failed to cache store products in background: %v", e.Wrap(op, err))
+		}
+	}()
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// DuplicateProductsError — отказ в регистрации продукта, изображения которого похожи на изображения опубликованных продуктов.
+// Оборачивает e.ErrPossibleDuplicate и содержит вероятные дубликаты, начиная с самого похожего.
+type DuplicateProductsError struct {
+	Candidates []DuplicateCandidate
+}
+
+func NewDuplicateProductsError(candidates []DuplicateCandidate) *DuplicateProductsError {
+	return &DuplicateProductsError{Candidates: candidates}
+}
+
+func (err *DuplicateProductsError) Error() string {
+	return e.ErrPossibleDuplicate.Error()
+}
+
+func (err *DuplicateProductsError) Unwrap() error {
+	return e.ErrPossibleDuplicate
+}
+
+// GetDuplicateFlags возвращает вероятные дубликаты, отмеченные при регистрации продукта.
+func (p *ProductUseCase) GetDuplicateFlags(ctx context.Context, id int64) ([]*domain.DuplicateFlag, error) {
+	const op = "ProductUseCase.GetDuplicateFlags"
+
+	if _, err := p.productRepo.GetByID(ctx, id); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	flags, err := p.duplicateRepo.ListByProduct(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return flags, nil
+}
+
+// findDuplicates ищет опубликованные продукты, похожие на продукт productID по векторам его изображений.
+// Score кандидата — наибольшая близость среди всех изображений, кандидаты ниже DuplicateThreshold отбрасываются.
+// Возвращается не больше MaxCandidates продуктов, начиная с самого похожего.
+func (p *ProductUseCase) findDuplicates(ctx context.Context, productID int64, vectors []VectorizeRes) ([]DuplicateCandidate, error) {
+	queries := make([][]float32, 0, len(vectors))
+	for _, vector := range vectors {
+		queries = append(queries, vector.Vector)
+	}
+
+	hits, err := p.embeddingRepo.SearchBatch(ctx, queries, p.recCfg.SearchLimit, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[int64]float32)
+	for _, imageHits := range hits {
+		for _, hit := range imageHits {
+			id, ok := hit.Payload.ProductID()
+			if !ok || id == productID || hit.Score < p.recCfg.DuplicateThreshold {
+				continue
+			}
+
+			if score, seen := scores[id]; !seen || hit.Score > score {
+				scores[id] = hit.Score
+			}
+		}
+	}
+
+	if len(scores) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+
+	// Названия нужны, чтобы по ответу было понятно, с чем совпал продукт
+	products, err := p.getProductsInfo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]DuplicateCandidate, 0, len(products))
+	for _, product := range products {
+		candidates = append(candidates, *NewDuplicateCandidate(product.ID, product.Name, scores[product.ID]))
+	}
+
+	slices.SortFunc(candidates, func(a, b DuplicateCandidate) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ProductID, b.ProductID)
+	})
+
+	if len(candidates) > p.recCfg.MaxCandidates {
+		candidates = candidates[:p.recCfg.MaxCandidates]
+	}
+
+	return candidates, nil
+}
+
+// duplicateFlags формирует отметки вероятных дубликатов продукта productID.
+func duplicateFlags(productID int64, candidates []DuplicateCandidate) []domain.DuplicateFlag {
+	flags := make([]domain.DuplicateFlag, 0, len(candidates))
+	for _, candidate := range candidates {
+		flags = append(flags, *domain.NewDuplicateFlag(productID, candidate.ProductID, candidate.Score))
+	}
+
+	return flags
+}
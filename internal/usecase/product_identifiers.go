@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+// GetProductByBarcode возвращает неархивный продукт по штрихкоду.
+// Соответствие штрихкода продукту кэшируется; запись кэша проверяется по штрихкодам самого продукта,
+// поэтому после переназначения штрихкода устаревшая запись не используется.
+func (p *ProductUseCase) GetProductByBarcode(ctx context.Context, barcode string) (*ProductInfo, error) {
+	const op = "ProductUseCase.GetProductByBarcode"
+
+	code, err := domain.ParseBarcode(barcode)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if id, ok, err := p.cacheRepo.GetBarcodeProductID(ctx, code); err == nil && ok {
+		product, err := p.getProductInfo(ctx, id)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if product != nil && slices.Contains(product.Barcodes, code) {
+			return product, nil
+		}
+	}
+
+	id, err := p.productRepo.GetIDByBarcode(ctx, code)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	product, err := p.getProductInfo(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Архивный продукт не выдаётся, как и в GetProductsInfo
+	if product == nil {
+		return nil, e.Wrap(op, e.ErrProductNotFound)
+	}
+
+	go func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		if err := p.cacheRepo.SetBarcodeProductID(bgCtx, code, id); err != nil {
+			p.logger.Warnf("Failed to cache barcode in background: %v", e.Wrap(op, err))
+		}
+	}()
+
+	return product, nil
+}
+
+// getProductInfo возвращает информацию о неархивном продукте с использованием кэша или nil, если продукт не найден.
+func (p *ProductUseCase) getProductInfo(ctx context.Context, id int64) (*ProductInfo, error) {
+	res, err := p.GetProductsInfo(ctx, NewGetProductsReq([]int64{id}))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Products) == 0 {
+		return nil, nil
+	}
+
+	return &res.Products[0], nil
+}
+
+// normalizeIdentifiers проверяет артикул и штрихкоды и приводит их к хранимому виду.
+// Единица измерения проверяется при разборе запроса.
+func normalizeIdentifiers(ids *ProductIdentifiers) error {
+	if ids.SKU != nil && *ids.SKU != "" {
+		sku, err := domain.ParseSKU(*ids.SKU)
+		if err != nil {
+			return err
+		}
+		ids.SKU = &sku
+	}
+
+	if ids.Barcodes != nil {
+		barcodes, err := domain.ParseBarcodes(ids.Barcodes)
+		if err != nil {
+			return err
+		}
+		ids.Barcodes = barcodes
+	}
+
+	return nil
+}
+
+// applyIdentifiers переносит заданные идентификаторы в продукт и сообщает, изменились ли
+// поля продукта и набор его штрихкодов.
+func applyIdentifiers(product *domain.Product, ids ProductIdentifiers) (bool, bool) {
+	fieldsChanged, barcodesChanged := false, false
+
+	if ids.SKU != nil {
+		var sku *string
+		if *ids.SKU != "" {
+			sku = ids.SKU
+		}
+
+		if !equalOptionalString(product.SKU, sku) {
+			product.SKU = sku
+			fieldsChanged = true
+		}
+	}
+
+	if ids.Unit != nil && *ids.Unit != product.Unit {
+		product.Unit = *ids.Unit
+		fieldsChanged = true
+	}
+
+	if ids.Barcodes != nil && !slices.Equal(ids.Barcodes, product.Barcodes) {
+		product.Barcodes = ids.Barcodes
+		barcodesChanged = true
+	}
+
+	return fieldsChanged, barcodesChanged
+}
+
+// equalOptionalString сравнивает необязательные строки, например артикулы.
+func equalOptionalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// AddProductImages добавляет изображения существующему продукту: загружает их в MinIO,
+// сохраняет векторы в Qdrant и публикует событие через outbox.
+// Всего у продукта может быть не больше domain.MaxProductImages изображений.
+// Векторизация и загрузка выполняются до блокировки продукта, чтобы медленный ML-сервис
+// не задерживал другие изменения продукта; лимит изображений перепроверяется под блокировкой.
+func (p *ProductUseCase) AddProductImages(ctx context.Context, req *AddProductImagesReq) (*AddProductImagesRes, error) {
+	const op = "ProductUseCase.AddProductImages"
+
+	var err error
+	if len(req.Images) == 0 {
+		return nil, e.Wrap(op, e.ErrNoImages)
+	}
+
+	var (
+		imagesRes  *UploadImagesRes
+		uploaded   bool
+		embeddings []domain.Embedding
+		upserted   bool
+	)
+
+	details, err := p.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	product := details.Product
+
+	if err = p.checkImagesLimit(ctx, product.ID, len(req.Images)); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	vectors, err := p.getVectors(ctx, req.Images)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	// Если произошла ошибка, происходит Rollback транзакции, очистка загруженных изображений и векторов
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if uploaded && imagesRes != nil {
+				p.logger.Warnf(
+					"Cleaning up orphaned images after transaction failure. product_id: %d, error: %v",
+					req.ProductID,
+					e.Wrap(op, err),
+				)
+
+				p.imagesInfra.CleanupImages(imagesRes.Keys())
+			}
+
+			if upserted {
+				if err := p.embeddingRepo.Delete(ctx, embeddings); err != nil {
+					p.logger.Warnf("Failed to cleanup Qdrant points %v", err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	imagesRes, err = p.uploadImages(ctx, product.Name, req.Images)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	uploaded = true
+
+	// Блокировка продукта исключает параллельное удаление и превышение лимита параллельными загрузками
+	if product, err = p.productRepo.GetForUpdate(ctx, req.ProductID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err = p.checkImagesLimit(ctx, product.ID, len(req.Images)); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	storeIDs, err := p.storeRepo.ListProductStoreIDs(ctx, product.ID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	embeddings, err = p.getEmbeddings(product, imagesRes.Images, vectors, storeIDs)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if _, err = p.upsertEmbeddings(ctx, embeddings); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	upserted = true
+
+	if err = p.saveImagesMeta(ctx, product.ID, imagesRes.Images, vectors); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	version, err := p.productRepo.IncrementVersion(ctx, product.ID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	event, err := p.createProductEvent(ctx, NewWriteMessageReq(OperationUpsert, product.ID, version, embeddings))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	imageIDs := make([]string, 0, len(embeddings))
+	for _, embedding := range embeddings {
+		imageIDs = append(imageIDs, embedding.ID)
+	}
+
+	return NewAddProductImagesRes(imageIDs, event), nil
+}
+
+// checkImagesLimit проверяет, что после добавления added изображений у продукта их будет не больше domain.MaxProductImages.
+// Учитываются векторы в Qdrant, т.к. изображения, загруженные до появления реестра, есть только там.
+func (p *ProductUseCase) checkImagesLimit(ctx context.Context, productID int64, added int) error {
+	existing, err := p.embeddingRepo.CountByProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	if existing+added > domain.MaxProductImages {
+		return e.ErrTooManyImages
+	}
+
+	return nil
+}
+
+// DeleteProductImage удаляет изображение продукта, запись о нём в реестре и его вектор.
+// ID изображения совпадает с ID вектора в Qdrant.
+func (p *ProductUseCase) DeleteProductImage(ctx context.Context, productID int64, imageID string) (*OutboxEvent, error) {
+	const op = "ProductUseCase.DeleteProductImage"
+
+	var (
+		err       error
+		embedding *domain.Embedding
+		deleted   bool
+	)
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	// Если произошла ошибка, происходит Rollback транзакции и восстановление удалённого вектора
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if deleted {
+				if _, err := p.embeddingRepo.Upsert(ctx, []domain.Embedding{*embedding}); err != nil {
+					p.logger.Warnf("Failed to restore Qdrant point. image_id: %s, error: %v", imageID, err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	if _, err = p.productRepo.GetForUpdate(ctx, productID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Изображения, загруженные до появления реестра, ищутся только по вектору
+	pointID := imageID
+	meta, err := p.imageMetaRepo.Delete(ctx, productID, imageID)
+	if err != nil && !errors.Is(err, e.ErrImageNotFound) {
+		return nil, e.Wrap(op, err)
+	}
+	if meta != nil && meta.EmbeddingID != nil {
+		pointID = *meta.EmbeddingID
+	}
+
+	embedding, err = p.embeddingRepo.Get(ctx, pointID)
+	switch {
+	case errors.Is(err, e.ErrImageNotFound) && meta != nil:
+		// Вектор уже отсутствует, удаляется только изображение
+		embedding, err = nil, nil
+	case err != nil:
+		return nil, e.Wrap(op, err)
+	case !belongsTo(embedding, productID):
+		err = e.ErrImageNotFound
+		return nil, e.Wrap(op, err)
+	}
+
+	keys := make([]string, 0, 1)
+	embeddings := make([]domain.Embedding, 0, 1)
+	if meta != nil {
+		keys = append(keys, meta.ObjectKey)
+	}
+	if embedding != nil {
+		if err = p.embeddingRepo.Delete(ctx, []domain.Embedding{*embedding}); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		deleted = true
+
+		embeddings = append(embeddings, *embedding)
+		if meta == nil {
+			keys = imageKeys(embeddings)
+		}
+	}
+
+	version, err := p.productRepo.IncrementVersion(ctx, productID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	event, err := p.createProductEvent(ctx, NewWriteMessageReq(OperationDeleteImages, productID, version, embeddings))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Изображение удаляется только после коммита, т.к. его невозможно восстановить
+	p.imagesInfra.CleanupImages(keys)
+
+	return event, nil
+}
+
+// belongsTo проверяет, что вектор относится к продукту.
+func belongsTo(embedding *domain.Embedding, productID int64) bool {
+	id, ok := embedding.Payload.ProductID()
+	return ok && id == productID
+}
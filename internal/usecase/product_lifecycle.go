@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// SubmitProduct отправляет доработанный продукт на повторную проверку.
+func (p *ProductUseCase) SubmitProduct(ctx context.Context, req *ProductTransitionReq) (*ProductTransitionRes, error) {
+	const op = "ProductUseCase.SubmitProduct"
+
+	comment, err := domain.ParseReviewComment(req.Comment)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := p.changeStatus(ctx, req.ProductID, domain.ProductPendingReview, nil, comment)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ApproveProduct публикует проверенный продукт: он и его векторы начинают участвовать в распознавании.
+func (p *ProductUseCase) ApproveProduct(ctx context.Context, req *ProductTransitionReq) (*ProductTransitionRes, error) {
+	const op = "ProductUseCase.ApproveProduct"
+
+	reviewer, err := domain.ParseReviewer(req.Reviewer)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	comment, err := domain.ParseReviewComment(req.Comment)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := p.changeStatus(ctx, req.ProductID, domain.ProductActive, &reviewer, comment)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// RejectProduct возвращает продукт на доработку. Комментарий объясняет, что нужно исправить.
+func (p *ProductUseCase) RejectProduct(ctx context.Context, req *ProductTransitionReq) (*ProductTransitionRes, error) {
+	const op = "ProductUseCase.RejectProduct"
+
+	reviewer, err := domain.ParseReviewer(req.Reviewer)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	comment, err := domain.ParseReviewComment(req.Comment)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if comment == nil {
+		return nil, e.Wrap(op, e.ErrReviewCommentRequired)
+	}
+
+	res, err := p.changeStatus(ctx, req.ProductID, domain.ProductDraft, &reviewer, comment)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ListReviewQueue возвращает страницу продуктов, ожидающих проверки, начиная с давно не изменявшихся.
+func (p *ProductUseCase) ListReviewQueue(ctx context.Context, limit int, cursor string) (*ListProductsRes, error) {
+	const op = "ProductUseCase.ListReviewQueue"
+
+	status := domain.ProductPendingReview
+	res, err := p.ListProducts(ctx, NewListProductsReq(ProductFilter{Status: &status}, SortByUpdatedAt, false, limit, cursor))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// GetProductTransitions возвращает историю статусов продукта.
+func (p *ProductUseCase) GetProductTransitions(ctx context.Context, id int64) ([]*domain.ProductTransition, error) {
+	const op = "ProductUseCase.GetProductTransitions"
+
+	if _, err := p.productRepo.GetByID(ctx, id); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	transitions, err := p.transitionRepo.ListByProduct(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return transitions, nil
+}
+
+// changeStatus переводит продукт в статус to в БД и у его векторов в Qdrant, сохраняет переход в истории
+// и публикует событие через outbox. Архивация и восстановление публикуются событием archive, остальные переходы — status_change.
+func (p *ProductUseCase) changeStatus(
+	ctx context.Context,
+	id int64,
+	to domain.ProductStatus,
+	reviewer *string,
+	comment *string,
+) (*ProductTransitionRes, error) {
+	var (
+		err     error
+		from    domain.ProductStatus
+		flagged bool
+	)
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	// Если произошла ошибка, происходит Rollback транзакции и возврат статуса у векторов
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if flagged {
+				if err := p.embeddingRepo.SetStatus(ctx, id, from); err != nil {
+					p.logger.Warnf("Failed to restore Qdrant points status. product_id: %d, error: %v", id, err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	product, err := p.productRepo.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	from = product.Status
+
+	if from == to {
+		err = e.ErrNoChanges
+		return nil, err
+	}
+
+	if !from.CanTransition(to) {
+		err = e.ErrInvalidTransition
+		return nil, err
+	}
+
+	// Восстановить слитый продукт или продукт в архивной категории нельзя
+	if from == domain.ProductArchived {
+		var redirects map[int64]int64
+		redirects, err = p.redirectRepo.GetTargets(ctx, []int64{id})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := redirects[id]; ok {
+			err = e.ErrProductMerged
+			return nil, err
+		}
+
+		var category *domain.Category
+		category, err = p.categoryRepo.GetForUpdate(ctx, product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		if category.IsArchived {
+			err = e.ErrCategoryArchived
+			return nil, err
+		}
+	}
+
+	product, err = p.productRepo.SetStatus(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.embeddingRepo.SetStatus(ctx, id, to); err != nil {
+		return nil, err
+	}
+	flagged = true
+
+	transition, err := p.transitionRepo.Create(ctx, domain.NewProductTransition(id, from, to, reviewer, comment))
+	if err != nil {
+		return nil, err
+	}
+
+	var req *WriteMessageReq
+	switch {
+	case to == domain.ProductArchived:
+		req = NewWriteMessageReq(OperationArchive, id, product.Version, nil)
+	case from == domain.ProductArchived:
+		req = NewWriteMessageReq(OperationUnarchive, id, product.Version, nil)
+	default:
+		req = NewStatusChangeMessageReq(product.Version, transition)
+	}
+
+	event, err := p.createProductEvent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cacheRepo.DeleteProducts(ctx, []int64{id}); err != nil {
+		p.logger.Warnf("Failed to delete products from cache: %v", err)
+	}
+
+	return NewProductTransitionRes(product, transition, event), nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+
+	// cursorTimeLayout — формат времени в курсоре, совместимый с приведением к TIMESTAMP в PostgreSQL
+	cursorTimeLayout = "2006-01-02 15:04:05.999999"
+)
+
+// ListProducts возвращает страницу списка продуктов с фильтрацией и сортировкой.
+// Пагинация курсорная: следующая страница запрашивается по NextCursor с теми же фильтрами и сортировкой.
+func (p *ProductUseCase) ListProducts(ctx context.Context, req *ListProductsReq) (*ListProductsRes, error) {
+	const op = "ProductUseCase.ListProducts"
+
+	sortBy, limit, err := validateListProducts(req)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	after, err := decodeProductCursor(req.Cursor, sortBy, req.Desc)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Лишний продукт показывает, что следующая страница существует
+	products, err := p.productRepo.List(ctx, NewListProductsQuery(req.Filter, sortBy, req.Desc, limit+1, after))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	var nextCursor string
+	if len(products) > limit {
+		products = products[:limit]
+		nextCursor = encodeProductCursor(newProductCursor(&products[limit-1], sortBy, req.Desc))
+	}
+
+	return NewListProductsRes(products, nextCursor), nil
+}
+
+// validateListProducts проверяет запрос списка продуктов и возвращает поле сортировки и размер страницы с учётом значений по умолчанию.
+func validateListProducts(req *ListProductsReq) (ProductSortField, int, error) {
+	sortBy := req.SortBy
+	switch sortBy {
+	case "":
+		sortBy = SortByID
+	case SortByID, SortByName, SortByPrice, SortByCreatedAt, SortByUpdatedAt:
+	default:
+		return "", 0, e.ErrInvalidSort
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit < 0 || limit > maxListLimit {
+		return "", 0, e.ErrInvalidLimit
+	}
+
+	f := req.Filter
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return "", 0, e.ErrInvalidFilter
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return "", 0, e.ErrInvalidFilter
+	}
+	if f.UpdatedFrom != nil && f.UpdatedTo != nil && f.UpdatedFrom.After(*f.UpdatedTo) {
+		return "", 0, e.ErrInvalidFilter
+	}
+
+	return sortBy, limit, nil
+}
+
+// newProductCursor формирует курсор, указывающий на продукт.
+func newProductCursor(product *ProductDetails, sortBy ProductSortField, desc bool) *ProductCursor {
+	cursor := &ProductCursor{SortBy: sortBy, Desc: desc, ID: product.Product.ID}
+
+	switch sortBy {
+	case SortByName:
+		cursor.Value = product.Product.Name
+	case SortByPrice:
+		cursor.Value = strconv.FormatInt(product.Product.Price.Amount, 10)
+	case SortByCreatedAt:
+		cursor.Value = product.Product.CreatedAt.UTC().Format(cursorTimeLayout)
+	case SortByUpdatedAt:
+		updatedAt := product.Product.CreatedAt
+		if product.Product.UpdatedAt != nil {
+			updatedAt = *product.Product.UpdatedAt
+		}
+		cursor.Value = updatedAt.UTC().Format(cursorTimeLayout)
+	}
+
+	return cursor
+}
+
+// encodeProductCursor сериализует курсор в непрозрачную строку.
+func encodeProductCursor(cursor *ProductCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeProductCursor разбирает курсор и проверяет, что он получен с той же сортировкой.
+func decodeProductCursor(s string, sortBy ProductSortField, desc bool) (*ProductCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, e.ErrInvalidCursor
+	}
+
+	var cursor ProductCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, e.ErrInvalidCursor
+	}
+
+	if cursor.SortBy != sortBy || cursor.Desc != desc {
+		return nil, e.ErrInvalidCursor
+	}
+
+	switch sortBy {
+	case SortByPrice:
+		if _, err := strconv.ParseInt(cursor.Value, 10, 64); err != nil {
+			return nil, e.ErrInvalidCursor
+		}
+	case SortByCreatedAt, SortByUpdatedAt:
+		if _, err := time.Parse(cursorTimeLayout, cursor.Value); err != nil {
+			return nil, e.ErrInvalidCursor
+		}
+	}
+
+	return &cursor, nil
+}
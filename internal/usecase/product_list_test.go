@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+)
+
+func TestProductCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.FixedZone("MSK", 3*60*60))
+	updatedAt := createdAt.Add(time.Hour)
+
+	product := &ProductDetails{Product: &domain.Product{
+		ID:        42,
+		Name:      "Кола \"Добрая\" 0,5 л",
+		Price:     domain.NewMoney(8999, domain.CurrencyRUB),
+		CreatedAt: createdAt,
+		UpdatedAt: &updatedAt,
+	}}
+	notUpdated := &ProductDetails{Product: &domain.Product{ID: 43, CreatedAt: createdAt}}
+
+	tests := []struct {
+		name    string
+		product *ProductDetails
+		sortBy  ProductSortField
+		value   string
+	}{
+		{"id", product, SortByID, ""},
+		{"name", product, SortByName, "Кола \"Добрая\" 0,5 л"},
+		{"price", product, SortByPrice, "8999"},
+		{"created_at in UTC", product, SortByCreatedAt, "2026-03-14 06:26:53.589793"},
+		{"updated_at", product, SortByUpdatedAt, "2026-03-14 07:26:53.589793"},
+		{"updated_at falls back to created_at", notUpdated, SortByUpdatedAt, "2026-03-14 06:26:53.589793"},
+	}
+
+	for _, tt := range tests {
+		for _, desc := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s desc=%t", tt.name, desc), func(t *testing.T) {
+				s := encodeProductCursor(newProductCursor(tt.product, tt.sortBy, desc))
+
+				got, err := decodeProductCursor(s, tt.sortBy, desc)
+				if err != nil {
+					t.Fatalf("decodeProductCursor() error = %v", err)
+				}
+
+				want := ProductCursor{SortBy: tt.sortBy, Desc: desc, Value: tt.value, ID: tt.product.Product.ID}
+				if *got != want {
+					t.Errorf("decodeProductCursor() = %+v, want %+v", *got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeProductCursor(t *testing.T) {
+	product := &ProductDetails{Product: &domain.Product{
+		ID:        1,
+		Name:      "Вода",
+		Price:     domain.NewMoney(5000, domain.CurrencyRUB),
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	encode := func(sortBy ProductSortField, desc bool) string {
+		return encodeProductCursor(newProductCursor(product, sortBy, desc))
+	}
+	raw := func(cursor ProductCursor) string {
+		return encodeProductCursor(&cursor)
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+		sortBy ProductSortField
+		desc   bool
+		err    error
+	}{
+		{"empty cursor is the first page", "", SortByID, false, nil},
+		{"same sort", encode(SortByName, true), SortByName, true, nil},
+		{"other sort field", encode(SortByName, false), SortByPrice, false, e.ErrInvalidCursor},
+		{"other direction", encode(SortByPrice, false), SortByPrice, true, e.ErrInvalidCursor},
+		{"other sort field and direction", encode(SortByCreatedAt, true), SortByID, false, e.ErrInvalidCursor},
+		{"not base64", "!!!", SortByID, false, e.ErrInvalidCursor},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"id"}`)), SortByID, false, e.ErrInvalidCursor},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("id:1")), SortByID, false, e.ErrInvalidCursor},
+		{"wrong json types", base64.RawURLEncoding.EncodeToString([]byte(`{"s":"id","id":"1"}`)), SortByID, false, e.ErrInvalidCursor},
+		{"price is not a number", raw(ProductCursor{SortBy: SortByPrice, Value: "89.99", ID: 1}), SortByPrice, false, e.ErrInvalidCursor},
+		{"empty price", raw(ProductCursor{SortBy: SortByPrice, ID: 1}), SortByPrice, false, e.ErrInvalidCursor},
+		{"time in other layout", raw(ProductCursor{SortBy: SortByCreatedAt, Value: "2026-01-01T00:00:00Z", ID: 1}), SortByCreatedAt, false, e.ErrInvalidCursor},
+		{"empty time", raw(ProductCursor{SortBy: SortByUpdatedAt, ID: 1}), SortByUpdatedAt, false, e.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeProductCursor(tt.cursor, tt.sortBy, tt.desc)
+			if !errors.Is(err, tt.err) || (tt.err != nil) != (err != nil) {
+				t.Fatalf("decodeProductCursor() error = %v, want %v", err, tt.err)
+			}
+			if (got == nil) != (tt.err != nil || tt.cursor == "") {
+				t.Errorf("decodeProductCursor() = %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import "github.com/DRSN-tech/go-backend/internal/domain"
+
+// localizeNames заменяет названия продуктов переводами на язык lang. Продукты без перевода
+// сохраняют каноническое название.
+func localizeNames(products []ProductInfo, lang string) {
+	for i := range products {
+		if name, ok := products[i].LocalizedNames.Name(lang); ok {
+			products[i].Name = name
+		}
+	}
+}
+
+// applyLocalizedNames заменяет переводы названия продукта, если они переданы. Возвращает true, если переводы изменились.
+func applyLocalizedNames(product *domain.Product, names domain.LocalizedNames) bool {
+	if names == nil || names.Equal(product.LocalizedNames) {
+		return false
+	}
+
+	product.LocalizedNames = names
+	return true
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/storectx"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// MergeProducts сливает продукт-дубликат с продуктом-получателем: переносит изображения и векторы источника
+// получателю, архивирует источник и сохраняет перенаправление, по которому GetProductsInfo возвращает получатель.
+// Публикуются событие merge для источника и событие upsert с перенесёнными векторами для получателя.
+// Если вместе с изображениями источника у получателя их станет больше domain.MaxProductImages,
+// слияние отклоняется: лишние изображения нужно сначала удалить.
+func (p *ProductUseCase) MergeProducts(ctx context.Context, req *MergeProductsReq) (*MergeProductsRes, error) {
+	const op = "ProductUseCase.MergeProducts"
+
+	if req.SourceID == req.TargetID {
+		return nil, e.Wrap(op, e.ErrMergeSameProduct)
+	}
+
+	var (
+		err      error
+		original []domain.Embedding
+		moved    []domain.Embedding
+		upserted bool
+	)
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	// Если произошла ошибка, происходит Rollback транзакции и возврат векторов источнику
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if upserted {
+				if _, err := p.embeddingRepo.Upsert(ctx, original); err != nil {
+					p.logger.Warnf("Failed to restore Qdrant points. product_id: %d, error: %v", req.SourceID, err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	source, target, err := p.lockMergedProducts(ctx, req.SourceID, req.TargetID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if target.Status == domain.ProductArchived {
+		err = e.ErrMergeTargetArchived
+		return nil, e.Wrap(op, err)
+	}
+
+	redirects, err := p.redirectRepo.GetTargets(ctx, []int64{source.ID})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	if _, ok := redirects[source.ID]; ok {
+		err = e.ErrProductMerged
+		return nil, e.Wrap(op, err)
+	}
+
+	storeIDs, err := p.storeRepo.ListProductStoreIDs(ctx, target.ID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	original, err = p.embeddingRepo.GetByProduct(ctx, source.ID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Получатель блокирован, поэтому параллельная загрузка изображений не превысит лимит после слияния
+	if err = p.checkImagesLimit(ctx, target.ID, len(original)); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	moved = reassignEmbeddings(original, target, storeIDs)
+	if len(moved) > 0 {
+		if _, err = p.upsertEmbeddings(ctx, moved); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		upserted = true
+	}
+
+	if err = p.imageMetaRepo.Reassign(ctx, source.ID, target.ID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Источник архивируется в обход проверки переходов: слить можно и непроверенный дубликат
+	if source.Status != domain.ProductArchived {
+		from := source.Status
+		if source, err = p.productRepo.SetStatus(ctx, source.ID, domain.ProductArchived); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if _, err = p.transitionRepo.Create(ctx, domain.NewProductTransition(source.ID, from, domain.ProductArchived, nil, nil)); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	} else {
+		if source.Version, err = p.productRepo.IncrementVersion(ctx, source.ID); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	redirect, err := p.redirectRepo.Create(ctx, domain.NewProductRedirect(source.ID, target.ID))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if target.Version, err = p.productRepo.IncrementVersion(ctx, target.ID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	sourceEvent, err := p.createProductEvent(ctx, NewMergeMessageReq(source.ID, source.Version, target.ID, moved))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	targetEvent, err := p.createProductEvent(ctx, NewWriteMessageReq(OperationUpsert, target.ID, target.Version, moved))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err := p.cacheRepo.DeleteProducts(ctx, []int64{source.ID, target.ID}); err != nil {
+		p.logger.Warnf("Failed to delete products from cache: %v", err)
+	}
+
+	return NewMergeProductsRes(source, target, redirect, moved, sourceEvent, targetEvent), nil
+}
+
+// lockMergedProducts блокирует источник и получатель слияния в порядке возрастания ID,
+// чтобы встречные слияния одной пары не приводили к взаимной блокировке.
+func (p *ProductUseCase) lockMergedProducts(ctx context.Context, sourceID int64, targetID int64) (*domain.Product, *domain.Product, error) {
+	firstID, secondID := sourceID, targetID
+	if firstID > secondID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := p.productRepo.GetForUpdate(ctx, firstID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	second, err := p.productRepo.GetForUpdate(ctx, secondID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if first.ID == sourceID {
+		return first, second, nil
+	}
+
+	return second, first, nil
+}
+
+// reassignEmbeddings копирует векторы с payload продукта-получателя: его ID, статусом, магазинами и атрибутами.
+// ID векторов сохраняются, поэтому запись копий в Qdrant заменяет исходные точки.
+func reassignEmbeddings(embeddings []domain.Embedding, target *domain.Product, storeIDs []int64) []domain.Embedding {
+	moved := make([]domain.Embedding, 0, len(embeddings))
+	for _, embedding := range embeddings {
+		payload := maps.Clone(embedding.Payload)
+		payload.SetProductID(target.ID)
+		payload.SetStoreIDs(storeIDs)
+		payload.SetVariant(target.Attributes, target.VariantGroupID)
+		payload.SetStatus(target.Status)
+		moved = append(moved, *domain.NewEmbedding(embedding.ID, embedding.Vector, payload))
+	}
+
+	return moved
+}
+
+// resolveRedirects заменяет не найденные ID слитых продуктов продуктами, в которые они слиты.
+// Получатель вне ассортимента магазина из контекста не возвращается, а ID источника остаётся не найденным.
+func (p *ProductUseCase) resolveRedirects(
+	ctx context.Context,
+	products []ProductInfo,
+	notFound []int64,
+) ([]ProductInfo, []int64, map[int64]int64, error) {
+	targets, err := p.redirectRepo.GetTargets(ctx, notFound)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(targets) == 0 {
+		return products, notFound, nil, nil
+	}
+
+	found := make(map[int64]struct{}, len(products))
+	for _, product := range products {
+		found[product.ID] = struct{}{}
+	}
+
+	missing := make([]int64, 0, len(targets))
+	for _, targetID := range targets {
+		if _, ok := found[targetID]; !ok {
+			found[targetID] = struct{}{}
+			missing = append(missing, targetID)
+		}
+	}
+
+	if len(missing) > 0 {
+		survivors, err := p.getProductsInfo(ctx, missing)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if storeID, ok := storectx.StoreIDFromCtx(ctx); ok && len(survivors) > 0 {
+			if survivors, _, err = p.applyAssortment(ctx, storeID, survivors, nil); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		for _, id := range missing {
+			delete(found, id)
+		}
+		for _, survivor := range survivors {
+			found[survivor.ID] = struct{}{}
+			products = append(products, survivor)
+		}
+	}
+
+	redirects := make(map[int64]int64, len(targets))
+	unresolved := make([]int64, 0, len(notFound))
+	for _, id := range notFound {
+		targetID, ok := targets[id]
+		if _, survived := found[targetID]; !ok || !survived {
+			unresolved = append(unresolved, id)
+			continue
+		}
+		redirects[id] = targetID
+	}
+
+	return products, unresolved, redirects, nil
+}
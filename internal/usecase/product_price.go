@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// duePriceRetryDelay — время, на которое откладывается запланированная цена после неудачного применения
+	duePriceRetryDelay = 5 * time.Minute
+	// maxDuePriceAttempts — кол-во неудачных попыток, после которого запланированная цена больше не применяется
+	maxDuePriceAttempts = 5
+)
+
+// GetPriceHistory возвращает применённые и запланированные цены продукта, начиная с самой поздней.
+func (p *ProductUseCase) GetPriceHistory(ctx context.Context, productID int64) ([]*domain.ProductPrice, error) {
+	const op = "ProductUseCase.GetPriceHistory"
+
+	if _, err := p.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	prices, err := p.priceRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return prices, nil
+}
+
+// GetEffectivePrice возвращает цену продукта, действующую в момент at.
+// Для будущего момента учитываются запланированные цены.
+func (p *ProductUseCase) GetEffectivePrice(ctx context.Context, productID int64, at time.Time) (*domain.ProductPrice, error) {
+	const op = "ProductUseCase.GetEffectivePrice"
+
+	if _, err := p.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	price, err := p.priceRepo.GetEffective(ctx, productID, at)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return price, nil
+}
+
+// SchedulePrice планирует цену продукта, которая будет применена фоновой задачей в момент EffectiveFrom.
+func (p *ProductUseCase) SchedulePrice(ctx context.Context, req *SchedulePriceReq) (*domain.ProductPrice, error) {
+	const op = "ProductUseCase.SchedulePrice"
+
+	var err error
+	if err = req.Price.Validate(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if !req.EffectiveFrom.After(time.Now()) {
+		return nil, e.Wrap(op, e.ErrPriceNotInFuture)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	// Блокировка продукта исключает его параллельное удаление
+	if _, err = p.productRepo.GetForUpdate(ctx, req.ProductID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	price, err := p.priceRepo.Schedule(ctx, domain.NewProductPrice(req.ProductID, req.Price, req.EffectiveFrom.UTC()))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return price, nil
+}
+
+// CancelScheduledPrice отменяет ещё не применённую цену продукта.
+func (p *ProductUseCase) CancelScheduledPrice(ctx context.Context, productID int64, priceID int64) error {
+	const op = "ProductUseCase.CancelScheduledPrice"
+
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	if err = p.priceRepo.DeleteScheduled(ctx, productID, priceID); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// ApplyDuePrices применяет не более limit запланированных цен, время действия которых наступило,
+// и возвращает кол-во применённых цен. Каждая цена применяется в отдельной транзакции.
+// Цена, которую не удалось применить, отмечается неудачной и откладывается на duePriceRetryDelay,
+// а пачка продолжается со следующей цены; после maxDuePriceAttempts попыток цена больше не применяется.
+func (p *ProductUseCase) ApplyDuePrices(ctx context.Context, limit int) (int, error) {
+	const op = "ProductUseCase.ApplyDuePrices"
+
+	applied := 0
+	for applied < limit {
+		price, err := p.applyDuePrice(ctx)
+		if err != nil {
+			// Без цены ошибка не относится к конкретной цене, например недоступна БД
+			if price == nil {
+				return applied, e.Wrap(op, err)
+			}
+
+			if err := p.markDuePriceFailed(ctx, price, err); err != nil {
+				return applied, e.Wrap(op, err)
+			}
+			continue
+		}
+
+		if price == nil {
+			break
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// markDuePriceFailed отмечает неудачную попытку применения запланированной цены.
+func (p *ProductUseCase) markDuePriceFailed(ctx context.Context, price *domain.ProductPrice, cause error) error {
+	attempts, err := p.priceRepo.MarkFailed(ctx, price.ID)
+	if err != nil {
+		return err
+	}
+
+	if attempts >= maxDuePriceAttempts {
+		p.logger.Errorf(cause, "Scheduled price abandoned after %d attempts. product_id: %d, price_id: %d", attempts, price.ProductID, price.ID)
+		return nil
+	}
+
+	p.logger.Warnf("Failed to apply scheduled price, retry in %s. product_id: %d, price_id: %d, attempt: %d, error: %v",
+		duePriceRetryDelay, price.ProductID, price.ID, attempts, cause)
+
+	return nil
+}
+
+// applyDuePrice применяет самую раннюю наступившую запланированную цену: изменяет цену продукта,
+// публикует событие через outbox и сбрасывает кэш. Возвращает nil, если применять нечего.
+// При ошибке применения вместе с ошибкой возвращается цена, которую не удалось применить.
+func (p *ProductUseCase) applyDuePrice(ctx context.Context) (*domain.ProductPrice, error) {
+	const op = "ProductUseCase.applyDuePrice"
+
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	price, err := p.priceRepo.GetDueForUpdate(ctx, duePriceRetryDelay, maxDuePriceAttempts)
+	if errors.Is(err, e.ErrPriceNotFound) {
+		err = tx.Rollback(ctx)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		return nil, nil
+	}
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	product, err := p.productRepo.GetForUpdate(ctx, price.ProductID)
+	if err != nil {
+		return price, e.Wrap(op, err)
+	}
+
+	if err = p.priceRepo.MarkApplied(ctx, price.ID); err != nil {
+		return price, e.Wrap(op, err)
+	}
+
+	// Цена уже совпадает с запланированной — достаточно отметить её применённой
+	if product.Price == price.Price {
+		err = tx.Commit(ctx)
+		if err != nil {
+			return price, e.Wrap(op, err)
+		}
+
+		return price, nil
+	}
+
+	product.Price = price.Price
+	updated, err := p.productRepo.Update(ctx, product)
+	if err != nil {
+		return price, e.Wrap(op, err)
+	}
+
+	current, err := p.productRepo.GetByID(ctx, updated.ID)
+	if err != nil {
+		return price, e.Wrap(op, err)
+	}
+
+	if _, err = p.createProductEvent(ctx, NewUpdateMessageReq(NewProductDetails(updated, current.CategoryName))); err != nil {
+		return price, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return price, e.Wrap(op, err)
+	}
+
+	if err := p.cacheRepo.DeleteProducts(ctx, []int64{updated.ID}); err != nil {
+		p.logger.Warnf("Failed to delete products from cache: %v", e.Wrap(op, err))
+	}
+
+	p.logger.Infof("Scheduled price applied. product_id: %d, price_id: %d, price: %s", updated.ID, price.ID, price.Price)
+
+	return price, nil
+}
+
+// PriceByWeight рассчитывает стоимость неархивного весового продукта по показанию весов в граммах.
+// Цена берётся из той же информации о продукте, что и в GetProductsInfo, включая кэш.
+func (p *ProductUseCase) PriceByWeight(ctx context.Context, req *PriceByWeightReq) (*WeightedPrice, error) {
+	const op = "ProductUseCase.PriceByWeight"
+
+	product, err := p.getProductInfo(ctx, req.ProductID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if product == nil {
+		return nil, e.Wrap(op, e.ErrProductNotFound)
+	}
+
+	if !product.Unit.IsWeighted() {
+		return nil, e.Wrap(op, e.ErrProductNotWeighted)
+	}
+
+	total, err := domain.PriceByWeight(product.Price, req.WeightGrams)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return NewWeightedPrice(*product, req.WeightGrams, total), nil
+}
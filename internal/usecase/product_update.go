@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetProduct возвращает продукт по ID, включая архивный.
+func (p *ProductUseCase) GetProduct(ctx context.Context, id int64) (*ProductDetails, error) {
+	const op = "ProductUseCase.GetProduct"
+
+	details, err := p.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// UpdateProduct частично изменяет название, цену, переводы названия, категорию, артикул, штрихкоды, единицу измерения, атрибуты
+// и группу вариантов продукта. Изображения и векторы не затрагиваются, в их payload обновляются только атрибуты. Изменение выполняется, только если версия продукта
+// совпадает с ожидаемой, т.е. продукт не был изменён с момента её получения.
+func (p *ProductUseCase) UpdateProduct(ctx context.Context, req *UpdateProductReq) (*UpdateProductRes, error) {
+	const op = "ProductUseCase.UpdateProduct"
+
+	var (
+		err        error
+		previous   domain.Product
+		payloadSet bool
+	)
+	if err = p.validateUpdate(req); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if payloadSet {
+				if err := p.embeddingRepo.SetVariant(ctx, previous.ID, previous.Attributes, previous.VariantGroupID); err != nil {
+					p.logger.Warnf("Failed to restore Qdrant payload of product %d: %v", previous.ID, err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	product, err := p.productRepo.GetForUpdate(ctx, req.ID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if *req.ExpectedVersion != product.Version {
+		err = e.ErrVersionMismatch
+		return nil, e.Wrap(op, err)
+	}
+	previous = *product
+
+	changed, priceChanged := false, false
+	if req.Name != nil && strings.TrimSpace(*req.Name) != product.Name {
+		product.Name = strings.TrimSpace(*req.Name)
+		changed = true
+	}
+
+	if req.Price != nil && *req.Price != product.Price {
+		product.Price = *req.Price
+		changed, priceChanged = true, true
+	}
+
+	changed = applyLocalizedNames(product, req.LocalizedNames) || changed
+
+	var (
+		categoryName    string
+		categoryChanged bool
+	)
+	if req.CategoryName != nil {
+		var category *domain.Category
+		category, err = p.createCategory(ctx, strings.TrimSpace(*req.CategoryName))
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if category.ID != product.CategoryID {
+			product.CategoryID = category.ID
+			changed, categoryChanged = true, true
+		}
+		categoryName = category.Name
+	}
+
+	// Атрибуты проверяются по схеме итоговой категории продукта
+	variantUpdated, err := p.applyVariant(ctx, product, req.Variant, categoryChanged)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	changed = changed || variantUpdated
+
+	fieldsChanged, barcodesChanged := applyIdentifiers(product, req.Identifiers)
+	changed = changed || fieldsChanged || barcodesChanged
+
+	if !changed {
+		err = e.ErrNoChanges
+		return nil, e.Wrap(op, err)
+	}
+
+	// Штрихкоды сохраняются до изменения продукта, чтобы он вернулся с новым набором
+	if barcodesChanged {
+		if err = p.productRepo.SetBarcodes(ctx, product.ID, product.Barcodes); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	updated, err := p.productRepo.Update(ctx, product)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if priceChanged {
+		if err = p.priceRepo.Record(ctx, updated.ID, updated.Price); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	if variantUpdated {
+		err = p.embeddingRepo.SetVariant(ctx, updated.ID, updated.Attributes, updated.VariantGroupID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		payloadSet = true
+	}
+
+	// Категория не менялась — название берётся из сохранённого продукта
+	if categoryName == "" {
+		var current *ProductDetails
+		current, err = p.productRepo.GetByID(ctx, updated.ID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		categoryName = current.CategoryName
+	}
+	details := NewProductDetails(updated, categoryName)
+
+	event, err := p.createProductEvent(ctx, NewUpdateMessageReq(details))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if err := p.cacheRepo.DeleteProducts(ctx, []int64{updated.ID}); err != nil {
+		p.logger.Warnf("Failed to delete products from cache: %v", e.Wrap(op, err))
+	}
+
+	return NewUpdateProductRes(*details, event), nil
+}
+
+// validateUpdate проверяет корректность входных данных запроса на изменение продукта.
+func (p *ProductUseCase) validateUpdate(req *UpdateProductReq) error {
+	if req.ExpectedVersion == nil {
+		return e.ErrVersionRequired
+	}
+
+	if *req.ExpectedVersion <= 0 {
+		return e.ErrInvalidVersion
+	}
+
+	ids, variant := req.Identifiers, req.Variant
+	if req.Name == nil && req.CategoryName == nil && req.Price == nil && req.LocalizedNames == nil &&
+		ids.SKU == nil && ids.Barcodes == nil && ids.Unit == nil && variant.Attributes == nil && variant.VariantGroupID == nil {
+		return e.ErrMissingFields
+	}
+
+	if variant.VariantGroupID != nil && *variant.VariantGroupID < 0 {
+		return e.ErrInvalidID
+	}
+
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return e.ErrProductNameRequired
+	}
+
+	if req.CategoryName != nil && strings.TrimSpace(*req.CategoryName) == "" {
+		return e.ErrCategoryNameRequired
+	}
+
+	if req.Price != nil {
+		if err := req.Price.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if req.LocalizedNames != nil {
+		names, err := domain.ParseLocalizedNames(req.LocalizedNames)
+		if err != nil {
+			return err
+		}
+		req.LocalizedNames = names
+	}
+
+	return normalizeIdentifiers(&req.Identifiers)
+}
@@ -6,32 +6,53 @@ import (
 	"strings"
 	"time"
 
+	"github.com/DRSN-tech/go-backend/internal/cfg"
 	"github.com/DRSN-tech/go-backend/internal/domain"
 	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/locale"
 	"github.com/DRSN-tech/go-backend/pkg/logger"
+	"github.com/DRSN-tech/go-backend/pkg/storectx"
 	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
-// TODO: добавить кафку и версию продукта
 // ProductUseCase реализует бизнес-логику управления продуктами.
 type ProductUseCase struct {
-	productRepo   ProductRepository
-	categoryRepo  CategoryRepository
-	dbPool        transaction.Transactional
-	mlService     MlServiceInfra
-	imagesInfra   ImagesInfra
-	embeddingRepo EmbeddingRepository
-	logger        logger.Logger
-	cacheRepo     CacheRepository
-	producer      MessageProducer
-	outboxRepo    OutboxRepository
+	productRepo    ProductRepository
+	categoryRepo   CategoryRepository
+	attributeRepo  AttributeSchemaRepository
+	variantRepo    VariantGroupRepository
+	transitionRepo ProductTransitionRepository
+	redirectRepo   ProductRedirectRepository
+	duplicateRepo  DuplicateFlagRepository
+	imageMetaRepo  ImageMetaRepository
+	priceRepo      PriceRepository
+	storeRepo      StoreRepository
+	stockRepo      StockRepository
+	dbPool         transaction.Transactional
+	mlService      MlServiceInfra
+	imagesInfra    ImagesInfra
+	embeddingRepo  EmbeddingRepository
+	logger         logger.Logger
+	cacheRepo      CacheRepository
+	producer       MessageProducer
+	outboxRepo     OutboxRepository
+	recCfg         *cfg.RecognitionCfg
 }
 
 func NewProductUC(
 	productRepo ProductRepository,
 	categoryRepo CategoryRepository,
+	attributeRepo AttributeSchemaRepository,
+	variantRepo VariantGroupRepository,
+	transitionRepo ProductTransitionRepository,
+	redirectRepo ProductRedirectRepository,
+	duplicateRepo DuplicateFlagRepository,
+	imageMetaRepo ImageMetaRepository,
+	priceRepo PriceRepository,
+	storeRepo StoreRepository,
+	stockRepo StockRepository,
 	dbPool transaction.Transactional,
 	mlService MlServiceInfra,
 	imagesInfra ImagesInfra,
@@ -40,23 +61,37 @@ func NewProductUC(
 	cacheRepo CacheRepository,
 	producer MessageProducer,
 	outboxRepo OutboxRepository,
+	recCfg *cfg.RecognitionCfg,
 ) *ProductUseCase {
 	return &ProductUseCase{
-		productRepo:   productRepo,
-		categoryRepo:  categoryRepo,
-		dbPool:        dbPool,
-		mlService:     mlService,
-		imagesInfra:   imagesInfra,
-		embeddingRepo: embeddingRepo,
-		logger:        logger,
-		cacheRepo:     cacheRepo,
-		producer:      producer,
-		outboxRepo:    outboxRepo,
+		productRepo:    productRepo,
+		categoryRepo:   categoryRepo,
+		attributeRepo:  attributeRepo,
+		variantRepo:    variantRepo,
+		transitionRepo: transitionRepo,
+		redirectRepo:   redirectRepo,
+		duplicateRepo:  duplicateRepo,
+		imageMetaRepo:  imageMetaRepo,
+		priceRepo:      priceRepo,
+		storeRepo:      storeRepo,
+		stockRepo:      stockRepo,
+		dbPool:         dbPool,
+		mlService:      mlService,
+		imagesInfra:    imagesInfra,
+		embeddingRepo:  embeddingRepo,
+		logger:         logger,
+		cacheRepo:      cacheRepo,
+		producer:       producer,
+		outboxRepo:     outboxRepo,
+		recCfg:         recCfg,
 	}
 }
 
 // RegisterNewProduct обрабатывает добавление нового продукта с изображениями, категорией, векторами и сохранением в хранилища.
-func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProductReq) (*OutboxEvent, error) {
+// Повторная регистрация существующего названия изменяет продукт, только если передана его текущая версия.
+// Изображения нового продукта сравниваются с изображениями опубликованных продуктов: при сходстве не ниже порога
+// регистрация отклоняется с DuplicateProductsError либо, при DuplicateReview, вероятные дубликаты отмечаются для проверяющего.
+func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProductReq) (*RegisterProductRes, error) {
 	const op = "ProductUseCase.RegisterNewProduct"
 
 	// Валидация данных
@@ -70,6 +105,9 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 		imagesRes  *UploadImagesRes
 		uploaded   bool
 		embeddings []domain.Embedding
+		upsertRes  *UpsertProductRes
+		payloadSet bool
+		duplicates []DuplicateCandidate
 	)
 
 	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
@@ -90,7 +128,7 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 					e.Wrap(op, err),
 				)
 
-				p.imagesInfra.CleanupImages(imagesRes.ImagesKeys)
+				p.imagesInfra.CleanupImages(imagesRes.Keys())
 			}
 
 			if len(embeddings) > 0 {
@@ -98,20 +136,37 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 					p.logger.Warnf("Failed to cleunup Qdrant points %v", err)
 				}
 			}
+
+			// Возвращаем атрибуты в payload векторов, сохранённых до изменения продукта
+			if payloadSet {
+				prev := upsertRes.Previous
+				if err := p.embeddingRepo.SetVariant(ctx, prev.ID, prev.Attributes, prev.VariantGroupID); err != nil {
+					p.logger.Warnf("Failed to restore Qdrant payload of product %d: %v", prev.ID, err)
+				}
+			}
 		}
 	}()
 	ctx = context.WithValue(ctx, "tx", tx.Transaction())
 
-	category, err := p.createCategory(ctx, req.Name)
+	category, err := p.createCategory(ctx, strings.TrimSpace(req.CategoryName))
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
 
-	upsertRes, err := p.upsertProduct(ctx, req.Name, req.Price, category.ID)
+	upsertRes, err = p.upsertProduct(ctx, req.Name, req.Price, category.ID, req.LocalizedNames, req.Identifiers, req.Variant, req.ExpectedVersion)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
 
+	// Атрибуты дублируются в payload векторов для фильтрации при распознавании
+	if upsertRes.Previous != nil && variantChanged(upsertRes.Previous, upsertRes.Product) {
+		err = p.embeddingRepo.SetVariant(ctx, upsertRes.Product.ID, upsertRes.Product.Attributes, upsertRes.Product.VariantGroupID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+		payloadSet = true
+	}
+
 	if req.Images == nil {
 		if upsertRes.NoChanges == true {
 			p.logger.Debugf("%s: images %")
@@ -128,7 +183,7 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 			p.logger.Warnf("Failed to delete products from cache: %v", e.Wrap(op, err))
 		}
 
-		return nil, nil
+		return NewRegisterProductRes(nil, nil), nil
 	}
 
 	// Отправка изображение на ML Service для получения векторов
@@ -137,6 +192,19 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 		return nil, e.Wrap(op, err)
 	}
 
+	// Новый продукт проверяется на дубликаты до сохранения изображений
+	if upsertRes.Previous == nil && !upsertRes.NoChanges {
+		duplicates, err = p.findDuplicates(ctx, upsertRes.Product.ID, vectors)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if len(duplicates) > 0 && req.OnDuplicate != domain.DuplicateReview {
+			err = NewDuplicateProductsError(duplicates)
+			return nil, e.Wrap(op, err)
+		}
+	}
+
 	// Сохранение изображений в MinIO
 	imagesRes, err = p.uploadImages(ctx, req.Name, req.Images)
 	if err != nil {
@@ -144,17 +212,42 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 	}
 	uploaded = true
 
-	embeddings, err = p.getEmbeddings(upsertRes.Product.ID, imagesRes.ImagesKeys, vectors)
+	// Векторы распознаются только в магазинах, где продукт уже продаётся
+	storeIDs, err := p.storeRepo.ListProductStoreIDs(ctx, upsertRes.Product.ID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	embeddings, err = p.getEmbeddings(upsertRes.Product, imagesRes.Images, vectors, storeIDs)
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
 
 	// Сохранение векторов с дополнительной информацией (S3 key, Product ID, Created At, Model Version)
-	if _, err := p.upsertEmbeddings(ctx, embeddings); err != nil {
+	if _, err = p.upsertEmbeddings(ctx, embeddings); err != nil {
 		return nil, e.Wrap(op, err)
 	}
 
-	outboxPayload, err := p.producer.GetPayloadBytes(NewWriteMessageReq(upsertRes.Product.ID, embeddings))
+	if err = p.saveImagesMeta(ctx, upsertRes.Product.ID, imagesRes.Images, vectors); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if len(duplicates) > 0 {
+		if err = p.duplicateRepo.CreateBatch(ctx, duplicateFlags(upsertRes.Product.ID, duplicates)); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	// Поля продукта не изменились, но добавление изображений — тоже новая версия
+	version := upsertRes.Product.Version
+	if upsertRes.NoChanges {
+		version, err = p.productRepo.IncrementVersion(ctx, upsertRes.Product.ID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	outboxPayload, err := p.producer.GetPayloadBytes(NewWriteMessageReq(OperationUpsert, upsertRes.Product.ID, version, embeddings))
 	if err != nil {
 		return nil, e.Wrap(op, err)
 	}
@@ -175,10 +268,13 @@ func (p *ProductUseCase) RegisterNewProduct(ctx context.Context, req *AddNewProd
 		p.logger.Warnf("Failed to delete products: %v", e.Wrap(op, err))
 	}
 
-	return event, nil
+	return NewRegisterProductRes(event, duplicates), nil
 }
 
 // GetProductsInfo возвращает информацию о продуктах по их идентификаторам.
+// По ID слитого продукта возвращается продукт, в который он слит.
+// Если в контексте задан магазин, продукты вне его ассортимента не возвращаются, а цена магазина заменяет общую.
+// Если в контексте задан язык клиента, название заменяется переводом на этот язык, когда он есть.
 func (p *ProductUseCase) GetProductsInfo(ctx context.Context, req *GetProductsReq) (*GetProductsRes, error) {
 	const op = "ProductUseCase.GetProductsInfo"
 
@@ -244,7 +340,26 @@ func (p *ProductUseCase) GetProductsInfo(ctx context.Context, req *GetProductsRe
 		}
 	}
 
-	return NewGetProductsRes(result, notFoundProducts), nil
+	if storeID, ok := storectx.StoreIDFromCtx(ctx); ok && len(result) > 0 {
+		result, notFoundProducts, err = p.applyAssortment(ctx, storeID, result, notFoundProducts)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	var redirects map[int64]int64
+	if len(notFoundProducts) > 0 {
+		result, notFoundProducts, redirects, err = p.resolveRedirects(ctx, result, notFoundProducts)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	if lang, ok := locale.FromCtx(ctx); ok {
+		localizeNames(result, lang)
+	}
+
+	return NewGetProductsRes(result, notFoundProducts, redirects), nil
 }
 
 // getProductsInfo делегирует запрос репозиторию продуктов.
@@ -266,14 +381,108 @@ func (p *ProductUseCase) getVectors(ctx context.Context, images []ProductImage)
 	return vectors, nil
 }
 
-// upsertProduct идемпотентно создаёт или обновляет продукт.
-func (p *ProductUseCase) upsertProduct(ctx context.Context, name string, price int64, categoryID int64) (*UpsertProductRes, error) {
-	return p.productRepo.Upsert(ctx, domain.NewProduct(name, price, categoryID))
+// upsertProduct создаёт продукт или изменяет цену, категорию, переводы названия, идентификаторы и атрибуты существующего продукта с тем же названием.
+// Существующий продукт изменяется только при совпадении его версии с expectedVersion, поэтому
+// из параллельных регистраций одного названия без версии успешно завершается только первая.
+func (p *ProductUseCase) upsertProduct(
+	ctx context.Context,
+	name string,
+	price domain.Money,
+	categoryID int64,
+	localizedNames domain.LocalizedNames,
+	ids ProductIdentifiers,
+	variant ProductVariant,
+	expectedVersion *int64,
+) (*UpsertProductRes, error) {
+	draft := domain.NewProduct(name, price, categoryID)
+	applyLocalizedNames(draft, localizedNames)
+	applyIdentifiers(draft, ids)
+	if _, err := p.applyVariant(ctx, draft, variant, false); err != nil {
+		return nil, err
+	}
+
+	product, err := p.productRepo.Create(ctx, draft)
+	if err == nil {
+		if err := p.priceRepo.Record(ctx, product.ID, product.Price); err != nil {
+			return nil, err
+		}
+
+		if len(draft.Barcodes) > 0 {
+			if err := p.productRepo.SetBarcodes(ctx, product.ID, draft.Barcodes); err != nil {
+				return nil, err
+			}
+			product.Barcodes = draft.Barcodes
+		}
+
+		return NewUpsertProductRes(product, false), nil
+	}
+
+	if !errors.Is(err, e.ErrProductNameTaken) || expectedVersion == nil {
+		return nil, err
+	}
+
+	product, err = p.productRepo.GetByNameForUpdate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Version != *expectedVersion {
+		return nil, e.ErrVersionMismatch
+	}
+
+	previous := *product
+	priceChanged := product.Price != price
+	categoryChanged := product.CategoryID != categoryID
+	namesChanged := applyLocalizedNames(product, localizedNames)
+	fieldsChanged, barcodesChanged := applyIdentifiers(product, ids)
+
+	product.CategoryID = categoryID
+	variantUpdated, err := p.applyVariant(ctx, product, variant, categoryChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	if !priceChanged && !categoryChanged && !namesChanged && !fieldsChanged && !barcodesChanged && !variantUpdated {
+		return NewUpsertProductRes(product, true), nil
+	}
+
+	if barcodesChanged {
+		if err := p.productRepo.SetBarcodes(ctx, product.ID, product.Barcodes); err != nil {
+			return nil, err
+		}
+	}
+
+	product.Price = price
+	product, err = p.productRepo.Update(ctx, product)
+	if err != nil {
+		return nil, err
+	}
+
+	if priceChanged {
+		if err := p.priceRepo.Record(ctx, product.ID, product.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	res := NewUpsertProductRes(product, false)
+	res.Previous = &previous
+
+	return res, nil
 }
 
-// createCategory идемпотентно создаёт категорию по имени продукта.
+// createCategory идемпотентно создаёт категорию по имени, новая категория становится корневой.
+// Добавлять продукты в архивную категорию нельзя.
 func (p *ProductUseCase) createCategory(ctx context.Context, categoryName string) (*domain.Category, error) {
-	return p.categoryRepo.Create(ctx, domain.NewCategory(categoryName))
+	category, err := p.categoryRepo.Create(ctx, domain.NewCategory(categoryName, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	if category.IsArchived {
+		return nil, e.ErrCategoryArchived
+	}
+
+	return category, nil
 }
 
 // uploadImages сохраняет изображения продукта в MinIO.
@@ -281,24 +490,42 @@ func (p *ProductUseCase) uploadImages(ctx context.Context, name string, images [
 	return p.imagesInfra.UploadImages(ctx, NewUploadImagesReq(name, images))
 }
 
-// getEmbeddings генерирует []domain.Embedding
-func (p *ProductUseCase) getEmbeddings(productID int64, imageKeys []string, vectors []VectorizeRes) ([]domain.Embedding, error) {
-	if len(imageKeys) != len(vectors) {
+// getEmbeddings генерирует []domain.Embedding. ID вектора совпадает с ID изображения.
+// storeIDs — магазины, в ассортименте которых продукт распознаётся. Статус продукта копируется в payload,
+// чтобы векторы неопубликованного продукта не участвовали в распознавании.
+func (p *ProductUseCase) getEmbeddings(product *domain.Product, images []UploadedImage, vectors []VectorizeRes, storeIDs []int64) ([]domain.Embedding, error) {
+	if len(images) != len(vectors) {
 		return nil, e.ErrImageVectorMismatch
 	}
 
-	embeddings := make([]domain.Embedding, 0, len(imageKeys))
-	for i, key := range imageKeys {
+	embeddings := make([]domain.Embedding, 0, len(images))
+	for i, image := range images {
 		if len(vectors[i].Vector) == 0 {
 			return nil, e.ErrVectorEmbeddingEmpty
 		}
-		payload := domain.NewPayload(productID, key, vectors[i].ModelVersion)
-		embeddings = append(embeddings, *domain.NewEmbedding(uuid.NewString(), vectors[i].Vector, payload))
+		payload := domain.NewPayload(product.ID, image.Key, vectors[i].ModelVersion)
+		payload.SetStoreIDs(storeIDs)
+		payload.SetVariant(product.Attributes, product.VariantGroupID)
+		payload.SetStatus(product.Status)
+		embeddings = append(embeddings, *domain.NewEmbedding(image.ID, vectors[i].Vector, payload))
 	}
 
 	return embeddings, nil
 }
 
+// saveImagesMeta сохраняет в реестр изображений загруженные изображения продукта и ID их векторов.
+func (p *ProductUseCase) saveImagesMeta(ctx context.Context, productID int64, images []UploadedImage, vectors []VectorizeRes) error {
+	metas := make([]domain.ImageMeta, 0, len(images))
+	for i, image := range images {
+		metas = append(metas, *domain.NewImageMeta(
+			image.ID, productID, image.Key, image.MimeType, image.Size, image.SHA256,
+			&image.ID, &vectors[i].ModelVersion,
+		))
+	}
+
+	return p.imageMetaRepo.CreateBatch(ctx, metas)
+}
+
 func (p *ProductUseCase) upsertEmbeddings(ctx context.Context, embeddings []domain.Embedding) ([]domain.Embedding, error) {
 	return p.embeddingRepo.Upsert(ctx, embeddings)
 }
@@ -309,8 +536,32 @@ func (p *ProductUseCase) validateProduct(req *AddNewProductReq) error {
 		return e.ErrProductNameRequired
 	}
 
-	if req.Price <= 0 {
-		return e.ErrPriceMustBePositive
+	if strings.TrimSpace(req.CategoryName) == "" {
+		return e.ErrCategoryNameRequired
+	}
+
+	if err := req.Price.Validate(); err != nil {
+		return err
+	}
+
+	if req.ExpectedVersion != nil && *req.ExpectedVersion <= 0 {
+		return e.ErrInvalidVersion
+	}
+
+	if req.LocalizedNames != nil {
+		names, err := domain.ParseLocalizedNames(req.LocalizedNames)
+		if err != nil {
+			return err
+		}
+		req.LocalizedNames = names
+	}
+
+	if err := normalizeIdentifiers(&req.Identifiers); err != nil {
+		return err
+	}
+
+	if req.Variant.VariantGroupID != nil && *req.Variant.VariantGroupID < 0 {
+		return e.ErrInvalidID
 	}
 
 	if len(req.Images) == 0 {
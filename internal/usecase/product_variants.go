@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateVariantGroup создаёт группу вариантов с уникальным названием.
+// Продукты добавляются в группу через регистрацию или изменение продукта.
+func (p *ProductUseCase) CreateVariantGroup(ctx context.Context, group *domain.VariantGroup) (*domain.VariantGroup, error) {
+	const op = "ProductUseCase.CreateVariantGroup"
+
+	var err error
+	if err = group.Validate(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	created, err := p.variantRepo.Create(ctx, group)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return created, nil
+}
+
+// GetVariantGroup возвращает группу вариантов и её неархивные продукты.
+// Если в контексте задан магазин, возвращаются только варианты из его ассортимента с ценой магазина.
+func (p *ProductUseCase) GetVariantGroup(ctx context.Context, id int64) (*VariantGroupDetails, error) {
+	const op = "ProductUseCase.GetVariantGroup"
+
+	if id <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	details, err := p.getVariantGroupDetails(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return details, nil
+}
+
+// ListVariantGroups возвращает все группы вариантов.
+func (p *ProductUseCase) ListVariantGroups(ctx context.Context) ([]*domain.VariantGroup, error) {
+	const op = "ProductUseCase.ListVariantGroups"
+
+	groups, err := p.variantRepo.List(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return groups, nil
+}
+
+// getVariantGroupDetails собирает группу вариантов с информацией о её продуктах.
+func (p *ProductUseCase) getVariantGroupDetails(ctx context.Context, id int64) (*VariantGroupDetails, error) {
+	group, err := p.variantRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := p.variantRepo.ListProducts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(products))
+	for _, product := range products {
+		if !product.IsArchived {
+			ids = append(ids, product.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return NewVariantGroupDetails(group, []ProductInfo{}), nil
+	}
+
+	info, err := p.GetProductsInfo(ctx, NewGetProductsReq(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVariantGroupDetails(group, info.Products), nil
+}
+
+// applyVariant переносит заданные атрибуты и группу вариантов в продукт и сообщает, изменились ли они.
+// Атрибуты проверяются по схеме категории продукта, если они заданы, категория сменилась или продукт новый.
+// При вступлении в группу или изменении атрибутов её участника проверяется, что вариант в группе не повторяется.
+func (p *ProductUseCase) applyVariant(ctx context.Context, product *domain.Product, v ProductVariant, categoryChanged bool) (bool, error) {
+	attrs := product.Attributes
+	if v.Attributes != nil {
+		attrs = v.Attributes
+	}
+
+	groupID := product.VariantGroupID
+	if v.VariantGroupID != nil {
+		groupID = nil
+		if *v.VariantGroupID != 0 {
+			id := *v.VariantGroupID
+			groupID = &id
+		}
+	}
+
+	if v.Attributes != nil || categoryChanged || product.ID == 0 {
+		defs, err := p.categoryAttributes(ctx, product.CategoryID)
+		if err != nil {
+			return false, err
+		}
+
+		attrs, err = domain.ValidateAttributes(defs, attrs)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	attrsChanged := !attrs.Equal(product.Attributes)
+	groupChanged := !equalOptionalInt64(groupID, product.VariantGroupID)
+	if groupID != nil && (attrsChanged || groupChanged) {
+		if err := p.checkVariant(ctx, *groupID, product.ID, attrs); err != nil {
+			return false, err
+		}
+	}
+
+	product.Attributes = attrs
+	product.VariantGroupID = groupID
+
+	return attrsChanged || groupChanged, nil
+}
+
+// checkVariant проверяет, что продукт с атрибутами attrs задаёт значения всех осей группы и
+// в группе нет другого продукта с теми же значениями. Группа блокируется до конца транзакции,
+// поэтому параллельные изменения её состава выполняются по очереди.
+func (p *ProductUseCase) checkVariant(ctx context.Context, groupID int64, productID int64, attrs domain.Attributes) error {
+	group, err := p.variantRepo.GetForUpdate(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	key, err := group.VariantKey(attrs)
+	if err != nil {
+		return err
+	}
+
+	members, err := p.variantRepo.ListProducts(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if member.ID == productID {
+			continue
+		}
+
+		if memberKey, err := group.VariantKey(member.Attributes); err == nil && memberKey == key {
+			return e.Wrap(member.Name, e.ErrVariantConflict)
+		}
+	}
+
+	return nil
+}
+
+// categoryAttributes возвращает определения атрибутов, действующие для продуктов категории.
+func (p *ProductUseCase) categoryAttributes(ctx context.Context, categoryID int64) ([]domain.AttributeDef, error) {
+	schemas, err := p.attributeRepo.ListForCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.MergeAttributeSchemas(schemas), nil
+}
+
+// variantChanged сообщает, различаются ли атрибуты или группа вариантов продуктов, т.е. нужно ли
+// обновить payload их векторов.
+func variantChanged(a, b *domain.Product) bool {
+	return !a.Attributes.Equal(b.Attributes) || !equalOptionalInt64(a.VariantGroupID, b.VariantGroupID)
+}
+
+// equalOptionalInt64 сравнивает необязательные идентификаторы.
+func equalOptionalInt64(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// PromotionUseCase реализует управление акциями и расчёт скидок для корзины или списка продуктов.
+// К позиции применяется не более одной акции; выбор акции описан в domain.EvaluatePromotions.
+type PromotionUseCase struct {
+	promotionRepo PromotionRepository
+	productUC     ProductUC
+	checkoutUC    CheckoutUC
+	dbPool        transaction.Transactional
+	logger        logger.Logger
+}
+
+func NewPromotionUC(
+	promotionRepo PromotionRepository,
+	productUC ProductUC,
+	checkoutUC CheckoutUC,
+	dbPool transaction.Transactional,
+	logger logger.Logger,
+) *PromotionUseCase {
+	return &PromotionUseCase{
+		promotionRepo: promotionRepo,
+		productUC:     productUC,
+		checkoutUC:    checkoutUC,
+		dbPool:        dbPool,
+		logger:        logger,
+	}
+}
+
+// CreatePromotion создаёт акцию. Продукт или категория акции должны существовать.
+func (p *PromotionUseCase) CreatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error) {
+	const op = "PromotionUseCase.CreatePromotion"
+
+	var err error
+	if err = promotion.Validate(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Время в БД хранится в UTC без часового пояса
+	if promotion.StartsAt != nil {
+		startsAt := promotion.StartsAt.UTC()
+		promotion.StartsAt = &startsAt
+	}
+	if promotion.EndsAt != nil {
+		endsAt := promotion.EndsAt.UTC()
+		promotion.EndsAt = &endsAt
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	created, err := p.promotionRepo.Create(ctx, promotion)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	p.logger.Infof("Promotion created. promotion_id: %d, kind: %s", created.ID, created.Kind)
+
+	return created, nil
+}
+
+// GetPromotion возвращает акцию по ID.
+func (p *PromotionUseCase) GetPromotion(ctx context.Context, id int64) (*domain.Promotion, error) {
+	const op = "PromotionUseCase.GetPromotion"
+
+	promotion, err := p.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return promotion, nil
+}
+
+// ListPromotions возвращает все акции или только действующие в момент activeAt.
+func (p *PromotionUseCase) ListPromotions(ctx context.Context, activeAt *time.Time) ([]*domain.Promotion, error) {
+	const op = "PromotionUseCase.ListPromotions"
+
+	if activeAt != nil {
+		at := activeAt.UTC()
+		activeAt = &at
+	}
+
+	promotions, err := p.promotionRepo.List(ctx, activeAt)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return promotions, nil
+}
+
+// DeletePromotion удаляет акцию.
+func (p *PromotionUseCase) DeletePromotion(ctx context.Context, id int64) error {
+	const op = "PromotionUseCase.DeletePromotion"
+
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, p.dbPool)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	if err = p.promotionRepo.Delete(ctx, id); err != nil {
+		return e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return e.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// EvaluatePromotions рассчитывает скидки для списка продуктов с кол-вом или весом по текущим ценам.
+// Архивные и отсутствующие продукты возвращаются недоступными позициями.
+func (p *PromotionUseCase) EvaluatePromotions(ctx context.Context, req *EvaluatePromotionsReq) (*DiscountedCart, error) {
+	const op = "PromotionUseCase.EvaluatePromotions"
+
+	if len(req.Items) == 0 {
+		return nil, e.Wrap(op, e.ErrNoProducts)
+	}
+
+	if len(req.Items) > domain.MaxCheckoutLines {
+		return nil, e.Wrap(op, e.ErrTooManyLines)
+	}
+
+	ids := make([]int64, 0, len(req.Items))
+	for _, item := range req.Items {
+		if item.ProductID <= 0 {
+			return nil, e.Wrap(op, e.ErrInvalidID)
+		}
+		ids = append(ids, item.ProductID)
+	}
+
+	res, err := p.productUC.GetProductsInfo(ctx, NewGetProductsReq(ids))
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	products := make(map[int64]*ProductInfo, len(res.Products))
+	for i := range res.Products {
+		products[res.Products[i].ID] = &res.Products[i]
+	}
+
+	lines := make([]DiscountedLine, 0, len(req.Items))
+	for _, item := range req.Items {
+		// Слитый продукт оценивается по продукту-получателю: его цене и акциям
+		productID := item.ProductID
+		if targetID, ok := res.Redirects[productID]; ok {
+			productID = targetID
+		}
+
+		line := DiscountedLine{
+			ProductID:   productID,
+			Product:     products[productID],
+			Quantity:    item.Quantity,
+			WeightGrams: item.WeightGrams,
+		}
+
+		if line.Product != nil {
+			if line.Quantity, err = itemQuantity(line.Product.Unit, item.Quantity); err != nil {
+				return nil, e.Wrap(op, err)
+			}
+
+			line.Total, err = domain.LineTotal(line.Product.Price, line.Product.Unit, line.Quantity, line.WeightGrams)
+			if err != nil {
+				return nil, e.Wrap(op, err)
+			}
+			line.Available = true
+		}
+
+		lines = append(lines, line)
+	}
+
+	cart, err := p.discountLines(ctx, lines, req.At)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return cart, nil
+}
+
+// EvaluateCheckout рассчитывает скидки для позиций корзины по акциям, действующим в текущий момент.
+// Недоступные позиции корзины не оцениваются.
+func (p *PromotionUseCase) EvaluateCheckout(ctx context.Context, checkoutID string) (*DiscountedCart, error) {
+	const op = "PromotionUseCase.EvaluateCheckout"
+
+	checkout, err := p.checkoutUC.GetCheckout(ctx, checkoutID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	lines := make([]DiscountedLine, 0, len(checkout.Lines))
+	for _, line := range checkout.Lines {
+		lines = append(lines, DiscountedLine{
+			LineID:      line.Line.ID,
+			ProductID:   line.Line.ProductID,
+			Product:     line.Product,
+			Quantity:    line.Line.Quantity,
+			WeightGrams: line.Line.WeightGrams,
+			Total:       line.Total,
+			Available:   line.Available,
+		})
+	}
+
+	cart, err := p.discountLines(ctx, lines, time.Now().UTC())
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return cart, nil
+}
+
+// discountLines применяет к доступным позициям акции, действующие в момент at, и подводит итоги.
+// Итоги считаются в валюте позиций; позиции в разных валютах не оцениваются вместе.
+func (p *PromotionUseCase) discountLines(ctx context.Context, lines []DiscountedLine, at time.Time) (*DiscountedCart, error) {
+	cart := &DiscountedCart{
+		Lines:       lines,
+		Subtotal:    domain.NewMoney(0, domain.DefaultCurrency),
+		EvaluatedAt: at,
+	}
+
+	ids := make([]int64, 0, len(lines))
+	priced := 0
+	for _, line := range lines {
+		if !line.Available {
+			continue
+		}
+
+		if priced == 0 {
+			cart.Subtotal.Currency = line.Total.Currency
+		} else if line.Total.Currency != cart.Subtotal.Currency {
+			return nil, e.ErrCurrencyMismatch
+		}
+
+		cart.Subtotal.Amount += line.Total.Amount
+		ids = append(ids, line.ProductID)
+		priced++
+	}
+
+	cart.Discount = domain.NewMoney(0, cart.Subtotal.Currency)
+	if len(ids) > 0 {
+		matches, err := p.promotionRepo.ListApplicable(ctx, ids, at.UTC())
+		if err != nil {
+			return nil, err
+		}
+
+		promotions := make(map[int64][]*domain.Promotion, len(ids))
+		for _, match := range matches {
+			promotions[match.ProductID] = append(promotions[match.ProductID], match.Promotion)
+		}
+
+		for i := range cart.Lines {
+			line := &cart.Lines[i]
+			if !line.Available {
+				continue
+			}
+
+			line.Discount = domain.NewMoney(0, line.Total.Currency)
+			line.Evaluations = domain.EvaluatePromotions(domain.PromotionLine{
+				ProductID:   line.ProductID,
+				Price:       line.Product.Price,
+				Unit:        line.Product.Unit,
+				Quantity:    line.Quantity,
+				WeightGrams: line.WeightGrams,
+				Total:       line.Total,
+			}, promotions[line.ProductID])
+
+			if len(line.Evaluations) > 0 && line.Evaluations[0].Outcome == domain.PromotionApplied {
+				line.Discount = line.Evaluations[0].Discount
+				cart.Discount.Amount += line.Discount.Amount
+			}
+		}
+	}
+
+	cart.Total = domain.NewMoney(cart.Subtotal.Amount-cart.Discount.Amount, cart.Subtotal.Currency)
+
+	return cart, nil
+}
+
+// itemQuantity проверяет кол-во продукта по его единице измерения: кол-во весового продукта
+// всегда равно 1, для штучного по умолчанию равно 1.
+func itemQuantity(unit domain.Unit, quantity int64) (int64, error) {
+	if quantity < 0 || (unit.IsWeighted() && quantity > 1) {
+		return 0, e.ErrInvalidQuantity
+	}
+
+	if quantity == 0 {
+		return 1, nil
+	}
+
+	return quantity, nil
+}
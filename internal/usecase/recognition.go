@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/storectx"
+)
+
+// RecognizeProduct распознаёт продукт по одному или нескольким кадрам: векторизует их через ML-сервис,
+// ищет ближайшие эмбеддинги в Qdrant, агрегирует их score по продуктам, объединяет результаты кадров
+// и выносит вердикт accepted/variant/ambiguous/unknown по порогам из конфигурации.
+func (p *ProductUseCase) RecognizeProduct(ctx context.Context, req *RecognizeProductReq) (*RecognizeProductRes, error) {
+	const op = "ProductUseCase.RecognizeProduct"
+
+	limit, fusion, err := p.validateRecognition(req)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	attrs, err := domain.ValidateAttributeFilter(req.Attributes)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	// Поиск по поддереву категории ограничивается её неархивными продуктами
+	var productIDs []int64
+	if req.CategoryID != nil {
+		productIDs, err = p.categoryRepo.SubtreeProductIDs(ctx, *req.CategoryID)
+		if err != nil {
+			return nil, e.Wrap(op, err)
+		}
+
+		if len(productIDs) == 0 {
+			return NewRecognizeProductRes(VerdictUnknown, []RecognitionCandidate{}, ""), nil
+		}
+	}
+
+	// В магазине поиск ограничивается его ассортиментом
+	var storeID *int64
+	if id, ok := storectx.StoreIDFromCtx(ctx); ok {
+		storeID = &id
+	}
+
+	// Остатки учитываются по магазинам, поэтому без магазина их вернуть нельзя
+	if req.IncludeStock && storeID == nil {
+		return nil, e.Wrap(op, e.ErrStoreRequired)
+	}
+
+	// Кадры векторизуются параллельно внутри ML-клиента
+	vectors, err := p.getVectors(ctx, req.Images)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	var scores []productScore
+	switch fusion {
+	case cfg.FusionCentroid:
+		scores, err = p.searchByCentroid(ctx, vectors, productIDs, storeID, attrs)
+	default:
+		scores, err = p.searchByFrames(ctx, vectors, productIDs, storeID, attrs)
+	}
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := p.buildRecognitionRes(ctx, scores, limit, vectors[0].ModelVersion)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	if req.IncludeStock {
+		if err := p.attachStock(ctx, *storeID, res.Candidates); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	return res, nil
+}
+
+// searchByFrames ищет ближайших соседей для каждого кадра и объединяет ранжирования кадров через RRF.
+func (p *ProductUseCase) searchByFrames(
+	ctx context.Context,
+	vectors []VectorizeRes,
+	productIDs []int64,
+	storeID *int64,
+	attrs domain.Attributes,
+) ([]productScore, error) {
+	queries := make([][]float32, 0, len(vectors))
+	for _, v := range vectors {
+		queries = append(queries, v.Vector)
+	}
+
+	batch, err := p.embeddingRepo.SearchBatch(ctx, queries, p.recCfg.SearchLimit, productIDs, storeID, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([][]productScore, 0, len(batch))
+	for _, hits := range batch {
+		frames = append(frames, aggregateHits(hits, p.recCfg.Aggregation, p.recCfg.TopN))
+	}
+
+	return fuseRRF(frames), nil
+}
+
+// searchByCentroid выполняет один поиск по усреднённому вектору всех кадров.
+func (p *ProductUseCase) searchByCentroid(
+	ctx context.Context,
+	vectors []VectorizeRes,
+	productIDs []int64,
+	storeID *int64,
+	attrs domain.Attributes,
+) ([]productScore, error) {
+	query, err := centroid(vectors)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := p.embeddingRepo.Search(ctx, query, p.recCfg.SearchLimit, productIDs, storeID, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateHits(hits, p.recCfg.Aggregation, p.recCfg.TopN), nil
+}
+
+// buildRecognitionRes формирует ответ распознавания из агрегированных score.
+// Для вердикта всегда учитывается второй кандидат, даже если limit равен 1.
+// Продукты, отсутствующие в каталоге или вне ассортимента магазина, отбрасываются до отбора кандидатов,
+// чтобы вердикт сравнивал лучший кандидат с действительно доступным вторым.
+// При вердикте variant к ответу добавляется группа вариантов со всеми её вариантами.
+func (p *ProductUseCase) buildRecognitionRes(ctx context.Context, scores []productScore, limit int, modelVersion string) (*RecognizeProductRes, error) {
+	want := max(limit, 2)
+	candidates := make([]RecognitionCandidate, 0, want)
+	// Продукты запрашиваются с запасом, следующая порция — только если часть продуктов отброшена
+	for start := 0; start < len(scores) && len(candidates) < want; {
+		end := min(len(scores), start+2*(want-len(candidates)))
+		batch, err := p.toRecognitionCandidates(ctx, scores[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, batch...)
+		start = end
+	}
+
+	if len(candidates) == 0 {
+		return NewRecognizeProductRes(VerdictUnknown, []RecognitionCandidate{}, modelVersion), nil
+	}
+
+	if len(candidates) > want {
+		candidates = candidates[:want]
+	}
+
+	var err error
+
+	verdict := p.decideVerdict(candidates)
+
+	var group *VariantGroupDetails
+	if verdict == VerdictVariant {
+		group, err = p.getVariantGroupDetails(ctx, *candidates[0].Product.VariantGroupID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	res := NewRecognizeProductRes(verdict, candidates, modelVersion)
+	res.VariantGroup = group
+
+	return res, nil
+}
+
+// productScore — агрегированный score продукта по результатам поиска.
+type productScore struct {
+	ProductID int64
+	Score     float32
+	Hits      int
+}
+
+// aggregateHits группирует найденные эмбеддинги по product_id и вычисляет score продукта выбранной стратегией:
+//   - max: лучший score среди изображений продукта;
+//   - mean_top_n: среднее по N лучшим изображениям продукта (или по всем, если их меньше N);
+//   - vote: голосование N ближайших векторов, взвешенное их score: сумма score векторов продукта среди
+//     N ближайших, делённая на N. Score лежит в том же диапазоне, что и близость изображений, и равен ей,
+//     когда все N ближайших векторов принадлежат продукту, поэтому к нему применимы те же пороги вердикта.
+//
+// Результат отсортирован по убыванию score, при равенстве — по кол-ву попаданий и product_id.
+func aggregateHits(hits []domain.SearchHit, strategy string, topN int) []productScore {
+	// hits отсортированы Qdrant по убыванию score, поэтому scores каждого продукта тоже упорядочены
+	grouped := make(map[int64][]float32)
+	votes := make(map[int64]float32)
+	total := 0
+	for _, hit := range hits {
+		productID, ok := hit.Payload.ProductID()
+		if !ok {
+			continue
+		}
+		grouped[productID] = append(grouped[productID], hit.Score)
+		if total < topN {
+			votes[productID] += hit.Score
+		}
+		total++
+	}
+
+	result := make([]productScore, 0, len(grouped))
+	for productID, scores := range grouped {
+		var score float32
+		switch strategy {
+		case cfg.AggregationMeanTopN:
+			n := min(topN, len(scores))
+			var sum float32
+			for _, s := range scores[:n] {
+				sum += s
+			}
+			score = sum / float32(n)
+		case cfg.AggregationVote:
+			score = votes[productID] / float32(min(topN, total))
+		default:
+			score = scores[0]
+		}
+
+		result = append(result, productScore{ProductID: productID, Score: score, Hits: len(scores)})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].Hits != result[j].Hits {
+			return result[i].Hits > result[j].Hits
+		}
+		return result[i].ProductID < result[j].ProductID
+	})
+
+	return result
+}
+
+// fuseRRF объединяет ранжирования продуктов по кадрам методом Reciprocal Rank Fusion.
+// Порядок определяется суммой 1/(k+rank) по кадрам, а score продукта — средним score по всем кадрам
+// (кадр, в котором продукт не найден, даёт 0), поэтому пороги вердикта сохраняют смысл одиночного кадра,
+// а продукт, найденный лишь на части кадров, получает штраф.
+func fuseRRF(frames [][]productScore) []productScore {
+	const k = 60
+
+	type fused struct {
+		productScore
+		rrf float64
+	}
+
+	index := make(map[int64]int)
+	result := make([]fused, 0)
+	for _, frame := range frames {
+		for rank, s := range frame {
+			i, ok := index[s.ProductID]
+			if !ok {
+				i = len(result)
+				index[s.ProductID] = i
+				result = append(result, fused{productScore: productScore{ProductID: s.ProductID}})
+			}
+
+			result[i].rrf += 1 / float64(k+rank+1)
+			result[i].Score += s.Score
+			result[i].Hits += s.Hits
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].rrf != result[j].rrf {
+			return result[i].rrf > result[j].rrf
+		}
+		return result[i].ProductID < result[j].ProductID
+	})
+
+	scores := make([]productScore, 0, len(result))
+	for _, r := range result {
+		r.Score /= float32(len(frames))
+		scores = append(scores, r.productScore)
+	}
+
+	return scores
+}
+
+// centroid возвращает среднее L2-нормированных векторов кадров.
+func centroid(vectors []VectorizeRes) ([]float32, error) {
+	dim := len(vectors[0].Vector)
+	result := make([]float32, dim)
+	for _, v := range vectors {
+		if len(v.Vector) != dim {
+			return nil, e.ErrImageVectorMismatch
+		}
+
+		var norm float64
+		for _, x := range v.Vector {
+			norm += float64(x) * float64(x)
+		}
+		if norm == 0 {
+			return nil, e.ErrVectorEmbeddingEmpty
+		}
+		norm = math.Sqrt(norm)
+
+		for i, x := range v.Vector {
+			result[i] += float32(float64(x) / norm)
+		}
+	}
+
+	for i := range result {
+		result[i] /= float32(len(vectors))
+	}
+
+	return result, nil
+}
+
+// decideVerdict выносит вердикт по отсортированному списку кандидатов:
+// accepted — лучший кандидат не ниже AcceptThreshold и отрывается от второго хотя бы на MinMargin,
+// variant — лучший кандидат не ниже AcceptThreshold, а все близкие к нему кандидаты — варианты одной группы,
+// unknown — кандидатов нет или лучший ниже RejectThreshold, иначе — ambiguous.
+func (p *ProductUseCase) decideVerdict(candidates []RecognitionCandidate) RecognitionVerdict {
+	if len(candidates) == 0 || candidates[0].Score < p.recCfg.RejectThreshold {
+		return VerdictUnknown
+	}
+
+	top := candidates[0].Score
+	if top < p.recCfg.AcceptThreshold {
+		return VerdictAmbiguous
+	}
+
+	if len(candidates) > 1 && top-candidates[1].Score < p.recCfg.MinMargin {
+		if sameVariantGroup(candidates, top-p.recCfg.MinMargin) {
+			return VerdictVariant
+		}
+		return VerdictAmbiguous
+	}
+
+	return VerdictAccepted
+}
+
+// sameVariantGroup сообщает, принадлежат ли все кандидаты со score выше threshold одной группе вариантов.
+func sameVariantGroup(candidates []RecognitionCandidate, threshold float32) bool {
+	groupID := candidates[0].Product.VariantGroupID
+	if groupID == nil {
+		return false
+	}
+
+	for _, c := range candidates[1:] {
+		if c.Score <= threshold {
+			break
+		}
+
+		if !equalOptionalInt64(c.Product.VariantGroupID, groupID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toRecognitionCandidates дополняет score продуктов информацией о них.
+// Продукты, отсутствующие в каталоге (например, удалённые), пропускаются.
+func (p *ProductUseCase) toRecognitionCandidates(ctx context.Context, scores []productScore) ([]RecognitionCandidate, error) {
+	ids := make([]int64, 0, len(scores))
+	for _, s := range scores {
+		ids = append(ids, s.ProductID)
+	}
+
+	info, err := p.GetProductsInfo(ctx, NewGetProductsReq(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	products := make(map[int64]ProductInfo, len(info.Products))
+	for _, product := range info.Products {
+		products[product.ID] = product
+	}
+
+	candidates := make([]RecognitionCandidate, 0, len(scores))
+	for _, s := range scores {
+		product, ok := products[s.ProductID]
+		if !ok {
+			p.logger.Warnf("Recognized product %d not found in catalog", s.ProductID)
+			continue
+		}
+		candidates = append(candidates, NewRecognitionCandidate(product, s.Score, s.Hits))
+	}
+
+	return candidates, nil
+}
+
+// validateRecognition проверяет запрос на распознавание и возвращает итоговое кол-во кандидатов и стратегию объединения кадров.
+func (p *ProductUseCase) validateRecognition(req *RecognizeProductReq) (int, string, error) {
+	if len(req.Images) == 0 {
+		return 0, "", e.ErrNoImages
+	}
+
+	if len(req.Images) > p.recCfg.MaxFrames {
+		return 0, "", e.ErrTooManyFrames
+	}
+
+	for _, image := range req.Images {
+		if len(image.Data) == 0 {
+			return 0, "", e.ErrNoImages
+		}
+	}
+
+	fusion := req.Fusion
+	switch fusion {
+	case "":
+		fusion = p.recCfg.Fusion
+	case cfg.FusionRRF, cfg.FusionCentroid:
+	default:
+		return 0, "", e.ErrInvalidFusion
+	}
+
+	if req.Limit < 0 {
+		return 0, "", e.ErrInvalidLimit
+	}
+
+	if req.CategoryID != nil && *req.CategoryID <= 0 {
+		return 0, "", e.ErrInvalidID
+	}
+
+	if req.Limit == 0 || req.Limit > p.recCfg.MaxCandidates {
+		return p.recCfg.MaxCandidates, fusion, nil
+	}
+
+	return req.Limit, fusion, nil
+}
+
+// attachStock добавляет к кандидатам остатки магазина. Кандидаты без учёта остатка остаются без него.
+func (p *ProductUseCase) attachStock(ctx context.Context, storeID int64, candidates []RecognitionCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.Product.ID)
+	}
+
+	levels, err := p.stockRepo.GetLevels(ctx, storeID, ids)
+	if err != nil {
+		return err
+	}
+
+	byProduct := make(map[int64]*domain.StockLevel, len(levels))
+	for _, level := range levels {
+		byProduct[level.ProductID] = level
+	}
+
+	for i := range candidates {
+		candidates[i].Stock = byProduct[candidates[i].Product.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/domain"
+)
+
+func hit(productID int64, score float32) domain.SearchHit {
+	return domain.SearchHit{Score: score, Payload: domain.Payload{"product_id": productID}}
+}
+
+func newRecognitionUC() *ProductUseCase {
+	return &ProductUseCase{recCfg: &cfg.RecognitionCfg{
+		AcceptThreshold: 0.85,
+		RejectThreshold: 0.6,
+		MinMargin:       0.05,
+	}}
+}
+
+func TestAggregateHits(t *testing.T) {
+	tests := []struct {
+		name     string
+		hits     []domain.SearchHit
+		strategy string
+		topN     int
+		want     []productScore
+		verdict  RecognitionVerdict
+	}{
+		{
+			name:     "max takes best image",
+			hits:     []domain.SearchHit{hit(1, 0.95), hit(1, 0.90), hit(2, 0.88), hit(1, 0.80), hit(2, 0.70)},
+			strategy: cfg.AggregationMax,
+			topN:     3,
+			want:     []productScore{{1, 0.95, 3}, {2, 0.88, 2}},
+			verdict:  VerdictAccepted,
+		},
+		{
+			name:     "mean_top_n averages available images",
+			hits:     []domain.SearchHit{hit(1, 0.95), hit(1, 0.90), hit(2, 0.88), hit(1, 0.80), hit(2, 0.70)},
+			strategy: cfg.AggregationMeanTopN,
+			topN:     3,
+			want:     []productScore{{1, 0.8833333, 3}, {2, 0.79, 2}},
+			verdict:  VerdictAccepted,
+		},
+		{
+			name:     "vote is weighted by nearest hits",
+			hits:     []domain.SearchHit{hit(1, 0.95), hit(1, 0.90), hit(2, 0.88), hit(1, 0.80), hit(2, 0.70)},
+			strategy: cfg.AggregationVote,
+			topN:     3,
+			want:     []productScore{{1, 0.6166667, 3}, {2, 0.2933333, 2}},
+			verdict:  VerdictAmbiguous,
+		},
+		{
+			name: "vote reaches accept threshold when product owns nearest hits",
+			hits: []domain.SearchHit{
+				hit(1, 0.92), hit(1, 0.90), hit(1, 0.88), hit(2, 0.50), hit(3, 0.45), hit(2, 0.40),
+				hit(3, 0.40), hit(4, 0.35), hit(4, 0.30), hit(5, 0.30), hit(5, 0.25), hit(6, 0.20),
+			},
+			strategy: cfg.AggregationVote,
+			topN:     3,
+			want:     []productScore{{1, 0.9, 3}, {2, 0, 2}, {3, 0, 2}, {4, 0, 2}, {5, 0, 2}, {6, 0, 1}},
+			verdict:  VerdictAccepted,
+		},
+		{
+			name:     "vote uses all hits when fewer than N",
+			hits:     []domain.SearchHit{hit(1, 0.7), hit(2, 0.5)},
+			strategy: cfg.AggregationVote,
+			topN:     3,
+			want:     []productScore{{1, 0.35, 1}, {2, 0.25, 1}},
+			verdict:  VerdictUnknown,
+		},
+		{
+			name:     "hits without product_id are skipped",
+			hits:     []domain.SearchHit{{Score: 0.99, Payload: domain.Payload{}}, hit(1, 0.5)},
+			strategy: cfg.AggregationMax,
+			topN:     3,
+			want:     []productScore{{1, 0.5, 1}},
+			verdict:  VerdictUnknown,
+		},
+		{
+			name:     "no hits",
+			strategy: cfg.AggregationVote,
+			topN:     3,
+			want:     []productScore{},
+			verdict:  VerdictUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := aggregateHits(tt.hits, tt.strategy, tt.topN)
+			if len(got) != len(tt.want) {
+				t.Fatalf("aggregateHits() = %v, want %v", got, tt.want)
+			}
+
+			candidates := make([]RecognitionCandidate, 0, len(got))
+			for i := range got {
+				if got[i].ProductID != tt.want[i].ProductID || got[i].Hits != tt.want[i].Hits ||
+					math.Abs(float64(got[i].Score-tt.want[i].Score)) > 1e-5 {
+					t.Fatalf("aggregateHits()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+				candidates = append(candidates, RecognitionCandidate{Product: ProductInfo{ID: got[i].ProductID}, Score: got[i].Score})
+			}
+
+			if verdict := newRecognitionUC().decideVerdict(candidates); verdict != tt.verdict {
+				t.Errorf("decideVerdict() = %s, want %s", verdict, tt.verdict)
+			}
+		})
+	}
+}
+
+func TestDecideVerdict(t *testing.T) {
+	group := int64(7)
+	other := int64(8)
+	candidate := func(id int64, score float32, groupID *int64) RecognitionCandidate {
+		return RecognitionCandidate{Product: ProductInfo{ID: id, VariantGroupID: groupID}, Score: score}
+	}
+
+	tests := []struct {
+		name       string
+		candidates []RecognitionCandidate
+		want       RecognitionVerdict
+	}{
+		{"no candidates", nil, VerdictUnknown},
+		{"below reject threshold", []RecognitionCandidate{candidate(1, 0.59, nil)}, VerdictUnknown},
+		{"between thresholds", []RecognitionCandidate{candidate(1, 0.7, nil)}, VerdictAmbiguous},
+		{"single confident candidate", []RecognitionCandidate{candidate(1, 0.9, nil)}, VerdictAccepted},
+		{"clear margin", []RecognitionCandidate{candidate(1, 0.9, nil), candidate(2, 0.8, nil)}, VerdictAccepted},
+		{"close second", []RecognitionCandidate{candidate(1, 0.9, nil), candidate(2, 0.88, nil)}, VerdictAmbiguous},
+		{"close variants of one group", []RecognitionCandidate{candidate(1, 0.9, &group), candidate(2, 0.88, &group)}, VerdictVariant},
+		{"close variants of different groups", []RecognitionCandidate{candidate(1, 0.9, &group), candidate(2, 0.88, &other)}, VerdictAmbiguous},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newRecognitionUC().decideVerdict(tt.candidates); got != tt.want {
+				t.Errorf("decideVerdict() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+// RecognitionTracker отслеживает стабильность распознавания в потоке кадров одного товара.
+// Результат считается стабильным, когда один и тот же продукт получает вердикт accepted
+// на required кадрах подряд. Не безопасен для конкурентного использования.
+type RecognitionTracker struct {
+	required  int
+	productID int64
+	streak    int
+}
+
+// RecognitionStability — состояние стабильности распознавания после очередного кадра.
+type RecognitionStability struct {
+	ProductID int64 // продукт текущей серии, 0 — серии нет
+	Streak    int   // кол-во подряд идущих кадров с этим продуктом
+	Stable    bool
+}
+
+func NewRecognitionTracker(required int) *RecognitionTracker {
+	return &RecognitionTracker{required: required}
+}
+
+// NewRecognitionTracker создаёт трекер стабильности с порогом из конфигурации распознавания.
+func (p *ProductUseCase) NewRecognitionTracker() *RecognitionTracker {
+	return NewRecognitionTracker(p.recCfg.StableFrames)
+}
+
+// Observe учитывает результат распознавания очередного кадра.
+// Любой вердикт, кроме accepted, прерывает серию.
+func (t *RecognitionTracker) Observe(res *RecognizeProductRes) RecognitionStability {
+	switch {
+	case res.Verdict != VerdictAccepted || len(res.Candidates) == 0:
+		t.productID, t.streak = 0, 0
+	case res.Candidates[0].Product.ID == t.productID:
+		t.streak++
+	default:
+		t.productID, t.streak = res.Candidates[0].Product.ID, 1
+	}
+
+	return RecognitionStability{
+		ProductID: t.productID,
+		Streak:    t.streak,
+		Stable:    t.streak >= t.required,
+	}
+}
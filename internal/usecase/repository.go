@@ -2,17 +2,118 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/google/uuid"
 )
 
 type ProductRepository interface {
-	Upsert(ctx context.Context, product *domain.Product) (*UpsertProductRes, error)
+	Create(ctx context.Context, product *domain.Product) (*domain.Product, error)
+	GetByNameForUpdate(ctx context.Context, name string) (*domain.Product, error)
 	GetProductsInfo(ctx context.Context, ids []int64) ([]ProductInfo, error)
+	GetByID(ctx context.Context, id int64) (*ProductDetails, error)
+	List(ctx context.Context, query *ListProductsQuery) ([]ProductDetails, error)
+	GetForUpdate(ctx context.Context, id int64) (*domain.Product, error)
+	Update(ctx context.Context, product *domain.Product) (*domain.Product, error)
+	SetStatus(ctx context.Context, id int64, status domain.ProductStatus) (*domain.Product, error)
+	IncrementVersion(ctx context.Context, id int64) (int64, error)
+	Delete(ctx context.Context, id int64) (int64, error)
+	SetBarcodes(ctx context.Context, productID int64, barcodes []string) error
+	GetIDByBarcode(ctx context.Context, barcode string) (int64, error)
+}
+
+type ProductTransitionRepository interface {
+	Create(ctx context.Context, transition *domain.ProductTransition) (*domain.ProductTransition, error)
+	ListByProduct(ctx context.Context, productID int64) ([]*domain.ProductTransition, error)
+}
+
+type ProductRedirectRepository interface {
+	Create(ctx context.Context, redirect *domain.ProductRedirect) (*domain.ProductRedirect, error)
+	GetTargets(ctx context.Context, ids []int64) (map[int64]int64, error)
+}
+
+type DuplicateFlagRepository interface {
+	CreateBatch(ctx context.Context, flags []domain.DuplicateFlag) error
+	ListByProduct(ctx context.Context, productID int64) ([]*domain.DuplicateFlag, error)
 }
 
 type CategoryRepository interface {
 	Create(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	GetByID(ctx context.Context, id int64) (*CategoryDetails, error)
+	List(ctx context.Context, archived *bool) ([]CategoryDetails, error)
+	GetForUpdate(ctx context.Context, id int64) (*domain.Category, error)
+	Rename(ctx context.Context, id int64, name string) (*domain.Category, error)
+	SetArchived(ctx context.Context, id int64, archived bool) (*domain.Category, error)
+	Move(ctx context.Context, id int64, parentID *int64) (*domain.Category, error)
+	Delete(ctx context.Context, id int64) error
+	CountActiveProducts(ctx context.Context, id int64) (int64, error)
+	CountActiveChildren(ctx context.Context, id int64) (int64, error)
+	SubtreeIDs(ctx context.Context, id int64) ([]int64, error)
+	SubtreeProductIDs(ctx context.Context, id int64) ([]int64, error)
+	LockTree(ctx context.Context) error
+}
+
+type AttributeSchemaRepository interface {
+	ListForCategory(ctx context.Context, categoryID int64) ([]*domain.AttributeSchema, error)
+	Set(ctx context.Context, schema *domain.AttributeSchema) (*domain.AttributeSchema, error)
+}
+
+type VariantGroupRepository interface {
+	Create(ctx context.Context, group *domain.VariantGroup) (*domain.VariantGroup, error)
+	GetByID(ctx context.Context, id int64) (*domain.VariantGroup, error)
+	GetForUpdate(ctx context.Context, id int64) (*domain.VariantGroup, error)
+	List(ctx context.Context) ([]*domain.VariantGroup, error)
+	ListProducts(ctx context.Context, id int64) ([]*domain.Product, error)
+}
+
+type PriceRepository interface {
+	Record(ctx context.Context, productID int64, price domain.Money) error
+	Schedule(ctx context.Context, price *domain.ProductPrice) (*domain.ProductPrice, error)
+	ListByProduct(ctx context.Context, productID int64) ([]*domain.ProductPrice, error)
+	GetEffective(ctx context.Context, productID int64, at time.Time) (*domain.ProductPrice, error)
+	GetDueForUpdate(ctx context.Context, retryDelay time.Duration, maxAttempts int) (*domain.ProductPrice, error)
+	MarkApplied(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64) (int, error)
+	DeleteScheduled(ctx context.Context, productID int64, id int64) error
+}
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error)
+	GetByID(ctx context.Context, id int64) (*domain.Promotion, error)
+	List(ctx context.Context, activeAt *time.Time) ([]*domain.Promotion, error)
+	Delete(ctx context.Context, id int64) error
+	ListApplicable(ctx context.Context, productIDs []int64, at time.Time) ([]PromotionMatch, error)
+}
+
+type StoreRepository interface {
+	Create(ctx context.Context, store *domain.Store) (*domain.Store, error)
+	GetByID(ctx context.Context, id int64) (*domain.Store, error)
+	List(ctx context.Context) ([]*domain.Store, error)
+	SetProduct(ctx context.Context, product *domain.StoreProduct) (*domain.StoreProduct, error)
+	DeleteProduct(ctx context.Context, storeID int64, productID int64) error
+	GetProducts(ctx context.Context, storeID int64, productIDs []int64) ([]*domain.StoreProduct, error)
+	ListProducts(ctx context.Context, storeID int64) ([]*domain.StoreProduct, error)
+	ListProductStoreIDs(ctx context.Context, productID int64) ([]int64, error)
+}
+
+type StockRepository interface {
+	GetLevel(ctx context.Context, storeID int64, productID int64) (*domain.StockLevel, error)
+	GetLevels(ctx context.Context, storeID int64, productIDs []int64) ([]*domain.StockLevel, error)
+	ListLevels(ctx context.Context, storeID int64) ([]*domain.StockLevel, error)
+	GetLevelForUpdate(ctx context.Context, storeID int64, productID int64) (*domain.StockLevel, error)
+	SetOnHand(ctx context.Context, storeID int64, productID int64, onHand int64) error
+	AddOnHand(ctx context.Context, storeID int64, productID int64, delta int64) error
+	CreateReservation(ctx context.Context, reservation *domain.StockReservation) (*domain.StockReservation, error)
+	GetReservationForUpdate(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error)
+	UpdateReservationStatus(ctx context.Context, id uuid.UUID, status domain.ReservationStatus) (*domain.StockReservation, error)
+}
+
+type ImageMetaRepository interface {
+	CreateBatch(ctx context.Context, images []domain.ImageMeta) error
+	ListByProduct(ctx context.Context, productID int64) ([]*domain.ImageMeta, error)
+	Delete(ctx context.Context, productID int64, id string) (*domain.ImageMeta, error)
+	Reassign(ctx context.Context, fromID int64, toID int64) error
 }
 
 type ImageRepository interface {
@@ -23,16 +124,37 @@ type ImageRepository interface {
 type EmbeddingRepository interface {
 	Upsert(ctx context.Context, vectors []domain.Embedding) ([]domain.Embedding, error)
 	Delete(ctx context.Context, vectors []domain.Embedding) error
+	Get(ctx context.Context, id string) (*domain.Embedding, error)
+	GetByProduct(ctx context.Context, productID int64) ([]domain.Embedding, error)
+	CountByProduct(ctx context.Context, productID int64) (int, error)
+	DeleteByProduct(ctx context.Context, productID int64) error
+	SetStatus(ctx context.Context, productID int64, status domain.ProductStatus) error
+	SetStores(ctx context.Context, productID int64, storeIDs []int64) error
+	SetVariant(ctx context.Context, productID int64, attrs domain.Attributes, variantGroupID *int64) error
+	Search(ctx context.Context, vector []float32, limit uint64, productIDs []int64, storeID *int64, attrs domain.Attributes) ([]domain.SearchHit, error)
+	SearchBatch(ctx context.Context, vectors [][]float32, limit uint64, productIDs []int64, storeID *int64, attrs domain.Attributes) ([][]domain.SearchHit, error)
 }
 
 type CacheRepository interface {
 	GetProducts(ctx context.Context, ids []int64) (map[int64]ProductInfo, error)
 	SetProducts(ctx context.Context, products []ProductInfo) error
 	DeleteProducts(ctx context.Context, ids []int64) error
+	GetStoreProducts(ctx context.Context, storeID int64, ids []int64) (map[int64]domain.StoreProduct, error)
+	SetStoreProducts(ctx context.Context, products []domain.StoreProduct) error
+	DeleteStoreProducts(ctx context.Context, storeID int64, ids []int64) error
+	GetBarcodeProductID(ctx context.Context, barcode string) (int64, bool, error)
+	SetBarcodeProductID(ctx context.Context, barcode string, productID int64) error
+}
+
+type CheckoutRepository interface {
+	Create(ctx context.Context, session *domain.CheckoutSession) error
+	Get(ctx context.Context, id string) (*domain.CheckoutSession, error)
+	Update(ctx context.Context, session *domain.CheckoutSession) error
 }
 
 type OutboxRepository interface {
 	Create(ctx context.Context, event *OutboxEvent) (*OutboxEvent, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) (*OutboxEvent, error)
 	GetAndMarkAsProcessing(ctx context.Context, limit int) ([]*OutboxEvent, error)
 	MarkAsProcessed(ctx context.Context, id int64) error
 }
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/cfg"
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// StockUseCase реализует учёт остатков товаров в магазинах: инвентаризацию, списание и резервы.
+// Каждое изменение остатка публикуется через outbox событием stock_changed.
+type StockUseCase struct {
+	stockRepo  StockRepository
+	storeRepo  StoreRepository
+	outboxRepo OutboxRepository
+	producer   MessageProducer
+	dbPool     transaction.Transactional
+	cfg        *cfg.StockCfg
+	logger     logger.Logger
+}
+
+func NewStockUC(
+	stockRepo StockRepository,
+	storeRepo StoreRepository,
+	outboxRepo OutboxRepository,
+	producer MessageProducer,
+	dbPool transaction.Transactional,
+	cfg *cfg.StockCfg,
+	logger logger.Logger,
+) *StockUseCase {
+	return &StockUseCase{
+		stockRepo:  stockRepo,
+		storeRepo:  storeRepo,
+		outboxRepo: outboxRepo,
+		producer:   producer,
+		dbPool:     dbPool,
+		cfg:        cfg,
+		logger:     logger,
+	}
+}
+
+// ListStock возвращает остатки магазина.
+func (s *StockUseCase) ListStock(ctx context.Context, storeID int64) ([]*domain.StockLevel, error) {
+	const op = "StockUseCase.ListStock"
+
+	if _, err := s.storeRepo.GetByID(ctx, storeID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	levels, err := s.stockRepo.ListLevels(ctx, storeID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return levels, nil
+}
+
+// GetStock возвращает остаток товара в магазине.
+func (s *StockUseCase) GetStock(ctx context.Context, storeID int64, productID int64) (*domain.StockLevel, error) {
+	const op = "StockUseCase.GetStock"
+
+	if storeID <= 0 || productID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	level, err := s.stockRepo.GetLevel(ctx, storeID, productID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return level, nil
+}
+
+// SetStock задаёт фактический остаток товара в магазине, например по результатам инвентаризации или приёмки.
+// Для товара без учёта остатка учёт начинается.
+func (s *StockUseCase) SetStock(ctx context.Context, storeID int64, productID int64, onHand int64) (*StockChangeRes, error) {
+	const op = "StockUseCase.SetStock"
+
+	if storeID <= 0 || productID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	if onHand < 0 || onHand > domain.MaxStockQuantity {
+		return nil, e.Wrap(op, e.ErrInvalidQuantity)
+	}
+
+	res, err := s.changeStock(ctx, func(ctx context.Context) (*domain.StockChange, *domain.StockReservation, error) {
+		var prevOnHand int64
+		prev, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, productID)
+		switch {
+		case err == nil:
+			prevOnHand = prev.OnHand
+		case !errors.Is(err, e.ErrStockNotFound):
+			return nil, nil, err
+		}
+
+		if err := s.stockRepo.SetOnHand(ctx, storeID, productID, onHand); err != nil {
+			return nil, nil, err
+		}
+
+		level, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, productID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return domain.NewStockChange(*level, onHand-prevOnHand, domain.StockSet, nil), nil, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	s.logger.Infof("Stock set. store_id: %d, product_id: %d, on_hand: %d", storeID, productID, onHand)
+
+	return res, nil
+}
+
+// DecrementStock списывает доступный остаток без резерва, например при продаже.
+func (s *StockUseCase) DecrementStock(ctx context.Context, storeID int64, productID int64, quantity int64) (*StockChangeRes, error) {
+	const op = "StockUseCase.DecrementStock"
+
+	if storeID <= 0 || productID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	if err := domain.ValidateStockQuantity(quantity); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := s.changeStock(ctx, func(ctx context.Context) (*domain.StockChange, *domain.StockReservation, error) {
+		level, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, productID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if level.Available() < quantity {
+			return nil, nil, e.ErrInsufficientStock
+		}
+
+		if err := s.stockRepo.AddOnHand(ctx, storeID, productID, -quantity); err != nil {
+			return nil, nil, err
+		}
+
+		level, err = s.stockRepo.GetLevelForUpdate(ctx, storeID, productID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return domain.NewStockChange(*level, -quantity, domain.StockDecrement, nil), nil, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ReserveStock резервирует доступный остаток на время из конфигурации.
+// Резерв, не подтверждённый и не отменённый за это время, перестаёт учитываться.
+func (s *StockUseCase) ReserveStock(ctx context.Context, storeID int64, productID int64, quantity int64) (*StockChangeRes, error) {
+	const op = "StockUseCase.ReserveStock"
+
+	if storeID <= 0 || productID <= 0 {
+		return nil, e.Wrap(op, e.ErrInvalidID)
+	}
+
+	if err := domain.ValidateStockQuantity(quantity); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	res, err := s.changeStock(ctx, func(ctx context.Context) (*domain.StockChange, *domain.StockReservation, error) {
+		level, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, productID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if level.Available() < quantity {
+			return nil, nil, e.ErrInsufficientStock
+		}
+
+		expiresAt := time.Now().UTC().Add(s.cfg.ReservationTTL)
+		reservation, err := s.stockRepo.CreateReservation(ctx, domain.NewStockReservation(storeID, productID, quantity, expiresAt))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		level, err = s.stockRepo.GetLevelForUpdate(ctx, storeID, productID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return domain.NewStockChange(*level, 0, domain.StockReserve, &reservation.ID), reservation, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// CommitReservation списывает зарезервированное кол-во с остатка. Просроченный резерв подтвердить нельзя.
+func (s *StockUseCase) CommitReservation(ctx context.Context, storeID int64, id uuid.UUID) (*StockChangeRes, error) {
+	const op = "StockUseCase.CommitReservation"
+
+	res, err := s.changeStock(ctx, func(ctx context.Context) (*domain.StockChange, *domain.StockReservation, error) {
+		reservation, err := s.getReservationForUpdate(ctx, storeID, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := reservation.CheckActive(time.Now().UTC()); err != nil {
+			return nil, nil, err
+		}
+
+		if _, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, reservation.ProductID); err != nil {
+			return nil, nil, err
+		}
+
+		if err := s.stockRepo.AddOnHand(ctx, storeID, reservation.ProductID, -reservation.Quantity); err != nil {
+			return nil, nil, err
+		}
+
+		reservation, err = s.stockRepo.UpdateReservationStatus(ctx, id, domain.ReservationCommitted)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		level, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, reservation.ProductID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return domain.NewStockChange(*level, -reservation.Quantity, domain.StockCommit, &reservation.ID), reservation, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// ReleaseReservation отменяет активный резерв, в том числе просроченный.
+func (s *StockUseCase) ReleaseReservation(ctx context.Context, storeID int64, id uuid.UUID) (*StockChangeRes, error) {
+	const op = "StockUseCase.ReleaseReservation"
+
+	res, err := s.changeStock(ctx, func(ctx context.Context) (*domain.StockChange, *domain.StockReservation, error) {
+		reservation, err := s.getReservationForUpdate(ctx, storeID, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if reservation.Status != domain.ReservationActive {
+			return nil, nil, e.ErrReservationClosed
+		}
+
+		if _, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, reservation.ProductID); err != nil {
+			return nil, nil, err
+		}
+
+		reservation, err = s.stockRepo.UpdateReservationStatus(ctx, id, domain.ReservationReleased)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		level, err := s.stockRepo.GetLevelForUpdate(ctx, storeID, reservation.ProductID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return domain.NewStockChange(*level, 0, domain.StockRelease, &reservation.ID), reservation, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return res, nil
+}
+
+// getReservationForUpdate блокирует резерв магазина. Резерв другого магазина считается ненайденным.
+func (s *StockUseCase) getReservationForUpdate(ctx context.Context, storeID int64, id uuid.UUID) (*domain.StockReservation, error) {
+	reservation, err := s.stockRepo.GetReservationForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.StoreID != storeID {
+		return nil, e.ErrReservationNotFound
+	}
+
+	return reservation, nil
+}
+
+// changeStock применяет изменение остатка в транзакции и публикует событие stock_changed через outbox.
+// Изменение блокирует остаток товара, поэтому операции с одним товаром в магазине выполняются последовательно.
+func (s *StockUseCase) changeStock(
+	ctx context.Context,
+	change func(ctx context.Context) (*domain.StockChange, *domain.StockReservation, error),
+) (*StockChangeRes, error) {
+	var err error
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, s.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	stockChange, reservation, err := change(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID := uuid.New()
+	payload, err := s.producer.GetStockPayloadBytes(NewStockChangedMessageReq(eventID, stockChange))
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.outboxRepo.Create(ctx, NewOutboxEvent(eventID, stockChange.Level.ProductID, StockEvent, payload))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStockChangeRes(&stockChange.Level, reservation, event), nil
+}
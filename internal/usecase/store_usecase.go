@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/DRSN-tech/go-backend/pkg/e"
+	"github.com/DRSN-tech/go-backend/pkg/logger"
+	transaction "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// StoreUseCase реализует управление магазинами и их ассортиментом. Ассортимент магазина
+// ограничивает выдачу GetProductsInfo и распознавание в магазине через payload store_ids векторов.
+type StoreUseCase struct {
+	storeRepo     StoreRepository
+	productRepo   ProductRepository
+	embeddingRepo EmbeddingRepository
+	cacheRepo     CacheRepository
+	outboxRepo    OutboxRepository
+	producer      MessageProducer
+	dbPool        transaction.Transactional
+	logger        logger.Logger
+}
+
+func NewStoreUC(
+	storeRepo StoreRepository,
+	productRepo ProductRepository,
+	embeddingRepo EmbeddingRepository,
+	cacheRepo CacheRepository,
+	outboxRepo OutboxRepository,
+	producer MessageProducer,
+	dbPool transaction.Transactional,
+	logger logger.Logger,
+) *StoreUseCase {
+	return &StoreUseCase{
+		storeRepo:     storeRepo,
+		productRepo:   productRepo,
+		embeddingRepo: embeddingRepo,
+		cacheRepo:     cacheRepo,
+		outboxRepo:    outboxRepo,
+		producer:      producer,
+		dbPool:        dbPool,
+		logger:        logger,
+	}
+}
+
+// CreateStore создаёт магазин с уникальным кодом.
+func (s *StoreUseCase) CreateStore(ctx context.Context, store *domain.Store) (*domain.Store, error) {
+	const op = "StoreUseCase.CreateStore"
+
+	var err error
+	if err = store.Validate(); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, s.dbPool)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+	defer func() {
+		if err != nil && tx.IsActive() {
+			tx.Rollback(ctx)
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	created, err := s.storeRepo.Create(ctx, store)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	s.logger.Infof("Store created. store_id: %d, code: %s", created.ID, created.Code)
+
+	return created, nil
+}
+
+// GetStore возвращает магазин по ID.
+func (s *StoreUseCase) GetStore(ctx context.Context, id int64) (*domain.Store, error) {
+	const op = "StoreUseCase.GetStore"
+
+	store, err := s.storeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return store, nil
+}
+
+// ListStores возвращает все магазины.
+func (s *StoreUseCase) ListStores(ctx context.Context) ([]*domain.Store, error) {
+	const op = "StoreUseCase.ListStores"
+
+	stores, err := s.storeRepo.List(ctx)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return stores, nil
+}
+
+// ListStoreProducts возвращает ассортимент магазина.
+func (s *StoreUseCase) ListStoreProducts(ctx context.Context, storeID int64) ([]*domain.StoreProduct, error) {
+	const op = "StoreUseCase.ListStoreProducts"
+
+	if _, err := s.storeRepo.GetByID(ctx, storeID); err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	products, err := s.storeRepo.ListProducts(ctx, storeID)
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return products, nil
+}
+
+// SetStoreProduct добавляет продукт в ассортимент магазина или изменяет его доступность и цену в магазине.
+func (s *StoreUseCase) SetStoreProduct(ctx context.Context, product *domain.StoreProduct) (*SetStoreProductRes, error) {
+	const op = "StoreUseCase.SetStoreProduct"
+
+	if product.Price != nil {
+		if err := product.Price.Validate(); err != nil {
+			return nil, e.Wrap(op, err)
+		}
+	}
+
+	var saved *domain.StoreProduct
+	event, err := s.changeAssortment(ctx, product.StoreID, product.ProductID, func(ctx context.Context) (*StoreAssortment, error) {
+		var err error
+		saved, err = s.storeRepo.SetProduct(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+
+		return &StoreAssortment{Product: *saved}, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return NewSetStoreProductRes(saved, event), nil
+}
+
+// RemoveStoreProduct исключает продукт из ассортимента магазина.
+func (s *StoreUseCase) RemoveStoreProduct(ctx context.Context, storeID int64, productID int64) (*OutboxEvent, error) {
+	const op = "StoreUseCase.RemoveStoreProduct"
+
+	event, err := s.changeAssortment(ctx, storeID, productID, func(ctx context.Context) (*StoreAssortment, error) {
+		if err := s.storeRepo.DeleteProduct(ctx, storeID, productID); err != nil {
+			return nil, err
+		}
+
+		return &StoreAssortment{Product: *domain.NewStoreProduct(storeID, productID, false, nil), Removed: true}, nil
+	})
+	if err != nil {
+		return nil, e.Wrap(op, err)
+	}
+
+	return event, nil
+}
+
+// changeAssortment применяет изменение ассортимента в транзакции, обновляет список магазинов у векторов
+// продукта в Qdrant и публикует событие через outbox. Продукт блокируется, чтобы векторы,
+// добавляемые параллельно, получили актуальный список магазинов.
+func (s *StoreUseCase) changeAssortment(
+	ctx context.Context,
+	storeID int64,
+	productID int64,
+	change func(ctx context.Context) (*StoreAssortment, error),
+) (*OutboxEvent, error) {
+	if storeID <= 0 || productID <= 0 {
+		return nil, e.ErrInvalidID
+	}
+
+	if _, err := s.storeRepo.GetByID(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	var (
+		err          error
+		prevStoreIDs []int64
+		flagged      bool
+	)
+
+	ctx, tx, err := transaction.NewTransaction(ctx, pgx.TxOptions{}, s.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	// Если произошла ошибка, происходит Rollback транзакции и возврат списка магазинов у векторов
+	defer func() {
+		if err != nil {
+			if tx.IsActive() {
+				tx.Rollback(ctx)
+			}
+
+			if flagged {
+				if err := s.embeddingRepo.SetStores(ctx, productID, prevStoreIDs); err != nil {
+					s.logger.Warnf("Failed to restore Qdrant points stores. product_id: %d, error: %v", productID, err)
+				}
+			}
+		}
+	}()
+	ctx = context.WithValue(ctx, "tx", tx.Transaction())
+
+	product, err := s.productRepo.GetForUpdate(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	prevStoreIDs, err = s.storeRepo.ListProductStoreIDs(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	assortment, err := change(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	storeIDs, err := s.storeRepo.ListProductStoreIDs(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Equal(prevStoreIDs, storeIDs) {
+		if err = s.embeddingRepo.SetStores(ctx, productID, storeIDs); err != nil {
+			return nil, err
+		}
+		flagged = true
+	}
+
+	payload, err := s.producer.GetPayloadBytes(NewStoreAssortmentMessageReq(productID, product.Version, assortment))
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.outboxRepo.Create(ctx, NewOutboxEvent(uuid.New(), productID, ProductEvent, payload))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheRepo.DeleteStoreProducts(ctx, storeID, []int64{productID}); err != nil {
+		s.logger.Warnf("Failed to delete store products from cache: %v", err)
+	}
+
+	return event, nil
+}
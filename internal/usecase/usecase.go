@@ -1,8 +1,92 @@
 package usecase
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/DRSN-tech/go-backend/internal/domain"
+	"github.com/google/uuid"
+)
 
 type ProductUC interface {
-	RegisterNewProduct(ctx context.Context, req *AddNewProductReq) (*OutboxEvent, error)
+	RegisterNewProduct(ctx context.Context, req *AddNewProductReq) (*RegisterProductRes, error)
 	GetProductsInfo(ctx context.Context, req *GetProductsReq) (*GetProductsRes, error)
+	GetProduct(ctx context.Context, id int64) (*ProductDetails, error)
+	GetProductByBarcode(ctx context.Context, barcode string) (*ProductInfo, error)
+	ListProducts(ctx context.Context, req *ListProductsReq) (*ListProductsRes, error)
+	UpdateProduct(ctx context.Context, req *UpdateProductReq) (*UpdateProductRes, error)
+	AddProductImages(ctx context.Context, req *AddProductImagesReq) (*AddProductImagesRes, error)
+	DeleteProductImage(ctx context.Context, productID int64, imageID string) (*OutboxEvent, error)
+	ArchiveProduct(ctx context.Context, id int64) (*OutboxEvent, error)
+	UnarchiveProduct(ctx context.Context, id int64) (*OutboxEvent, error)
+	SubmitProduct(ctx context.Context, req *ProductTransitionReq) (*ProductTransitionRes, error)
+	ApproveProduct(ctx context.Context, req *ProductTransitionReq) (*ProductTransitionRes, error)
+	RejectProduct(ctx context.Context, req *ProductTransitionReq) (*ProductTransitionRes, error)
+	ListReviewQueue(ctx context.Context, limit int, cursor string) (*ListProductsRes, error)
+	GetProductTransitions(ctx context.Context, id int64) ([]*domain.ProductTransition, error)
+	MergeProducts(ctx context.Context, req *MergeProductsReq) (*MergeProductsRes, error)
+	GetDuplicateFlags(ctx context.Context, id int64) ([]*domain.DuplicateFlag, error)
+	DeleteProduct(ctx context.Context, id int64) (*OutboxEvent, error)
+	GetPriceHistory(ctx context.Context, productID int64) ([]*domain.ProductPrice, error)
+	GetEffectivePrice(ctx context.Context, productID int64, at time.Time) (*domain.ProductPrice, error)
+	SchedulePrice(ctx context.Context, req *SchedulePriceReq) (*domain.ProductPrice, error)
+	CancelScheduledPrice(ctx context.Context, productID int64, priceID int64) error
+	ApplyDuePrices(ctx context.Context, limit int) (int, error)
+	PriceByWeight(ctx context.Context, req *PriceByWeightReq) (*WeightedPrice, error)
+	RecognizeProduct(ctx context.Context, req *RecognizeProductReq) (*RecognizeProductRes, error)
+	CreateVariantGroup(ctx context.Context, group *domain.VariantGroup) (*domain.VariantGroup, error)
+	GetVariantGroup(ctx context.Context, id int64) (*VariantGroupDetails, error)
+	ListVariantGroups(ctx context.Context) ([]*domain.VariantGroup, error)
+	NewRecognitionTracker() *RecognitionTracker
+}
+
+type CategoryUC interface {
+	CreateCategory(ctx context.Context, req *CreateCategoryReq) (*domain.Category, error)
+	GetCategory(ctx context.Context, id int64) (*CategoryDetails, error)
+	ListCategories(ctx context.Context, archived *bool) ([]CategoryDetails, error)
+	RenameCategory(ctx context.Context, id int64, name string) (*domain.Category, error)
+	ArchiveCategory(ctx context.Context, id int64) (*domain.Category, error)
+	MoveCategory(ctx context.Context, id int64, parentID *int64) (*domain.Category, error)
+	UnarchiveCategory(ctx context.Context, id int64) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, id int64) error
+	GetAttributeSchema(ctx context.Context, id int64) (*AttributeSchemaDetails, error)
+	SetAttributeSchema(ctx context.Context, schema *domain.AttributeSchema) (*AttributeSchemaDetails, error)
+}
+
+type CheckoutUC interface {
+	CreateCheckout(ctx context.Context) (*CheckoutDetails, error)
+	GetCheckout(ctx context.Context, id string) (*CheckoutDetails, error)
+	AddCheckoutItem(ctx context.Context, req *AddCheckoutItemReq) (*CheckoutDetails, error)
+	AddRecognizedItem(ctx context.Context, req *AddRecognizedItemReq) (*AddRecognizedItemRes, error)
+	UpdateCheckoutLine(ctx context.Context, req *UpdateCheckoutLineReq) (*CheckoutDetails, error)
+	RemoveCheckoutLine(ctx context.Context, sessionID string, lineID string) (*CheckoutDetails, error)
+	CompleteCheckout(ctx context.Context, id string) (*CompleteCheckoutRes, error)
+}
+
+type PromotionUC interface {
+	CreatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error)
+	GetPromotion(ctx context.Context, id int64) (*domain.Promotion, error)
+	ListPromotions(ctx context.Context, activeAt *time.Time) ([]*domain.Promotion, error)
+	DeletePromotion(ctx context.Context, id int64) error
+	EvaluatePromotions(ctx context.Context, req *EvaluatePromotionsReq) (*DiscountedCart, error)
+	EvaluateCheckout(ctx context.Context, checkoutID string) (*DiscountedCart, error)
+}
+
+type StoreUC interface {
+	CreateStore(ctx context.Context, store *domain.Store) (*domain.Store, error)
+	GetStore(ctx context.Context, id int64) (*domain.Store, error)
+	ListStores(ctx context.Context) ([]*domain.Store, error)
+	ListStoreProducts(ctx context.Context, storeID int64) ([]*domain.StoreProduct, error)
+	SetStoreProduct(ctx context.Context, product *domain.StoreProduct) (*SetStoreProductRes, error)
+	RemoveStoreProduct(ctx context.Context, storeID int64, productID int64) (*OutboxEvent, error)
+}
+
+type StockUC interface {
+	ListStock(ctx context.Context, storeID int64) ([]*domain.StockLevel, error)
+	GetStock(ctx context.Context, storeID int64, productID int64) (*domain.StockLevel, error)
+	SetStock(ctx context.Context, storeID int64, productID int64, onHand int64) (*StockChangeRes, error)
+	DecrementStock(ctx context.Context, storeID int64, productID int64, quantity int64) (*StockChangeRes, error)
+	ReserveStock(ctx context.Context, storeID int64, productID int64, quantity int64) (*StockChangeRes, error)
+	CommitReservation(ctx context.Context, storeID int64, id uuid.UUID) (*StockChangeRes, error)
+	ReleaseReservation(ctx context.Context, storeID int64, id uuid.UUID) (*StockChangeRes, error)
 }
@@ -50,5 +50,24 @@ func (q *QdrantClient) EnsureCollection(ctx context.Context) error {
 		}
 	}
 
+	// Индексы payload для фильтрации по продукту, признаку архивации, ассортименту магазина, группе вариантов и статусу продукта.
+	// Атрибуты не индексируются: их коды задаются схемами категорий, а фильтр по ним применяется вместе с векторным поиском.
+	indexes := map[string]qdrant.FieldType{
+		"product_id":       qdrant.FieldType_FieldTypeInteger,
+		"is_archived":      qdrant.FieldType_FieldTypeBool,
+		"store_ids":        qdrant.FieldType_FieldTypeInteger,
+		"variant_group_id": qdrant.FieldType_FieldTypeInteger,
+		"status":           qdrant.FieldType_FieldTypeKeyword,
+	}
+	for field, fieldType := range indexes {
+		if _, err := q.Client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+			CollectionName: q.cfg.QdrantCollectionName,
+			FieldName:      field,
+			FieldType:      qdrant.PtrOf(fieldType),
+		}); err != nil {
+			return fmt.Errorf("failed to create payload index %s: %w", field, err)
+		}
+	}
+
 	return nil
 }
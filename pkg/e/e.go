@@ -6,33 +6,127 @@ import (
 
 var (
 	// 500 Internal Server Error
-	ErrInternalServerError  = fmt.Errorf("internal server error")
-	ErrIncorrectEnvVariable = fmt.Errorf("incorrect environment variable")
+	ErrInternalServerError  = newError("internal_server_error", "internal server error")
+	ErrIncorrectEnvVariable = newError("incorrect_env_variable", "incorrect environment variable")
 
 	// Транзакции
-	ErrTransactionNotFound = fmt.Errorf("transaction not found")
+	ErrTransactionNotFound = newError("transaction_not_found", "transaction not found")
 
 	// Векторы
-	ErrEmptyVectors         = fmt.Errorf("empty vectors")
-	ErrVectorEmbeddingEmpty = fmt.Errorf("vector embedding is empty")
-	ErrImageVectorMismatch  = fmt.Errorf("image vector mismatch")
+	ErrEmptyVectors         = newError("empty_vectors", "empty vectors")
+	ErrVectorEmbeddingEmpty = newError("vector_embedding_empty", "vector embedding is empty")
+	ErrImageVectorMismatch  = newError("image_vector_mismatch", "image vector mismatch")
+
+	// 404 Not Found
+	ErrProductNotFound      = newError("product_not_found", "product not found")
+	ErrImageNotFound        = newError("image_not_found", "image not found")
+	ErrCategoryNotFound     = newError("category_not_found", "category not found")
+	ErrPriceNotFound        = newError("price_not_found", "price not found")
+	ErrCheckoutNotFound     = newError("checkout_not_found", "checkout session not found")
+	ErrLineNotFound         = newError("line_not_found", "checkout line not found")
+	ErrPromotionNotFound    = newError("promotion_not_found", "promotion not found")
+	ErrStoreNotFound        = newError("store_not_found", "store not found")
+	ErrStockNotFound        = newError("stock_not_found", "stock is not tracked for product in store")
+	ErrReservationNotFound  = newError("reservation_not_found", "stock reservation not found")
+	ErrVariantGroupNotFound = newError("variant_group_not_found", "variant group not found")
+	ErrEventNotFound        = newError("event_not_found", "outbox event not found")
+
+	// 409 Conflict
+	ErrProductNameTaken      = newError("product_name_taken", "product name already taken")
+	ErrVersionMismatch       = newError("version_mismatch", "product version mismatch")
+	ErrSKUTaken              = newError("sku_taken", "sku already taken")
+	ErrBarcodeTaken          = newError("barcode_taken", "barcode already assigned to another product")
+	ErrCategoryNameTaken     = newError("category_name_taken", "category name already taken")
+	ErrCategoryHasProducts   = newError("category_has_products", "category has products")
+	ErrCategoryArchived      = newError("category_archived", "category is archived")
+	ErrCategoryHasChildren   = newError("category_has_children", "category has subcategories")
+	ErrCategoryCycle         = newError("category_cycle", "category cannot be moved into its own subtree")
+	ErrCheckoutCompleted     = newError("checkout_completed", "checkout session is already completed")
+	ErrCheckoutConflict      = newError("checkout_conflict", "checkout session was modified concurrently")
+	ErrCheckoutEmpty         = newError("checkout_empty", "checkout session has no lines")
+	ErrTooManyLines          = newError("too_many_lines", "too many checkout lines")
+	ErrCurrencyMismatch      = newError("currency_mismatch", "checkout lines have different currencies")
+	ErrLinesUnavailable      = newError("lines_unavailable", "checkout has unavailable products")
+	ErrStoreCodeTaken        = newError("store_code_taken", "store code already taken")
+	ErrStoreMismatch         = newError("store_mismatch", "checkout session belongs to another store")
+	ErrInsufficientStock     = newError("insufficient_stock", "insufficient stock")
+	ErrReservationClosed     = newError("reservation_closed", "stock reservation is already committed or released")
+	ErrReservationExpired    = newError("reservation_expired", "stock reservation has expired")
+	ErrVariantGroupNameTaken = newError("variant_group_name_taken", "variant group name already taken")
+	ErrVariantConflict       = newError("variant_conflict", "variant group already has a product with the same axis values")
+	ErrInvalidTransition     = newError("invalid_status_transition", "product status does not allow this transition")
+	ErrProductMerged         = newError("product_merged", "product has been merged into another product")
+	ErrMergeTargetArchived   = newError("merge_target_archived", "cannot merge into an archived product")
+	ErrPossibleDuplicate     = newError("possible_duplicate", "product images match existing products")
+
+	// 428 Precondition Required
+	ErrVersionRequired = newError("version_required", "product version is required")
 
 	// 400 Bad Request
-	ErrProductNameRequired  = fmt.Errorf("product name is required")
-	ErrPriceMustBePositive  = fmt.Errorf("price must be positive")
-	ErrNoImages             = fmt.Errorf("no images provided")
-	ErrUnsupportedMediaType = fmt.Errorf("unsupported media type")
-	ErrNoProducts           = fmt.Errorf("no products provided")
-	ErrStatusBadRequest     = fmt.Errorf("status bad request")
-	ErrExpectedMultipart    = fmt.Errorf("expected multipart/form-data")
-	ErrMissingFields        = fmt.Errorf("missing fields")
-	ErrInvalidPrice         = fmt.Errorf("invalid price")
-	ErrPricePrecision       = fmt.Errorf("price must have at most 2 decimal places")
-	ErrTooManyImages        = fmt.Errorf("too many images (max 10)")
-	ErrFileTooLarge         = fmt.Errorf("file too large")
-	ErrNoChanges            = fmt.Errorf("no changes")
+	ErrProductNameRequired    = newError("product_name_required", "product name is required")
+	ErrPriceMustBePositive    = newError("price_must_be_positive", "price must be positive")
+	ErrNoImages               = newError("no_images", "no images provided")
+	ErrUnsupportedMediaType   = newError("unsupported_media_type", "unsupported media type")
+	ErrNoProducts             = newError("no_products", "no products provided")
+	ErrStatusBadRequest       = newError("bad_request", "status bad request")
+	ErrExpectedMultipart      = newError("expected_multipart", "expected multipart/form-data")
+	ErrMissingFields          = newError("missing_fields", "missing fields")
+	ErrInvalidPrice           = newError("invalid_price", "invalid price")
+	ErrPricePrecision         = newError("price_precision", "price has more decimal places than its currency allows")
+	ErrTooManyImages          = newError("too_many_images", "too many images (max 10)")
+	ErrFileTooLarge           = newError("file_too_large", "file too large")
+	ErrNoChanges              = newError("no_changes", "no changes")
+	ErrInvalidLimit           = newError("invalid_limit", "invalid limit")
+	ErrTooManyFrames          = newError("too_many_frames", "too many frames")
+	ErrInvalidFusion          = newError("invalid_fusion", "invalid fusion strategy")
+	ErrInvalidID              = newError("invalid_id", "invalid id")
+	ErrInvalidVersion         = newError("invalid_version", "invalid version")
+	ErrCategoryNameRequired   = newError("category_name_required", "category name is required")
+	ErrInvalidJSON            = newError("invalid_json", "invalid json body")
+	ErrInvalidCursor          = newError("invalid_cursor", "invalid cursor")
+	ErrInvalidSort            = newError("invalid_sort", "invalid sort")
+	ErrInvalidFilter          = newError("invalid_filter", "invalid filter")
+	ErrPriceNotInFuture       = newError("price_not_in_future", "scheduled price must take effect in the future")
+	ErrUnsupportedCurrency    = newError("unsupported_currency", "unsupported currency")
+	ErrInvalidSKU             = newError("invalid_sku", "invalid sku")
+	ErrInvalidBarcode         = newError("invalid_barcode", "invalid barcode")
+	ErrInvalidUnit            = newError("invalid_unit", "invalid unit of measure")
+	ErrInvalidWeight          = newError("invalid_weight", "invalid weight")
+	ErrProductNotWeighted     = newError("product_not_weighted", "product is not sold by weight")
+	ErrInvalidQuantity        = newError("invalid_quantity", "invalid quantity")
+	ErrInvalidPromotion       = newError("invalid_promotion", "invalid promotion")
+	ErrInvalidStore           = newError("invalid_store", "invalid store")
+	ErrStoreRequired          = newError("store_required", "store is required")
+	ErrInvalidAttributeSchema = newError("invalid_attribute_schema", "invalid attribute schema")
+	ErrUnknownAttribute       = newError("unknown_attribute", "attribute is not defined by category schema")
+	ErrInvalidAttribute       = newError("invalid_attribute", "invalid attribute value")
+	ErrAttributeRequired      = newError("attribute_required", "required attribute is missing")
+	ErrInvalidVariantGroup    = newError("invalid_variant_group", "invalid variant group")
+	ErrVariantAxisMissing     = newError("variant_axis_missing", "product has no value for variant group axis")
+	ErrUnsupportedLocale      = newError("unsupported_locale", "unsupported locale")
+	ErrInvalidStatus          = newError("invalid_status", "invalid product status")
+	ErrInvalidReviewer        = newError("invalid_reviewer", "reviewer is missing or too long")
+	ErrReviewCommentRequired  = newError("review_comment_required", "comment is required to reject a product")
+	ErrInvalidReviewComment   = newError("invalid_review_comment", "review comment is too long")
+	ErrMergeSameProduct       = newError("merge_same_product", "cannot merge a product into itself")
+	ErrInvalidDuplicatePolicy = newError("invalid_duplicate_policy", "invalid duplicate policy")
 )
 
+// Error — ошибка с кодом, по которому клиенты API различают ошибки независимо от языка сообщения.
+// Message — сообщение на английском, переводы задаются по коду, см. Message.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func newError(code string, message string) error {
+	return &Error{Code: code, Message: message}
+}
+
 // Wrap оборачивает ошибку
 func Wrap(msg string, err error) error {
 	return fmt.Errorf("%s: %w", msg, err)
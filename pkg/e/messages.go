@@ -0,0 +1,136 @@
+package e
+
+import (
+	"errors"
+
+	"github.com/DRSN-tech/go-backend/pkg/locale"
+)
+
+// Code возвращает код ошибки err или ближайшей ошибки с кодом в её цепочке.
+// Ошибки без кода считаются внутренними.
+func Code(err error) string {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+
+	return ErrInternalServerError.(*Error).Code
+}
+
+// Message возвращает сообщение ошибки с кодом на языке lang. Для языка без перевода
+// и ошибок без кода возвращается сообщение на английском; контекст, добавленный Wrap, не включается.
+func Message(err error, lang string) string {
+	var coded *Error
+	if !errors.As(err, &coded) {
+		coded = ErrInternalServerError.(*Error)
+	}
+
+	if message, ok := translations[lang][coded.Code]; ok {
+		return message
+	}
+
+	return coded.Message
+}
+
+// translations — переводы сообщений об ошибках по кодам. Английские сообщения задаются в самих ошибках.
+var translations = map[string]map[string]string{
+	locale.Russian: {
+		"internal_server_error":  "Внутренняя ошибка сервера",
+		"incorrect_env_variable": "Некорректная переменная окружения",
+		"transaction_not_found":  "Транзакция не найдена",
+
+		"empty_vectors":          "Векторы не получены",
+		"vector_embedding_empty": "Пустой вектор",
+		"image_vector_mismatch":  "Количество изображений и векторов не совпадает",
+
+		"product_not_found":       "Товар не найден",
+		"image_not_found":         "Изображение не найдено",
+		"category_not_found":      "Категория не найдена",
+		"price_not_found":         "Цена не найдена",
+		"checkout_not_found":      "Корзина не найдена",
+		"line_not_found":          "Позиция корзины не найдена",
+		"promotion_not_found":     "Акция не найдена",
+		"store_not_found":         "Магазин не найден",
+		"stock_not_found":         "Остаток товара в магазине не учитывается",
+		"reservation_not_found":   "Резерв не найден",
+		"variant_group_not_found": "Группа вариантов не найдена",
+		"event_not_found":         "Событие не найдено",
+
+		"product_name_taken":        "Название товара уже занято",
+		"version_mismatch":          "Версия товара не совпадает",
+		"sku_taken":                 "Артикул уже занят",
+		"barcode_taken":             "Штрихкод назначен другому товару",
+		"category_name_taken":       "Название категории уже занято",
+		"category_has_products":     "В категории есть товары",
+		"category_archived":         "Категория архивирована",
+		"category_has_children":     "В категории есть подкатегории",
+		"category_cycle":            "Категорию нельзя перенести в её подкатегорию",
+		"checkout_completed":        "Корзина уже оформлена",
+		"checkout_conflict":         "Корзина была изменена параллельно",
+		"checkout_empty":            "В корзине нет позиций",
+		"too_many_lines":            "Слишком много позиций в корзине",
+		"currency_mismatch":         "Позиции корзины в разных валютах",
+		"lines_unavailable":         "В корзине есть недоступные товары",
+		"store_code_taken":          "Код магазина уже занят",
+		"store_mismatch":            "Корзина принадлежит другому магазину",
+		"insufficient_stock":        "Недостаточно товара на остатке",
+		"reservation_closed":        "Резерв уже списан или снят",
+		"reservation_expired":       "Срок резерва истёк",
+		"variant_group_name_taken":  "Название группы вариантов уже занято",
+		"variant_conflict":          "В группе уже есть товар с такими же значениями осей",
+		"invalid_status_transition": "Статус товара не допускает этот переход",
+		"product_merged":            "Товар объединён с другим товаром",
+		"merge_target_archived":     "Нельзя объединить товар с архивным товаром",
+		"possible_duplicate":        "Изображения товара похожи на изображения существующих товаров",
+
+		"version_required": "Не указана версия товара",
+
+		"product_name_required":    "Не указано название товара",
+		"price_must_be_positive":   "Цена должна быть положительной",
+		"no_images":                "Не переданы изображения",
+		"unsupported_media_type":   "Неподдерживаемый тип файла",
+		"no_products":              "Не переданы товары",
+		"bad_request":              "Некорректный запрос",
+		"expected_multipart":       "Ожидается multipart/form-data",
+		"missing_fields":           "Не заполнены обязательные поля",
+		"invalid_price":            "Некорректная цена",
+		"price_precision":          "В цене больше знаков после запятой, чем допускает валюта",
+		"too_many_images":          "Слишком много изображений (не более 10)",
+		"file_too_large":           "Файл слишком большой",
+		"no_changes":               "Нет изменений",
+		"invalid_limit":            "Некорректный limit",
+		"too_many_frames":          "Слишком много кадров",
+		"invalid_fusion":           "Некорректная стратегия объединения кадров",
+		"invalid_id":               "Некорректный идентификатор",
+		"invalid_version":          "Некорректная версия",
+		"category_name_required":   "Не указано название категории",
+		"invalid_json":             "Некорректное JSON-тело запроса",
+		"invalid_cursor":           "Некорректный курсор",
+		"invalid_sort":             "Некорректная сортировка",
+		"invalid_filter":           "Некорректный фильтр",
+		"price_not_in_future":      "Запланированная цена должна вступать в силу в будущем",
+		"unsupported_currency":     "Неподдерживаемая валюта",
+		"invalid_sku":              "Некорректный артикул",
+		"invalid_barcode":          "Некорректный штрихкод",
+		"invalid_unit":             "Некорректная единица измерения",
+		"invalid_weight":           "Некорректный вес",
+		"product_not_weighted":     "Товар не продаётся на вес",
+		"invalid_quantity":         "Некорректное количество",
+		"invalid_promotion":        "Некорректная акция",
+		"invalid_store":            "Некорректный магазин",
+		"store_required":           "Не указан магазин",
+		"invalid_attribute_schema": "Некорректная схема атрибутов",
+		"unknown_attribute":        "Атрибут не задан схемой категории",
+		"invalid_attribute":        "Некорректное значение атрибута",
+		"attribute_required":       "Не указан обязательный атрибут",
+		"invalid_variant_group":    "Некорректная группа вариантов",
+		"variant_axis_missing":     "У товара нет значения оси группы вариантов",
+		"unsupported_locale":       "Неподдерживаемый язык",
+		"invalid_status":           "Некорректный статус товара",
+		"invalid_reviewer":         "Не указан проверяющий или слишком длинное имя",
+		"review_comment_required":  "Для отклонения товара нужен комментарий",
+		"invalid_review_comment":   "Слишком длинный комментарий проверки",
+		"merge_same_product":       "Нельзя объединить товар с самим собой",
+		"invalid_duplicate_policy": "Недопустимое действие при вероятном дубликате",
+	},
+}
@@ -0,0 +1,72 @@
+package locale
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+// Поддерживаемые языки: сообщения об ошибках и локализованные названия продуктов задаются только для них
+const (
+	English = "en"
+	Russian = "ru"
+
+	// Default — язык сообщений об ошибках, если клиент не указал поддерживаемый язык
+	Default = Russian
+)
+
+// Supported — поддерживаемые языки в порядке предпочтения при равных весах
+var Supported = []string{English, Russian}
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Russian})
+
+type localeKey struct{}
+
+// WithLocale возвращает контекст с выбранным языком клиента
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromCtx извлекает язык клиента из контекста; false означает, что клиент не выбрал поддерживаемый язык
+func FromCtx(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok
+}
+
+// FromCtxOrDefault возвращает язык клиента из контекста или Default
+func FromCtxOrDefault(ctx context.Context) string {
+	if locale, ok := FromCtx(ctx); ok {
+		return locale
+	}
+	return Default
+}
+
+// Negotiate выбирает поддерживаемый язык по значению Accept-Language с учётом весов q,
+// например "ru-RU,ru;q=0.9,en;q=0.8" даёт ru. false означает, что ни один из языков клиента не поддерживается.
+func Negotiate(acceptLanguage string) (string, bool) {
+	if acceptLanguage == "" {
+		return "", false
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return "", false
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return "", false
+	}
+
+	return Supported[index], true
+}
+
+// IsSupported сообщает, поддерживается ли язык с кодом locale, например "ru"
+func IsSupported(locale string) bool {
+	for _, supported := range Supported {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
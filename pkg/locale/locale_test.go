@@ -0,0 +1,37 @@
+package locale
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+		ok             bool
+	}{
+		{"empty header", "", "", false},
+		{"single language", "en", English, true},
+		{"region subtag", "ru-RU", Russian, true},
+		{"region subtag of english", "en-GB", English, true},
+		{"uppercase tag", "RU", Russian, true},
+		{"q-weights order", "en;q=0.5,ru;q=0.9", Russian, true},
+		{"first listed wins at equal weights", "ru,en", Russian, true},
+		{"browser header", "ru-RU,ru;q=0.9,en;q=0.8", Russian, true},
+		{"unsupported language is skipped", "de-DE,de;q=0.9,en;q=0.5", English, true},
+		{"only unsupported languages", "de-DE,fr;q=0.8", "", false},
+		{"wildcard", "*", "", false},
+		{"zero weight excludes language", "ru;q=0", "", false},
+		{"malformed weight", "ru;q=abc", "", false},
+		{"malformed tag", "ru_RU!!", "", false},
+		{"separators only", ",;,", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Negotiate(tt.acceptLanguage)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Negotiate(%q) = %q, %t, want %q, %t", tt.acceptLanguage, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
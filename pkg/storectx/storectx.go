@@ -0,0 +1,16 @@
+package storectx
+
+import "context"
+
+type storeIDKey struct{}
+
+// WithStoreID возвращает контекст, ограниченный ассортиментом магазина
+func WithStoreID(ctx context.Context, storeID int64) context.Context {
+	return context.WithValue(ctx, storeIDKey{}, storeID)
+}
+
+// StoreIDFromCtx извлекает ID магазина из контекста; false означает запрос без магазина
+func StoreIDFromCtx(ctx context.Context) (int64, bool) {
+	storeID, ok := ctx.Value(storeIDKey{}).(int64)
+	return storeID, ok
+}